@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package blindschnorr implements blind Schnorr signatures over secp256k1, on top of the secp256k1 package's
+// arithmetic. The unblinded signatures are regular BIP-340 signatures for the x-only public key of the signer, and
+// can't be linked to the signing session that produced them.
+//
+// The protocol has three moves: the signer sends a nonce point from Commit, the user blinds it and the message with
+// Blind and sends the blinded challenge, and the signer answers with SignerNonce.Sign, which the user unblinds into
+// the final signature with Blinding.Unblind.
+//
+// Caveat: blind Schnorr signatures are only secure if the signer never runs concurrent sessions, i.e. it must finish
+// or abort a session before committing to the next nonce. With many concurrent sessions, the ROS attack (Benhamouda et
+// al., 2020) lets a user obtain one more valid signature than the number of completed sessions, in polynomial time.
+// Applications that can't serialize sessions should use a scheme designed for concurrency instead.
+package blindschnorr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// SignatureLength is the byte size of a BIP-340 signature.
+const SignatureLength = 64
+
+const tagChallenge = "BIP0340/challenge"
+
+var (
+	// ErrNonceUsed indicates a signer nonce that has already been used.
+	ErrNonceUsed = errors.New("signer nonce already used")
+
+	// ErrInvalidSignature indicates a blind signature that does not verify, or a nil input.
+	ErrInvalidSignature = errors.New("invalid blind signature")
+)
+
+// evenKey returns the point with even y and the same x coordinate as the public key, which is the key BIP-340
+// signatures verify with.
+func evenKey(pub *secp256k1.PublicKey) *secp256k1.Element {
+	p := pub.Element()
+	if p.Encode()[0] == 3 {
+		p.Negate()
+	}
+
+	return p
+}
+
+// challenge returns the BIP-340 challenge of the nonce point and x-only public key for the message.
+func challenge(r, p *secp256k1.Element, message []byte) *secp256k1.Scalar {
+	rx, px := r.EncodeXOnly(), p.EncodeXOnly()
+	return secp256k1.NewScalar().SetBytesMod(secp256k1.TaggedHash(tagChallenge, rx[:], px[:], message))
+}
+
+// SignerNonce holds the signer's secret nonce for one session. It is erased by Sign, and must never be reused.
+type SignerNonce struct {
+	k *secp256k1.Scalar
+}
+
+// Commit returns a fresh secret nonce of the signer and its nonce point, which is sent to the user in the first move.
+// The nonce is read from rand, e.g. crypto/rand.Reader, and an error is returned if rand is nil or fails, as for
+// Scalar.RandomFrom. The signer must not commit to a new nonce while a session is open, as explained in the package
+// documentation.
+func Commit(rand io.Reader) (*SignerNonce, *secp256k1.Element, error) {
+	k, err := secp256k1.NewScalar().RandomFrom(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &SignerNonce{k: k}, secp256k1.ScalarBaseMult(k), nil
+}
+
+// Sign returns the signer's blind signature s = k + c * d of the user's blinded challenge c, where d is the private
+// key negated if needed to match the x-only public key, and erases the nonce. It returns ErrNonceUsed if the nonce
+// has already been used.
+func (n *SignerNonce) Sign(key *secp256k1.PrivateKey, blindedChallenge *secp256k1.Scalar) (*secp256k1.Scalar, error) {
+	if key == nil || blindedChallenge == nil {
+		return nil, fmt.Errorf("%w: nil private key or challenge", ErrInvalidSignature)
+	}
+
+	if n.k.IsZero() {
+		return nil, ErrNonceUsed
+	}
+
+	defer n.k.Zero()
+
+	d := key.Scalar()
+	defer d.Zero()
+
+	if key.PublicKey().Bytes()[0] == 3 {
+		d.Multiply(secp256k1.NewScalar().MinusOne())
+	}
+
+	return secp256k1.NewScalar().Set(d).Multiply(blindedChallenge).Add(n.k), nil
+}
+
+// Blinding holds the user's blinding factors and the values needed to unblind the signer's answer.
+type Blinding struct {
+	alpha   *secp256k1.Scalar
+	nonce   *secp256k1.Element
+	key     *secp256k1.Element
+	r       *secp256k1.Element
+	c       *secp256k1.Scalar
+	message []byte
+}
+
+// Blind returns the user's blinding of the signer's nonce point and of the message for the signer's public key, and
+// the blinded challenge to send to the signer in the second move. The nonce point is blinded to R' = R + alpha * G +
+// beta * P for random alpha and beta, such that R' has an even y coordinate, and the challenge to c = e' + beta with
+// e' the BIP-340 challenge of R'. alpha and beta are read from rand as for Commit.
+func Blind(
+	pub *secp256k1.PublicKey,
+	nonce *secp256k1.Element,
+	message []byte,
+	rand io.Reader,
+) (*Blinding, *secp256k1.Scalar, error) {
+	if pub == nil || nonce == nil || nonce.IsIdentity() {
+		return nil, nil, fmt.Errorf("%w: nil public key or invalid nonce point", ErrInvalidSignature)
+	}
+
+	p := evenKey(pub)
+
+	for {
+		alpha, err := secp256k1.NewScalar().RandomFrom(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		beta, err := secp256k1.NewScalar().RandomFrom(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Drawing new factors until R' has an even y coordinate, about twice on average, reveals nothing to the signer.
+		r := nonce.Copy().Add(secp256k1.ScalarBaseMult(alpha)).Add(p.Copy().Multiply(beta))
+		if r.IsIdentity() || r.Encode()[0] != 2 {
+			continue
+		}
+
+		c := challenge(r, p, message).Add(beta)
+
+		return &Blinding{
+			alpha:   alpha,
+			nonce:   nonce.Copy(),
+			key:     p,
+			r:       r,
+			c:       c.Copy(),
+			message: append([]byte{}, message...),
+		}, c, nil
+	}
+}
+
+// Unblind returns the BIP-340 signature (R', s + alpha) of the message from the signer's blind signature s, after
+// checking that s * G = R + c * P. It returns ErrInvalidSignature if the blind signature does not verify.
+func (b *Blinding) Unblind(blindSignature *secp256k1.Scalar) ([SignatureLength]byte, error) {
+	var sig [SignatureLength]byte
+
+	if blindSignature == nil ||
+		secp256k1.ScalarBaseMult(blindSignature).Equal(b.nonce.Copy().Add(b.key.Copy().Multiply(b.c))) != 1 {
+		return sig, ErrInvalidSignature
+	}
+
+	r := b.r.EncodeXOnly()
+	copy(sig[:], r[:])
+	copy(sig[SignatureLength/2:], blindSignature.Copy().Add(b.alpha).Encode())
+
+	return sig, nil
+}
+
+// Verify returns whether sig is a valid BIP-340 signature of the message for the 32-byte x-only public key.
+// Verification only handles public values, and is not constant-time.
+func Verify(pub [32]byte, message []byte, sig [SignatureLength]byte) bool {
+	p := secp256k1.NewElement()
+	if err := p.LiftX(pub); err != nil {
+		return false
+	}
+
+	var rx [32]byte
+	copy(rx[:], sig[:SignatureLength/2])
+
+	r := secp256k1.NewElement()
+	if err := r.LiftX(rx); err != nil {
+		return false
+	}
+
+	s := secp256k1.NewScalar()
+	if err := s.Decode(sig[SignatureLength/2:]); err != nil {
+		return false
+	}
+
+	// s * G - e * P must be R, i.e. have an even y coordinate and the x coordinate of the signature.
+	e := challenge(r, p, message)
+
+	return secp256k1.DoubleScalarBaseMultVartime(s, secp256k1.NewScalar().Subtract(e), p).Equal(r) == 1
+}
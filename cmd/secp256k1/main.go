@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Command secp256k1 exposes the secp256k1 package's operations on the command line, to debug interoperability issues
+// and produce test fixtures with the exact library code.
+//
+// Keys, points, scalars, digests, and signatures are read and written as hexadecimal strings. Points are accepted in
+// compressed or uncompressed form, and written compressed unless stated otherwise. Messages and domain separation tags
+// are taken as is.
+//
+// Usage:
+//
+//	secp256k1 keygen
+//	secp256k1 pubkey <private key>
+//	secp256k1 compress <point>
+//	secp256k1 decompress <point>
+//	secp256k1 point add|sub <point> <point>
+//	secp256k1 point mul <point> <scalar>
+//	secp256k1 point neg <point>
+//	secp256k1 scalar add|sub|mul <scalar> <scalar>
+//	secp256k1 scalar neg|inv <scalar>
+//	secp256k1 hash-to-curve <dst> <message>
+//	secp256k1 encode-to-curve <dst> <message>
+//	secp256k1 hash-to-scalar <dst> <message>
+//	secp256k1 sign <private key> <digest>
+//	secp256k1 verify <public key> <digest> <signature>
+//
+// sign and verify use ECDSA with RFC 6979 nonces and compact 64-byte signatures, as implemented in the ecdsa package.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+var (
+	errUsage          = errors.New("invalid arguments")
+	errInvalidSig     = errors.New("signature verification failed")
+	errUnknownCommand = errors.New("unknown command")
+)
+
+type command struct {
+	args int
+	run  func(w io.Writer, args []string) error
+}
+
+var commands = map[string]command{
+	"keygen":          {0, keygen},
+	"pubkey":          {1, pubkey},
+	"compress":        {1, compress},
+	"decompress":      {1, decompress},
+	"point":           {-1, point},
+	"scalar":          {-1, scalar},
+	"hash-to-curve":   {2, hashToCurve},
+	"encode-to-curve": {2, encodeToCurve},
+	"hash-to-scalar":  {2, hashToScalar},
+	"sign":            {2, sign},
+	"verify":          {3, verify},
+}
+
+func main() {
+	if err := run(os.Stdout, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "secp256k1:", err)
+		os.Exit(1)
+	}
+}
+
+// run executes the command in args, and writes its result to w.
+func run(w io.Writer, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing command", errUsage)
+	}
+
+	c, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownCommand, args[0])
+	}
+
+	if c.args >= 0 && len(args)-1 != c.args {
+		return fmt.Errorf("%w: %s takes %d arguments", errUsage, args[0], c.args)
+	}
+
+	return c.run(w, args[1:])
+}
+
+func decodeHex(s string) ([]byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	return b, nil
+}
+
+func decodeScalar(s string) (*secp256k1.Scalar, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := secp256k1.NewScalar()
+	if err = sc.Decode(b); err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+func decodePublicKey(s string) (*secp256k1.PublicKey, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return secp256k1.NewPublicKey(b)
+}
+
+func decodePoint(s string) (*secp256k1.Element, error) {
+	pub, err := decodePublicKey(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return pub.Element(), nil
+}
+
+func decodePrivateKey(s string) (*secp256k1.PrivateKey, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return secp256k1.NewPrivateKey(b)
+}
+
+func printHex(w io.Writer, b []byte) error {
+	_, err := fmt.Fprintln(w, hex.EncodeToString(b))
+	return err
+}
+
+func keygen(w io.Writer, _ []string) error {
+	key, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	if err = printHex(w, key.Bytes()); err != nil {
+		return err
+	}
+
+	return printHex(w, key.PublicKey().Bytes())
+}
+
+func pubkey(w io.Writer, args []string) error {
+	key, err := decodePrivateKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	return printHex(w, key.PublicKey().Bytes())
+}
+
+func compress(w io.Writer, args []string) error {
+	p, err := decodePoint(args[0])
+	if err != nil {
+		return err
+	}
+
+	return printHex(w, p.Encode())
+}
+
+func decompress(w io.Writer, args []string) error {
+	p, err := decodePoint(args[0])
+	if err != nil {
+		return err
+	}
+
+	return printHex(w, p.EncodeUncompressed())
+}
+
+func point(w io.Writer, args []string) error {
+	if len(args) == 2 && args[0] == "neg" {
+		p, err := decodePoint(args[1])
+		if err != nil {
+			return err
+		}
+
+		return printHex(w, p.Negate().Encode())
+	}
+
+	if len(args) != 3 {
+		return fmt.Errorf("%w: point takes an operation and its operands", errUsage)
+	}
+
+	p, err := decodePoint(args[1])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "add", "sub":
+		q, err := decodePoint(args[2])
+		if err != nil {
+			return err
+		}
+
+		if args[0] == "add" {
+			p.Add(q)
+		} else {
+			p.Subtract(q)
+		}
+	case "mul":
+		s, err := decodeScalar(args[2])
+		if err != nil {
+			return err
+		}
+
+		p.Multiply(s)
+	default:
+		return fmt.Errorf("%w: point %q", errUnknownCommand, args[0])
+	}
+
+	return printHex(w, p.Encode())
+}
+
+func scalar(w io.Writer, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("%w: scalar takes an operation and its operands", errUsage)
+	}
+
+	s, err := decodeScalar(args[1])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "neg", "inv":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: scalar %s takes 1 operand", errUsage, args[0])
+		}
+
+		if args[0] == "neg" {
+			s = secp256k1.NewScalar().Subtract(s)
+		} else {
+			s.Invert()
+		}
+	case "add", "sub", "mul":
+		if len(args) != 3 {
+			return fmt.Errorf("%w: scalar %s takes 2 operands", errUsage, args[0])
+		}
+
+		t, err := decodeScalar(args[2])
+		if err != nil {
+			return err
+		}
+
+		switch args[0] {
+		case "add":
+			s.Add(t)
+		case "sub":
+			s.Subtract(t)
+		default:
+			s.Multiply(t)
+		}
+	default:
+		return fmt.Errorf("%w: scalar %q", errUnknownCommand, args[0])
+	}
+
+	return printHex(w, s.Encode())
+}
+
+func hashToCurve(w io.Writer, args []string) error {
+	return printHex(w, secp256k1.HashToGroup([]byte(args[1]), []byte(args[0])).Encode())
+}
+
+func encodeToCurve(w io.Writer, args []string) error {
+	return printHex(w, secp256k1.EncodeToGroup([]byte(args[1]), []byte(args[0])).Encode())
+}
+
+func hashToScalar(w io.Writer, args []string) error {
+	return printHex(w, secp256k1.HashToScalar([]byte(args[1]), []byte(args[0])).Encode())
+}
+
+func sign(w io.Writer, args []string) error {
+	key, err := decodePrivateKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	digest, err := decodeHex(args[1])
+	if err != nil {
+		return err
+	}
+
+	sig, err := ecdsa.Sign(key, digest)
+	if err != nil {
+		return err
+	}
+
+	return printHex(w, sig.Encode())
+}
+
+func verify(w io.Writer, args []string) error {
+	pub, err := decodePublicKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	digest, err := decodeHex(args[1])
+	if err != nil {
+		return err
+	}
+
+	enc, err := decodeHex(args[2])
+	if err != nil {
+		return err
+	}
+
+	sig := new(ecdsa.Signature)
+	if err = sig.Decode(enc); err != nil {
+		return err
+	}
+
+	if !ecdsa.Verify(pub, digest, sig) {
+		return errInvalidSig
+	}
+
+	_, err = fmt.Fprintln(w, "ok")
+
+	return err
+}
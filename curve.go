@@ -10,18 +10,30 @@ package secp256k1
 
 import (
 	"crypto"
+	"errors"
 	"math/big"
 
+	"github.com/bytemare/hash"
 	"github.com/bytemare/hash2curve"
 
 	"github.com/bytemare/secp256k1/internal/field"
 )
 
 const (
-	scalarLength  = 32
-	elementLength = 33
-	secLength     = 48
-	hash          = crypto.SHA256
+	scalarLength              = 32
+	fieldLength               = 32
+	elementLength             = 33
+	elementUncompressedLength = 65
+	secLength                 = 48
+	hashID                    = crypto.SHA256
+)
+
+var (
+	// errParamNotXOF indicates that the hash function is not an extendable-output function.
+	errParamNotXOF = errors.New("hash function is not an extendable-output function")
+
+	// errParamXMDHash indicates a hash function that is unavailable or too short for expand_message_xmd.
+	errParamXMDHash = errors.New("hash function is unavailable or has an output shorter than 32 bytes")
 )
 
 var (
@@ -47,6 +59,12 @@ var (
 		186, 174, 220, 230, 175, 72, 160, 59, 191, 210, 94, 140, 208, 54, 65, 64,
 	}
 
+	// scHalfOrder = (groupOrderBytes - 1) / 2.
+	scHalfOrder = []byte{
+		127, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+		93, 87, 110, 115, 87, 164, 80, 29, 223, 233, 47, 70, 104, 27, 32, 160,
+	}
+
 	// 0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798.
 	baseXBytes = []byte{
 		121, 190, 102, 126, 249, 220, 187, 172, 85, 160, 98, 149, 206, 135, 11, 7,
@@ -80,20 +98,52 @@ var (
 )
 
 func hashToScalar(input, dst []byte) *Scalar {
-	s := hash2curve.HashToFieldXMD(hash, input, dst, 1, 1, secLength, fn.Order())[0]
+	return hashToScalars(input, dst, 1)[0]
+}
+
+func hashToScalars(input, dst []byte, count uint) []*Scalar {
+	return hashToScalarsXMD(hashID, input, dst, count)
+}
+
+func hashToScalarsXMD(id crypto.Hash, input, dst []byte, count uint) []*Scalar {
+	if count == 0 {
+		return []*Scalar{}
+	}
+
+	u := hash2curve.HashToFieldXMD(id, input, dst, count, 1, secLength, fn.Order())
+	res := make([]*Scalar, count)
+
+	for i, s := range u {
+		res[i] = newScalar()
+		res[i].scalar.Set(s)
+	}
+
+	return res
+}
+
+// checkXMD panics if id is not linked into the binary or if its output is shorter than 2 * 128 bits, as required
+// by RFC 9380 for expand_message_xmd at the 128-bit security level of the curve.
+func checkXMD(id crypto.Hash) crypto.Hash {
+	if !id.Available() || id.Size() < scalarLength {
+		panic(errParamXMDHash)
+	}
 
-	// If necessary, build a buffer of right size, so it gets correctly interpreted.
-	bytes := s.Bytes()
+	return id
+}
 
-	length := scalarLength
-	if l := length - len(bytes); l > 0 {
-		buf := make([]byte, l, length)
-		buf = append(buf, bytes...)
-		bytes = buf
+func checkXOF(xof hash.Hash) *hash.ExtendableHash {
+	if !xof.Available() || xof.Type() != hash.ExtendableOutputFunction {
+		panic(errParamNotXOF)
 	}
 
+	return xof.GetXOF()
+}
+
+func hashToScalarXOF(xof hash.Hash, input, dst []byte) *Scalar {
+	s := hash2curve.HashToFieldXOF(checkXOF(xof), input, dst, 1, 1, secLength, fn.Order())[0]
+
 	res := newScalar()
-	res.scalar.SetBytes(bytes)
+	res.scalar.Set(s)
 
 	return res
 }
@@ -114,8 +164,7 @@ func isogeny3iso(e *Element) *Element {
 	return newElementWithAffine(x, y)
 }
 
-func hashToCurve(input, dst []byte) *Element {
-	u := hash2curve.HashToFieldXMD(hash, input, dst, 2, 1, secLength, fp.Order())
+func mapToCurveRO(u []*big.Int) *Element {
 	q0 := map2IsoCurve(u[0])
 	q1 := map2IsoCurve(u[1])
 	q0.addAffine(q1) // we use a generic affine add here because the others are tailored for a = 0 and b = 7.
@@ -123,8 +172,31 @@ func hashToCurve(input, dst []byte) *Element {
 	return isogeny3iso(q0)
 }
 
+func hashToCurve(input, dst []byte) *Element {
+	return hashToCurveXMD(hashID, input, dst)
+}
+
+func hashToCurveXMD(id crypto.Hash, input, dst []byte) *Element {
+	return mapToCurveRO(hash2curve.HashToFieldXMD(id, input, dst, 2, 1, secLength, fp.Order()))
+}
+
 func encodeToCurve(input, dst []byte) *Element {
-	u := hash2curve.HashToFieldXMD(hash, input, dst, 1, 1, secLength, fp.Order())
+	return encodeToCurveXMD(hashID, input, dst)
+}
+
+func encodeToCurveXMD(id crypto.Hash, input, dst []byte) *Element {
+	u := hash2curve.HashToFieldXMD(id, input, dst, 1, 1, secLength, fp.Order())
+	q0 := map2IsoCurve(u[0])
+
+	return isogeny3iso(q0)
+}
+
+func hashToCurveXOF(input, dst []byte) *Element {
+	return mapToCurveRO(hash2curve.HashToFieldXOF(hash.SHAKE256.GetXOF(), input, dst, 2, 1, secLength, fp.Order()))
+}
+
+func encodeToCurveXOF(input, dst []byte) *Element {
+	u := hash2curve.HashToFieldXOF(hash.SHAKE256.GetXOF(), input, dst, 1, 1, secLength, fp.Order())
 	q0 := map2IsoCurve(u[0])
 
 	return isogeny3iso(q0)
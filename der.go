@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"encoding/asn1"
+	"fmt"
+)
+
+var (
+	// oidPublicKeyECDSA is the id-ecPublicKey algorithm identifier from RFC 5480.
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	// oidSecp256k1 is the secp256k1 named curve identifier from SEC 2.
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// ecPrivateKeyVersion is the version of the ECPrivateKey structure from RFC 5915.
+const ecPrivateKeyVersion = 1
+
+// The fields of the following structures are in the order of their ASN.1 definitions, which encoding/asn1 follows.
+
+// algorithmIdentifier is the AlgorithmIdentifier structure from RFC 5280, whose parameters are a named curve for EC
+// keys.
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// subjectPublicKeyInfo is the SubjectPublicKeyInfo structure from RFC 5280.
+type subjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// privateKeyInfo is the PrivateKeyInfo structure from RFC 5208.
+type privateKeyInfo struct {
+	Version    int
+	Algorithm  algorithmIdentifier
+	PrivateKey []byte
+	Attributes []asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+// ecPrivateKey is the ECPrivateKey structure from RFC 5915.
+type ecPrivateKey struct {
+	Version    int
+	PrivateKey []byte
+	NamedCurve asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey  asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// secp256k1Algorithm identifies EC keys on secp256k1.
+var secp256k1Algorithm = func() algorithmIdentifier {
+	namedCurve, err := asn1.Marshal(oidSecp256k1)
+	if err != nil {
+		panic(err)
+	}
+
+	return algorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: namedCurve}}
+}()
+
+// unmarshalDER parses der into out, and rejects trailing data.
+func unmarshalDER(der []byte, out any) error {
+	rest, err := asn1.Unmarshal(der, out)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDER, err)
+	}
+
+	if len(rest) != 0 {
+		return fmt.Errorf("%w: trailing data", ErrInvalidDER)
+	}
+
+	return nil
+}
+
+// bitStringBytes returns the bytes of the bit string holding an encoded point, which must be a whole number of bytes.
+func bitStringBytes(b *asn1.BitString) ([]byte, error) {
+	if b.BitLength%8 != 0 {
+		return nil, fmt.Errorf("%w: public key bit string is not octet-aligned", ErrInvalidDER)
+	}
+
+	return b.Bytes, nil
+}
+
+// checkAlgorithm returns an error if a does not identify an EC key on secp256k1.
+func checkAlgorithm(a *algorithmIdentifier) error {
+	if !a.Algorithm.Equal(oidPublicKeyECDSA) {
+		return fmt.Errorf("%w: not an EC key (algorithm %v)", ErrUnsupportedCurve, a.Algorithm)
+	}
+
+	var namedCurve asn1.ObjectIdentifier
+	if err := unmarshalDER(a.Parameters.FullBytes, &namedCurve); err != nil {
+		return fmt.Errorf("%w: invalid named curve", ErrUnsupportedCurve)
+	}
+
+	if !namedCurve.Equal(oidSecp256k1) {
+		return fmt.Errorf("%w: named curve %v", ErrUnsupportedCurve, namedCurve)
+	}
+
+	return nil
+}
+
+// MarshalPKIXPublicKey returns the DER encoding of the public key as a SubjectPublicKeyInfo structure (RFC 5280 and
+// RFC 5480), with the uncompressed point and the secp256k1 named curve (1.3.132.0.10), as used in "PUBLIC KEY" PEM
+// blocks. It returns an error wrapping ErrInvalidPointEncoding if pub is nil.
+func MarshalPKIXPublicKey(pub *PublicKey) ([]byte, error) {
+	if pub == nil {
+		return nil, fmt.Errorf("%w: nil public key", ErrInvalidPointEncoding)
+	}
+
+	point := pub.BytesUncompressed()
+
+	der, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: secp256k1Algorithm,
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDER, err)
+	}
+
+	return der, nil
+}
+
+// ParsePKIXPublicKey parses a public key from the DER encoding of a SubjectPublicKeyInfo structure, in which the point
+// may be compressed or uncompressed. It returns an error wrapping ErrInvalidDER if der is malformed,
+// ErrUnsupportedCurve if the key is not an EC key on secp256k1, and ErrInvalidPointEncoding if the point is invalid.
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var info subjectPublicKeyInfo
+	if err := unmarshalDER(der, &info); err != nil {
+		return nil, err
+	}
+
+	if err := checkAlgorithm(&info.Algorithm); err != nil {
+		return nil, err
+	}
+
+	point, err := bitStringBytes(&info.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPublicKey(point)
+}
+
+// MarshalPKCS8PrivateKey returns the DER encoding of the private key as a PKCS #8 PrivateKeyInfo structure (RFC 5208),
+// holding an ECPrivateKey structure (RFC 5915) with the public key, as in "PRIVATE KEY" PEM blocks and as generated by
+// OpenSSL. It returns an error wrapping ErrInvalidPrivateKey if key is nil.
+func MarshalPKCS8PrivateKey(key *PrivateKey) ([]byte, error) {
+	inner, err := marshalECPrivateKey(key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(privateKeyInfo{
+		Version:    0,
+		Algorithm:  secp256k1Algorithm,
+		PrivateKey: inner,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDER, err)
+	}
+
+	return der, nil
+}
+
+// ParsePKCS8PrivateKey parses a private key from the DER encoding of a PKCS #8 PrivateKeyInfo structure. The public
+// key it may hold must match the secret scalar. It returns an error wrapping ErrInvalidDER if der is malformed or the
+// public key doesn't match, ErrUnsupportedCurve if the key is not an EC key on secp256k1, ErrInvalidPrivateKey if the
+// secret scalar is not in [1, n-1], and ErrInvalidPointEncoding if the public key is not a valid point.
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var info privateKeyInfo
+	if err := unmarshalDER(der, &info); err != nil {
+		return nil, err
+	}
+
+	if info.Version != 0 {
+		return nil, fmt.Errorf("%w: unsupported PKCS #8 version %d", ErrInvalidDER, info.Version)
+	}
+
+	if err := checkAlgorithm(&info.Algorithm); err != nil {
+		return nil, err
+	}
+
+	return parseECPrivateKey(info.PrivateKey)
+}
+
+// MarshalECPrivateKey returns the DER encoding of the private key as an ECPrivateKey structure (SEC 1 and RFC 5915),
+// with the secp256k1 named curve and the public key, as in "EC PRIVATE KEY" PEM blocks and as generated by OpenSSL. It
+// returns an error wrapping ErrInvalidPrivateKey if key is nil.
+func MarshalECPrivateKey(key *PrivateKey) ([]byte, error) {
+	return marshalECPrivateKey(key, oidSecp256k1)
+}
+
+// ParseECPrivateKey parses a private key from the DER encoding of an ECPrivateKey structure. The named curve, if
+// present, must be secp256k1, and the public key it may hold must match the secret scalar. It returns the same errors
+// as ParsePKCS8PrivateKey.
+func ParseECPrivateKey(der []byte) (*PrivateKey, error) {
+	return parseECPrivateKey(der)
+}
+
+// marshalECPrivateKey returns the DER encoding of the ECPrivateKey structure for key, with the named curve parameter
+// if it is not nil.
+func marshalECPrivateKey(key *PrivateKey, namedCurve asn1.ObjectIdentifier) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf("%w: nil private key", ErrInvalidPrivateKey)
+	}
+
+	point := key.pub.point.EncodeUncompressed()
+
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:    ecPrivateKeyVersion,
+		PrivateKey: key.Bytes(),
+		NamedCurve: namedCurve,
+		PublicKey:  asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDER, err)
+	}
+
+	return der, nil
+}
+
+// parseECPrivateKey parses the DER encoding of an ECPrivateKey structure, whose named curve parameter, if present,
+// must be secp256k1, and whose public key, if present, must match the secret scalar. Secret scalars shorter than 32
+// bytes, as produced by some encoders, are left-padded with zeros.
+func parseECPrivateKey(der []byte) (*PrivateKey, error) {
+	var key ecPrivateKey
+	if err := unmarshalDER(der, &key); err != nil {
+		return nil, err
+	}
+
+	if key.Version != ecPrivateKeyVersion {
+		return nil, fmt.Errorf("%w: unsupported EC private key version %d", ErrInvalidDER, key.Version)
+	}
+
+	if key.NamedCurve != nil && !key.NamedCurve.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("%w: named curve %v", ErrUnsupportedCurve, key.NamedCurve)
+	}
+
+	if len(key.PrivateKey) > scalarLength {
+		return nil, fmt.Errorf("%w: secret scalar too long", ErrInvalidPrivateKey)
+	}
+
+	var d [scalarLength]byte
+
+	copy(d[scalarLength-len(key.PrivateKey):], key.PrivateKey)
+	defer clear(d[:])
+
+	k, err := NewPrivateKey(d[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if key.PublicKey.BitLength == 0 {
+		return k, nil
+	}
+
+	point, err := bitStringBytes(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := NewPublicKey(point)
+	if err != nil {
+		return nil, err
+	}
+
+	if !k.pub.Equal(pub) {
+		return nil, fmt.Errorf("%w: public key does not match the private key", ErrInvalidDER)
+	}
+
+	return k, nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import "fmt"
+
+// recommendedDSTLength is the minimum DST length recommended by RFC 9380.
+const recommendedDSTLength = 16
+
+// HashOptions sets the DST policy of the error-returning hash-to-curve functions. The zero value rejects empty DSTs
+// and DSTs shorter than the 16 bytes recommended by RFC 9380.
+type HashOptions struct {
+	// Warn, if not nil, is called with an error wrapping ErrShortDST whenever a short DST is accepted because
+	// AllowShortDST is set.
+	Warn func(err error)
+
+	// MinDSTLength is the minimum accepted DST length. If zero, the recommended 16 bytes are used.
+	MinDSTLength int
+
+	// AllowShortDST accepts non-empty DSTs shorter than MinDSTLength, reporting them through Warn instead of
+	// rejecting them. Empty DSTs are always rejected.
+	AllowShortDST bool
+}
+
+// check returns an error if dst is not acceptable under the options. Nil options are the zero value.
+func (o *HashOptions) check(dst []byte) error {
+	if len(dst) == 0 {
+		return ErrEmptyDST
+	}
+
+	if o == nil {
+		o = &HashOptions{}
+	}
+
+	minLength := o.MinDSTLength
+	if minLength == 0 {
+		minLength = recommendedDSTLength
+	}
+
+	if len(dst) >= minLength {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: %d bytes, expected at least %d", ErrShortDST, len(dst), minLength)
+	if !o.AllowShortDST {
+		return err
+	}
+
+	if o.Warn != nil {
+		o.Warn(err)
+	}
+
+	return nil
+}
+
+// HashToScalarWithOptions is like HashToScalar, but returns an error instead of panicking if the DST is not
+// acceptable under the policy set by opts, which may be nil to use the defaults.
+func HashToScalarWithOptions(input, dst []byte, opts *HashOptions) (*Scalar, error) {
+	if err := opts.check(dst); err != nil {
+		return nil, err
+	}
+
+	return hashToScalar(input, dst), nil
+}
+
+// HashToGroupWithOptions is like HashToGroup, but returns an error instead of panicking if the DST is not acceptable
+// under the policy set by opts, which may be nil to use the defaults.
+func HashToGroupWithOptions(input, dst []byte, opts *HashOptions) (*Element, error) {
+	if err := opts.check(dst); err != nil {
+		return nil, err
+	}
+
+	return hashToCurve(input, dst), nil
+}
+
+// EncodeToGroupWithOptions is like EncodeToGroup, but returns an error instead of panicking if the DST is not
+// acceptable under the policy set by opts, which may be nil to use the defaults.
+func EncodeToGroupWithOptions(input, dst []byte, opts *HashOptions) (*Element, error) {
+	if err := opts.check(dst); err != nil {
+		return nil, err
+	}
+
+	return encodeToCurve(input, dst), nil
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// SharedPoint returns the Diffie-Hellman shared point of the private key and the peer's public key, i.e. d * P. It
+// returns an error wrapping ErrIdentity if the public key is nil or holds the identity, e.g. as a zero value. The
+// multiplication is constant time, and the returned element can be freely modified.
+func (k *PrivateKey) SharedPoint(pub *PublicKey) (*Element, error) {
+	if pub == nil {
+		return nil, fmt.Errorf("%w: nil public key", ErrIdentity)
+	}
+
+	if pub.point.IsIdentity() {
+		return nil, fmt.Errorf("%w: invalid public key", ErrIdentity)
+	}
+
+	return pub.point.copy().Multiply(&k.d), nil
+}
+
+// ECDH returns the 32-byte shared secret of the private key and the peer's public key, computed as libsecp256k1's
+// secp256k1_ecdh with its default hash function, i.e. the SHA-256 of the 33-byte compressed encoding of the shared
+// point. Secrets are thus interoperable with libsecp256k1 and its bindings (e.g. coincurve or rust-secp256k1's
+// SharedSecret). It returns the same errors as SharedPoint.
+func (k *PrivateKey) ECDH(pub *PublicKey) ([]byte, error) {
+	p, err := k.SharedPoint(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := sha256.Sum256(p.Encode())
+
+	return secret[:], nil
+}
+
+// ECDHRawX returns the 32-byte big-endian x coordinate of the shared point of the private key and the peer's public
+// key, as in SEC 1 and Go's crypto/ecdh for the NIST curves. This raw value is not uniformly distributed, and should
+// be passed through a key derivation function before use. It returns the same errors as SharedPoint.
+func (k *PrivateKey) ECDHRawX(pub *PublicKey) ([]byte, error) {
+	p, err := k.SharedPoint(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.XCoordinate(), nil
+}
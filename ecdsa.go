@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+)
+
+// checkCurve returns an error if curve is nil or if its parameters are not those of secp256k1. Only Params() is
+// used, so any elliptic.Curve implementation for secp256k1 is accepted, e.g. the ones of go-ethereum or btcec.
+func checkCurve(curve elliptic.Curve) error {
+	if curve == nil {
+		return fmt.Errorf("%w: nil curve", ErrUnsupportedCurve)
+	}
+
+	params := curve.Params()
+	if params == nil ||
+		params.P == nil || params.P.Cmp(fp.Order()) != 0 ||
+		params.N == nil || params.N.Cmp(fn.Order()) != 0 ||
+		params.B == nil || params.B.Cmp(b) != 0 ||
+		params.Gx == nil || params.Gx.Cmp(baseX) != 0 ||
+		params.Gy == nil || params.Gy.Cmp(baseY) != 0 {
+		return fmt.Errorf("%w: parameters are not those of secp256k1", ErrUnsupportedCurve)
+	}
+
+	return nil
+}
+
+// ToECDSAPublicKey returns the element as an ecdsa.PublicKey on the given curve, which must be an implementation of
+// secp256k1, since the standard library does not provide one. The identity element can't be converted.
+func (e *Element) ToECDSAPublicKey(curve elliptic.Curve) (*ecdsa.PublicKey, error) {
+	if err := checkCurve(curve); err != nil {
+		return nil, err
+	}
+
+	if e.IsIdentity() {
+		return nil, ErrIdentity
+	}
+
+	x, y := e.affine()
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     fp.Mod(new(big.Int).Set(x)),
+		Y:     fp.Mod(new(big.Int).Set(y)),
+	}, nil
+}
+
+// FromECDSAPublicKey sets the receiver to the point of the ecdsa.PublicKey, and returns an error if its curve is not
+// secp256k1 or if its coordinates are not those of a point of the curve. The receiver is not modified on error.
+func (e *Element) FromECDSAPublicKey(pub *ecdsa.PublicKey) error {
+	if pub == nil {
+		return fmt.Errorf("%w: nil public key", ErrInvalidPointEncoding)
+	}
+
+	if err := checkCurve(pub.Curve); err != nil {
+		return err
+	}
+
+	return e.SetAffine(pub.X, pub.Y)
+}
+
+// ToECDSAPrivateKey returns the scalar as an ecdsa.PrivateKey on the given curve, which must be an implementation of
+// secp256k1, with the corresponding public key. The scalar must not be zero.
+func (s *Scalar) ToECDSAPrivateKey(curve elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	if s.IsZero() {
+		return nil, fmt.Errorf("%w: zero scalar", ErrInvalidPrivateKey)
+	}
+
+	pub, err := ScalarBaseMult(s).ToECDSAPublicKey(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: *pub,
+		D:         new(big.Int).Set(&s.scalar),
+	}, nil
+}
+
+// FromECDSAPrivateKey sets the receiver to the secret scalar of the ecdsa.PrivateKey, and returns an error if its
+// curve is not secp256k1 or if the scalar is not in [1, n-1]. The public key is not verified. The receiver is not
+// modified on error.
+func (s *Scalar) FromECDSAPrivateKey(priv *ecdsa.PrivateKey) error {
+	if priv == nil || priv.D == nil {
+		return fmt.Errorf("%w: nil private key", ErrInvalidPrivateKey)
+	}
+
+	if err := checkCurve(priv.Curve); err != nil {
+		return err
+	}
+
+	if priv.D.Sign() <= 0 || priv.D.Cmp(fn.Order()) >= 0 {
+		return fmt.Errorf("%w: scalar out of range", ErrInvalidPrivateKey)
+	}
+
+	s.scalar.Set(priv.D)
+
+	return nil
+}
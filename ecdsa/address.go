@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // RIPEMD-160 is required by Bitcoin addresses.
+
+	"github.com/bytemare/secp256k1"
+)
+
+// BitcoinNetwork holds the address parameters of a Bitcoin network.
+type BitcoinNetwork struct {
+	// HRP is the human-readable part of bech32 segwit addresses.
+	HRP string
+
+	// P2PKHVersion is the version byte of base58 pay-to-public-key-hash addresses.
+	P2PKHVersion byte
+}
+
+var (
+	// BitcoinMainnet holds the address parameters of the Bitcoin main network.
+	BitcoinMainnet = BitcoinNetwork{HRP: "bc", P2PKHVersion: 0x00}
+
+	// BitcoinTestnet holds the address parameters of the Bitcoin test networks.
+	BitcoinTestnet = BitcoinNetwork{HRP: "tb", P2PKHVersion: 0x6f}
+)
+
+// hash160 returns RIPEMD-160(SHA-256(data)).
+func hash160(data []byte) []byte {
+	s := sha256.Sum256(data)
+	h := ripemd160.New()
+	_, _ = h.Write(s[:])
+
+	return h.Sum(nil)
+}
+
+// BitcoinP2PKHAddress returns the base58check pay-to-public-key-hash address of the public key on the network, of its
+// compressed or uncompressed encoding. The identity element has no address, and yields an empty string, as does a nil
+// element.
+func BitcoinP2PKHAddress(pub *secp256k1.Element, compressed bool, network BitcoinNetwork) string {
+	if pub == nil || pub.IsIdentity() {
+		return ""
+	}
+
+	enc := pub.EncodeUncompressed()
+	if compressed {
+		enc = pub.Encode()
+	}
+
+	return base58Check(append([]byte{network.P2PKHVersion}, hash160(enc)...))
+}
+
+// BitcoinP2WPKHAddress returns the bech32 native segwit pay-to-witness-public-key-hash address of the public key on
+// the network, as specified in BIP-173. The identity element has no address, and yields an empty string, as does a
+// nil element.
+func BitcoinP2WPKHAddress(pub *secp256k1.Element, network BitcoinNetwork) string {
+	if pub == nil || pub.IsIdentity() {
+		return ""
+	}
+
+	// Witness version 0, followed by the 20-byte key hash converted to 5-bit groups.
+	data := append([]byte{0}, convertBits(hash160(pub.Encode()), 8, 5)...)
+
+	return bech32Encode(network.HRP, data)
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Check returns the base58 encoding of the payload followed by the first 4 bytes of its double SHA-256.
+func base58Check(payload []byte) string {
+	h := sha256.Sum256(payload)
+	h = sha256.Sum256(h[:])
+	data := append(payload, h[:4]...)
+
+	var out []byte
+
+	x := new(big.Int).SetBytes(data)
+	radix, mod := big.NewInt(58), new(big.Int)
+
+	for x.Sign() > 0 {
+		x.DivMod(x, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// Leading zero bytes are encoded as leading '1's.
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// convertBits regroups the bits of data from groups of from bits to groups of to bits, padding the last group with
+// zeros.
+func convertBits(data []byte, from, to uint) []byte {
+	var (
+		acc  uint
+		bits uint
+		out  []byte
+	)
+
+	maxv := uint(1)<<to - 1
+
+	for _, b := range data {
+		acc = acc<<from | uint(b)
+		bits += from
+
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+
+	if bits > 0 {
+		out = append(out, byte(acc<<(to-bits)&maxv))
+	}
+
+	return out
+}
+
+// bech32Polymod returns the BCH checksum of the 5-bit values, as specified in BIP-173.
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+
+		for i, g := range generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+
+	return chk
+}
+
+// bech32Encode returns the bech32 encoding of the 5-bit data with the human-readable part hrp, as specified in
+// BIP-173.
+func bech32Encode(hrp string, data []byte) string {
+	values := make([]byte, 0, 2*len(hrp)+1+len(data)+6)
+	for _, c := range []byte(hrp) {
+		values = append(values, c>>5)
+	}
+
+	values = append(values, 0)
+	for _, c := range []byte(hrp) {
+		values = append(values, c&31)
+	}
+
+	values = append(values, data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 1
+
+	var sb strings.Builder
+
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+
+	for i := range 6 {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// The anti-exfiltration protocol prevents a signer, e.g. a hardware wallet, from leaking secrets through its choice of
+// nonces, by having a host contribute randomness to the nonce with a sign-to-contract commitment, as in
+// libsecp256k1-zkp:
+//
+//  1. The host draws 32 random bytes of host data, and sends their commitment AntiExfilHostCommit(hostData) to the
+//     signer.
+//  2. The signer derives its nonce k from its key, the message, and the host commitment, and sends its original nonce
+//     point R0 = k * G to the host with AntiExfilSignerCommit.
+//  3. The host reveals the host data, and the signer signs with the nonce k + t, where t is the hash of R0 and the
+//     host data, with AntiExfilSign.
+//  4. The host checks with AntiExfilHostVerify that the signature is valid, and that its nonce point is R0 + t * G.
+//
+// Since the signer commits to R0 before learning the host data, the final nonce is as random as the host data.
+const (
+	tagAntiExfilData  = "s2c/ecdsa/data"
+	tagAntiExfilPoint = "s2c/ecdsa/point"
+
+	// hostDataLength is the byte size of the host's random data.
+	hostDataLength = 32
+)
+
+// AntiExfilHostCommit returns the host's commitment to its 32 bytes of random host data, which is sent to the signer
+// in the first step of the anti-exfiltration protocol.
+func AntiExfilHostCommit(hostData []byte) []byte {
+	return secp256k1.TaggedHash(tagAntiExfilData, hostData)
+}
+
+// antiExfilNonce returns the signer's original nonce and nonce point for the host commitment.
+func antiExfilNonce(
+	key *secp256k1.PrivateKey,
+	digest, hostCommitment []byte,
+) (*secp256k1.Scalar, *secp256k1.Element, error) {
+	if key == nil {
+		return nil, nil, ErrNilKey
+	}
+
+	k, err := secp256k1.DeriveScalarRFC6979(key.Bytes(), digest, hostCommitment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return k, secp256k1.ScalarBaseMult(k), nil
+}
+
+// antiExfilTweak returns the tweak committing the nonce point to the host data.
+func antiExfilTweak(opening *secp256k1.Element, hostData []byte) (*secp256k1.Scalar, error) {
+	t := secp256k1.NewScalar()
+	if err := t.Decode(secp256k1.TaggedHash(tagAntiExfilPoint, opening.Encode(), hostData)); err != nil {
+		// This happens with negligible probability.
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return t, nil
+}
+
+// AntiExfilSignerCommit returns the signer's original nonce point R0 for the message digest and the host's commitment,
+// which is sent to the host in the second step of the anti-exfiltration protocol.
+func AntiExfilSignerCommit(key *secp256k1.PrivateKey, digest, hostCommitment []byte) (*secp256k1.Element, error) {
+	k, opening, err := antiExfilNonce(key, digest, hostCommitment)
+	if err != nil {
+		return nil, err
+	}
+
+	k.Zero()
+
+	return opening, nil
+}
+
+// AntiExfilSign returns the signature of the message digest with the nonce of AntiExfilSignerCommit tweaked with the
+// 32 bytes of host data, in the third step of the anti-exfiltration protocol. The signature is normalized to low-S.
+func AntiExfilSign(key *secp256k1.PrivateKey, digest, hostData []byte) (*Signature, error) {
+	if len(hostData) != hostDataLength {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidHostData, hostDataLength, len(hostData))
+	}
+
+	k, opening, err := antiExfilNonce(key, digest, AntiExfilHostCommit(hostData))
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := antiExfilTweak(opening, hostData)
+	if err != nil {
+		k.Zero()
+		return nil, err
+	}
+
+	d := key.Scalar()
+	defer d.Zero()
+
+	sig, _ := signWithNonce(d, hashToScalar(digest), k.Add(t))
+	if sig == nil {
+		// This happens with negligible probability.
+		return nil, fmt.Errorf("%w: zero r or s", ErrInvalidSignature)
+	}
+
+	return sig, nil
+}
+
+// AntiExfilHostVerify returns whether sig is a valid signature of the message digest for the public key, and whether
+// its nonce point is the signer's original nonce point opening tweaked with the host data, in the last step of the
+// anti-exfiltration protocol.
+func AntiExfilHostVerify(
+	pub *secp256k1.PublicKey,
+	digest []byte,
+	sig *Signature,
+	hostData []byte,
+	opening *secp256k1.Element,
+) bool {
+	if opening == nil || opening.IsIdentity() || !Verify(pub, digest, sig) {
+		return false
+	}
+
+	t, err := antiExfilTweak(opening, hostData)
+	if err != nil {
+		return false
+	}
+
+	r := opening.Copy().Add(secp256k1.ScalarBaseMult(t))
+	if r.IsIdentity() {
+		return false
+	}
+
+	return xCoordinate(r).Equal(sig.R) == 1
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"github.com/bytemare/secp256k1"
+)
+
+// BatchEntry is a signature to be verified by VerifyBatch, with the public key, the message digest, and the
+// recovery ID returned by SignRecoverable.
+type BatchEntry struct {
+	PublicKey  *secp256k1.PublicKey
+	Signature  *Signature
+	Digest     []byte
+	RecoveryID byte
+}
+
+// batchMultiplierLength is the byte size of the random multipliers, which makes the probability that an invalid
+// batch is accepted at most 2^-128.
+const batchMultiplierLength = 16
+
+// VerifyBatch returns whether all signatures are valid, which is faster than verifying them one by one with Verify.
+// It checks that the sum of z_i * (u1_i * G + u2_i * P_i - R_i) is the identity with one multi-scalar multiplication,
+// where the z_i are random 128-bit multipliers and the nonce points R_i are derived from r_i and the recovery IDs.
+// A batch is thus only accepted if each signature is valid and has the correct recovery ID. If the batch is rejected,
+// the invalid signatures can be identified with Verify. An empty batch is valid.
+func VerifyBatch(entries []BatchEntry) bool {
+	scalars := make([]*secp256k1.Scalar, 0, 2*len(entries)+1)
+	points := make([]*secp256k1.Element, 0, 2*len(entries)+1)
+	u1Sum := secp256k1.NewScalar()
+
+	for i, entry := range entries {
+		sig := entry.Signature
+		if entry.PublicKey == nil || sig == nil || sig.R == nil || sig.S == nil || sig.R.IsZero() || sig.S.IsZero() {
+			return false
+		}
+
+		r, err := noncePoint(sig.R, entry.RecoveryID)
+		if err != nil {
+			return false
+		}
+
+		// The first multiplier can be 1 without loss of security.
+		z := secp256k1.NewScalar().One()
+		if i > 0 {
+			z.SetBytesMod(secp256k1.NewScalar().Random().Encode()[:batchMultiplierLength])
+		}
+
+		zw := sig.S.Copy().Invert().Multiply(z)
+		u1Sum.Add(hashToScalar(entry.Digest).Multiply(zw))
+
+		// Negating R rather than z keeps the short multiplier.
+		scalars = append(scalars, sig.R.Copy().Multiply(zw), z)
+		points = append(points, entry.PublicKey.Element(), r.Negate())
+	}
+
+	scalars = append(scalars, u1Sum)
+	points = append(points, secp256k1.Base())
+
+	return secp256k1.MultiScalarMultVartime(scalars, points).IsIdentity()
+}
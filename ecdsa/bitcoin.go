@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// bitcoinMessageMagic is the prefix of signed messages, preceded by its length.
+const bitcoinMessageMagic = "\x18Bitcoin Signed Message:\n"
+
+// BitcoinAddressType is the type of address a Bitcoin signed message is signed for, which is encoded in the header
+// byte of the signature as specified in BIP-137.
+type BitcoinAddressType byte
+
+const (
+	// BitcoinP2PKHUncompressed is a pay-to-public-key-hash address of the uncompressed public key, with headers 27-30.
+	BitcoinP2PKHUncompressed BitcoinAddressType = 27
+
+	// BitcoinP2PKH is a pay-to-public-key-hash address of the compressed public key, with headers 31-34.
+	BitcoinP2PKH BitcoinAddressType = 31
+
+	// BitcoinP2WPKH is a native segwit pay-to-witness-public-key-hash address, with headers 39-42.
+	BitcoinP2WPKH BitcoinAddressType = 39
+
+	// bitcoinP2SHP2WPKH is the unsupported nested segwit address type, with headers 35-38.
+	bitcoinP2SHP2WPKH BitcoinAddressType = 35
+
+	// bitcoinCompactLength is the byte size of a compact signature [header || R || S].
+	bitcoinCompactLength = 65
+)
+
+// BitcoinMessageHash returns the double SHA-256 of the message prefixed with the "Bitcoin Signed Message:\n" magic,
+// each preceded by its length as a Bitcoin variable-length integer.
+func BitcoinMessageHash(message []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(bitcoinMessageMagic))
+	h.Write(compactSize(uint64(len(message))))
+	h.Write(message)
+
+	d := sha256.Sum256(h.Sum(nil))
+
+	return d[:]
+}
+
+// compactSize returns the Bitcoin variable-length encoding of n.
+func compactSize(n uint64) []byte {
+	switch {
+	case n < 0xfd:
+		return []byte{byte(n)}
+	case n <= 0xffff:
+		return binary.LittleEndian.AppendUint16([]byte{0xfd}, uint16(n))
+	case n <= 0xffffffff:
+		return binary.LittleEndian.AppendUint32([]byte{0xfe}, uint32(n))
+	default:
+		return binary.LittleEndian.AppendUint64([]byte{0xff}, n)
+	}
+}
+
+// SignBitcoinMessage returns the base64-encoded compact signature of the message for an address of the given type, as
+// produced by Bitcoin Core's signmessage for P2PKH addresses and specified in BIP-137. The signature is deterministic.
+func SignBitcoinMessage(key *secp256k1.PrivateKey, message []byte, addressType BitcoinAddressType) (string, error) {
+	switch addressType {
+	case BitcoinP2PKHUncompressed, BitcoinP2PKH, BitcoinP2WPKH:
+	default:
+		return "", fmt.Errorf("%w: unsupported address type %d", ErrInvalidSignature, addressType)
+	}
+
+	sig, recoveryID, err := SignRecoverable(key, BitcoinMessageHash(message))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(append([]byte{byte(addressType) + recoveryID}, sig.Encode()...)), nil
+}
+
+// RecoverBitcoinMessage returns the public key that produced the base64-encoded BIP-137 signature of the message, and
+// the address type of its header. It returns an error wrapping ErrInvalidSignature on failure.
+func RecoverBitcoinMessage(signature string, message []byte) (*secp256k1.PublicKey, BitcoinAddressType, error) {
+	data, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(data) != bitcoinCompactLength {
+		return nil, 0, fmt.Errorf("%w: invalid compact signature encoding", ErrInvalidSignature)
+	}
+
+	header := BitcoinAddressType(data[0])
+	if header < BitcoinP2PKHUncompressed || header >= BitcoinP2WPKH+4 {
+		return nil, 0, fmt.Errorf("%w: invalid header byte %d", ErrInvalidSignature, header)
+	}
+
+	addressType := BitcoinP2PKHUncompressed + (header-BitcoinP2PKHUncompressed)&^3
+
+	sig := new(Signature)
+	if err = sig.Decode(data[1:]); err != nil {
+		return nil, 0, err
+	}
+
+	pub, err := RecoverPublicKey(BitcoinMessageHash(message), sig, byte(header-addressType))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return pub, addressType, nil
+}
+
+// VerifyBitcoinMessage returns whether signature is a valid base64-encoded BIP-137 signature of the message by the
+// signer of the P2PKH or P2WPKH address on the network. As some wallets sign for segwit addresses with the P2PKH
+// headers, a compressed key is accepted for both address types. Nested segwit (P2SH-P2WPKH) addresses are not
+// supported.
+func VerifyBitcoinMessage(network BitcoinNetwork, address, signature string, message []byte) bool {
+	pub, addressType, err := RecoverBitcoinMessage(signature, message)
+	if err != nil {
+		return false
+	}
+
+	p := pub.Element()
+
+	switch addressType {
+	case BitcoinP2PKHUncompressed:
+		return address == BitcoinP2PKHAddress(p, false, network)
+	case BitcoinP2PKH, BitcoinP2WPKH:
+		return address == BitcoinP2PKHAddress(p, true, network) ||
+			address == BitcoinP2WPKHAddress(p, network)
+	case bitcoinP2SHP2WPKH:
+		return false
+	default:
+		return false
+	}
+}
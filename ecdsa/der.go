@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+const (
+	// derSequence and derInteger are the ASN.1 DER tags of the signature structure.
+	derSequence = 0x30
+	derInteger  = 0x02
+
+	// derMinLength and derMaxLength bound the size of a DER signature: two one-byte integers, and two 33-byte
+	// integers with a leading zero byte.
+	derMinLength = 8
+	derMaxLength = 72
+)
+
+// derInt appends the minimal DER INTEGER encoding of the non-negative big-endian integer v.
+func derInt(dst, v []byte) []byte {
+	for len(v) > 1 && v[0] == 0 {
+		v = v[1:]
+	}
+
+	dst = append(dst, derInteger, byte(len(v)+int(v[0]>>7)))
+	if v[0]&0x80 != 0 {
+		dst = append(dst, 0) // the value would otherwise be negative
+	}
+
+	return append(dst, v...)
+}
+
+// EncodeDER returns the strict DER encoding of the signature, i.e. of the ASN.1 structure
+// SEQUENCE { r INTEGER, s INTEGER }, as used in Bitcoin without the trailing sighash byte.
+func (sig *Signature) EncodeDER() []byte {
+	out := make([]byte, 2, derMaxLength)
+	out = derInt(out, sig.R.Encode())
+	out = derInt(out, sig.S.Encode())
+	out[0], out[1] = derSequence, byte(len(out)-2)
+
+	return out
+}
+
+// checkDERInt returns an error if v, the content of a DER INTEGER, is empty, negative, or not minimally encoded.
+func checkDERInt(v []byte) error {
+	switch {
+	case len(v) == 0:
+		return fmt.Errorf("%w: empty integer", ErrInvalidSignature)
+	case v[0]&0x80 != 0:
+		return fmt.Errorf("%w: negative integer", ErrInvalidSignature)
+	case len(v) > 1 && v[0] == 0 && v[1]&0x80 == 0:
+		return fmt.Errorf("%w: integer with excessive padding", ErrInvalidSignature)
+	}
+
+	return nil
+}
+
+// derScalar returns the scalar for v, the content of a DER INTEGER, or an error if it is not in [1, n-1].
+func derScalar(v []byte) (*secp256k1.Scalar, error) {
+	if err := checkDERInt(v); err != nil {
+		return nil, err
+	}
+
+	if v[0] == 0 {
+		v = v[1:]
+	}
+
+	if len(v) > secp256k1.ScalarLength() {
+		return nil, fmt.Errorf("%w: integer too large", ErrInvalidSignature)
+	}
+
+	enc := make([]byte, secp256k1.ScalarLength())
+	copy(enc[len(enc)-len(v):], v)
+
+	s := secp256k1.NewScalar()
+	if err := s.Decode(enc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	if s.IsZero() {
+		return nil, fmt.Errorf("%w: zero scalar", ErrInvalidSignature)
+	}
+
+	return s, nil
+}
+
+// DecodeDER sets the receiver to the decoding of the strict DER encoding of a signature, and returns an error wrapping
+// ErrInvalidSignature if the encoding is not canonical, or if r or s is not in [1, n-1]. The encoding rules are those
+// of Bitcoin's consensus (BIP 66), without the trailing sighash byte: the input must be a single SEQUENCE of exactly
+// two INTEGERs with short-form lengths, and the integers must be positive and minimally encoded, without trailing
+// data. The receiver is not modified on error.
+func (sig *Signature) DecodeDER(data []byte) error {
+	if len(data) < derMinLength || len(data) > derMaxLength {
+		return fmt.Errorf("%w: invalid DER length %d", ErrInvalidSignature, len(data))
+	}
+
+	if data[0] != derSequence || int(data[1]) != len(data)-2 {
+		return fmt.Errorf("%w: invalid DER sequence", ErrInvalidSignature)
+	}
+
+	lenR := int(data[3])
+	if data[2] != derInteger || 5+lenR >= len(data) {
+		return fmt.Errorf("%w: invalid DER integer r", ErrInvalidSignature)
+	}
+
+	lenS := int(data[5+lenR])
+	if data[4+lenR] != derInteger || lenR+lenS+6 != len(data) {
+		return fmt.Errorf("%w: invalid DER integer s", ErrInvalidSignature)
+	}
+
+	r, err := derScalar(data[4 : 4+lenR])
+	if err != nil {
+		return err
+	}
+
+	s, err := derScalar(data[6+lenR:])
+	if err != nil {
+		return err
+	}
+
+	sig.R, sig.S = r, s
+
+	return nil
+}
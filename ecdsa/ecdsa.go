@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ecdsa implements ECDSA signatures over secp256k1 as specified in SEC 1, with deterministic nonces as
+// specified in RFC 6979, on top of the secp256k1 package's arithmetic.
+package ecdsa
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+const (
+	// signatureLength is the byte size of the compact encoding of a signature.
+	signatureLength = 64
+
+	// entropyLength is the byte size of the fresh randomness read by SignHedged, as for libsecp256k1's ndata.
+	entropyLength = 32
+)
+
+var (
+	// ErrNilKey indicates a nil private or public key.
+	ErrNilKey = errors.New("nil key")
+
+	// ErrInvalidSignature indicates an invalid signature encoding, or a signature whose r or s is not in [1, n-1].
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrInvalidHostData indicates anti-exfiltration host data that is not 32 bytes long.
+	ErrInvalidHostData = errors.New("invalid host data")
+
+	// ErrInvalidShare indicates a zero or used secret share of a key or nonce, or a peer's share that is the identity,
+	// in multi-party signing.
+	ErrInvalidShare = errors.New("invalid share")
+)
+
+// Signature is an ECDSA signature (r, s).
+type Signature struct {
+	R *secp256k1.Scalar
+	S *secp256k1.Scalar
+}
+
+// Encode returns the 64-byte compact encoding of the signature, i.e. the big-endian encodings of r and s.
+func (sig *Signature) Encode() []byte {
+	out := make([]byte, 0, signatureLength)
+	out = append(out, sig.R.Encode()...)
+
+	return append(out, sig.S.Encode()...)
+}
+
+// Decode sets the receiver to the decoding of the 64-byte compact encoding of a signature, and returns an error
+// wrapping ErrInvalidSignature if the input is not 64 bytes long, or if r or s is not in [1, n-1]. The receiver is
+// not modified on error.
+func (sig *Signature) Decode(data []byte) error {
+	if len(data) != signatureLength {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, signatureLength, len(data))
+	}
+
+	r, s := secp256k1.NewScalar(), secp256k1.NewScalar()
+	if err := r.Decode(data[:signatureLength/2]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	if err := s.Decode(data[signatureLength/2:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	if r.IsZero() || s.IsZero() {
+		return fmt.Errorf("%w: zero scalar", ErrInvalidSignature)
+	}
+
+	sig.R, sig.S = r, s
+
+	return nil
+}
+
+// IsHighS returns whether s is strictly greater than (n-1)/2. Such a signature is valid under SEC 1, but (r, n - s) is
+// an equally valid signature of the same message, which is why BIP 62, BIP 146, and Ethereum (EIP-2) only accept
+// low-S signatures.
+func (sig *Signature) IsHighS() bool {
+	return sig.S.IsHigh()
+}
+
+// Normalize sets s to n - s if the signature is high-S, which yields the equivalent low-S signature, and returns sig.
+// This flips the parity bit of the signature's recovery ID, if any.
+func (sig *Signature) Normalize() *Signature {
+	sig.S.NegateIfHigh()
+	return sig
+}
+
+// hashToScalar returns the message digest as a scalar, i.e. its leftmost 256 bits reduced modulo n, as specified in
+// SEC 1, section 4.1.3.
+func hashToScalar(digest []byte) *secp256k1.Scalar {
+	return secp256k1.NewScalar().SetBytesMod(digest[:min(len(digest), secp256k1.ScalarLength())])
+}
+
+// xCoordinate returns the x-coordinate of p reduced modulo n.
+func xCoordinate(p *secp256k1.Element) *secp256k1.Scalar {
+	x := p.EncodeXOnly()
+	return secp256k1.NewScalar().SetBytesMod(x[:])
+}
+
+// Sign returns the ECDSA signature of the message digest with the private key, using a deterministic nonce derived as
+// specified in RFC 6979 with HMAC-SHA-256, so that signing does not depend on a random source. The digest should be
+// the output of a cryptographic hash function, e.g. SHA-256, of which the leftmost 256 bits are used. The sequence of
+// group operations does not depend on the private key or the nonce. As with libsecp256k1, the signature is always
+// normalized to low-S, so that it is accepted under the VerifyLowS policy.
+func Sign(key *secp256k1.PrivateKey, digest []byte) (*Signature, error) {
+	sig, _, err := sign(key, digest, nil)
+	return sig, err
+}
+
+// SignRecoverable is like Sign, and also returns the recovery ID of the signature, which identifies the nonce point R
+// among the candidates with an x coordinate congruent to r: its bit 0 is the parity of the y coordinate of R, and its
+// bit 1 is set if the x coordinate of R is not r but r + n, which happens with negligible probability. It allows
+// batch verification, and recovering the public key from the signature.
+func SignRecoverable(key *secp256k1.PrivateKey, digest []byte) (*Signature, byte, error) {
+	return sign(key, digest, nil)
+}
+
+// SignWithEntropy is like Sign, but mixes extra into the RFC 6979 nonce derivation as additional data (section 3.6),
+// as libsecp256k1 does with the ndata argument of its nonce function. The signature is deterministic for a given
+// extra, and with fresh random extra data the nonce remains secure even if the random source is weak, while repeated
+// signatures of the same message use different nonces, which protects against fault attacks. A nil or empty extra
+// yields the same signature as Sign.
+func SignWithEntropy(key *secp256k1.PrivateKey, digest, extra []byte) (*Signature, error) {
+	sig, _, err := sign(key, digest, extra)
+	return sig, err
+}
+
+// SignHedged is like SignWithEntropy, using 32 bytes of fresh randomness read from rand, e.g. crypto/rand.Reader. It
+// returns an error wrapping secp256k1.ErrNilRandomSource or secp256k1.ErrRandomSource if rand is nil or fails.
+func SignHedged(key *secp256k1.PrivateKey, digest []byte, rand io.Reader) (*Signature, error) {
+	if rand == nil {
+		return nil, secp256k1.ErrNilRandomSource
+	}
+
+	var extra [entropyLength]byte
+	if _, err := io.ReadFull(rand, extra[:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", secp256k1.ErrRandomSource, err)
+	}
+
+	sig, _, err := sign(key, digest, extra[:])
+
+	return sig, err
+}
+
+// sign implements SignRecoverable, mixing extra into the nonce derivation.
+func sign(key *secp256k1.PrivateKey, digest, extra []byte) (*Signature, byte, error) {
+	if key == nil {
+		return nil, 0, ErrNilKey
+	}
+
+	d := key.Scalar()
+	defer d.Zero()
+
+	e := hashToScalar(digest)
+
+	for counter := byte(0); ; counter++ {
+		// r or s are zero with negligible probability, in which case a new nonce is derived with an incremented
+		// counter as additional data, as libsecp256k1 does.
+		data := extra
+		if counter != 0 {
+			data = append(append([]byte{}, extra...), counter)
+		}
+
+		k, err := secp256k1.DeriveScalarRFC6979(key.Bytes(), digest, data)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if sig, recoveryID := signWithNonce(d, e, k); sig != nil {
+			return sig, recoveryID, nil
+		}
+	}
+}
+
+// signWithNonce returns the low-S signature (r, s) = (x(k * G) mod n, ±(e + r * d) / k) and its recovery ID, or nil
+// if r or s is zero.
+func signWithNonce(d, e, k *secp256k1.Scalar) (*Signature, byte) {
+	defer k.Zero()
+
+	p := secp256k1.ScalarBaseMult(k)
+	r := xCoordinate(p)
+
+	if r.IsZero() {
+		return nil, 0
+	}
+
+	s := r.Copy().Multiply(d)
+	s.Add(e).Multiply(k.Invert())
+
+	if s.IsZero() {
+		return nil, 0
+	}
+
+	return &Signature{R: r, S: s}, normalize(p, s)
+}
+
+// normalize sets s to its low-S form, and returns the recovery ID of the signature with the nonce point p.
+func normalize(p *secp256k1.Element, s *secp256k1.Scalar) byte {
+	// Negating s to normalize it amounts to signing with -k, i.e. the nonce point -R.
+	enc := p.Encode()
+	recoveryID := enc[0] & 1
+
+	if s.IsHigh() {
+		s.NegateIfHigh()
+		recoveryID ^= 1
+	}
+
+	if bytes.Compare(enc[1:], secp256k1.Order()) >= 0 {
+		recoveryID |= 2
+	}
+
+	return recoveryID
+}
+
+// VerifyFlags select optional verification policies on top of SEC 1, and can be combined with a bitwise or.
+type VerifyFlags uint
+
+const (
+	// VerifyLowS rejects high-S signatures, i.e. with s > (n-1)/2, to prevent signature malleability as required by
+	// BIP 62, BIP 146, and Ethereum (EIP-2) transaction signatures.
+	VerifyLowS VerifyFlags = 1 << iota
+)
+
+// Verify returns whether sig is a valid signature of the message digest for the public key, as specified in SEC 1,
+// section 4.1.4. r and s must be in [1, n-1], and both low and high s values are accepted. Verification only
+// handles public values, and is not constant-time.
+func Verify(pub *secp256k1.PublicKey, digest []byte, sig *Signature) bool {
+	return VerifyWithFlags(pub, digest, sig, 0)
+}
+
+// VerifyWithFlags is like Verify, and additionally enforces the policies selected by flags.
+func VerifyWithFlags(pub *secp256k1.PublicKey, digest []byte, sig *Signature, flags VerifyFlags) bool {
+	if pub == nil || sig == nil || sig.R == nil || sig.S == nil || sig.R.IsZero() || sig.S.IsZero() {
+		return false
+	}
+
+	if flags&VerifyLowS != 0 && sig.IsHighS() {
+		return false
+	}
+
+	e := hashToScalar(digest)
+	w := sig.S.Copy().Invert()
+	u1 := e.Multiply(w)
+	u2 := sig.R.Copy().Multiply(w)
+
+	p := secp256k1.DoubleScalarBaseMultVartime(u1, u2, pub.Element())
+	if p.IsIdentity() {
+		return false
+	}
+
+	return xCoordinate(p).Equal(sig.R) == 1
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"fmt"
+	"hash"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytemare/secp256k1"
+)
+
+const (
+	// EthereumSignatureLength is the byte size of an Ethereum signature [R || S || V].
+	EthereumSignatureLength = 65
+
+	// ethereumDigestLength is the byte size of the Keccak-256 digests signed in Ethereum.
+	ethereumDigestLength = 32
+
+	// ethereumLegacyV is the offset of the pre-EIP-155 v value, and ethereumEIP155V that of EIP-155.
+	ethereumLegacyV = 27
+	ethereumEIP155V = 35
+
+	// ethereumAddressLength is the byte size of an Ethereum address.
+	ethereumAddressLength = 20
+)
+
+// EthereumAddress returns the Ethereum address of the public key pub, i.e. the last 20 bytes of the Keccak-256 hash
+// of its uncompressed encoding without the 0x04 prefix. The identity element has no address, and yields the zero
+// address, as does a nil element.
+func EthereumAddress(pub *secp256k1.Element) [ethereumAddressLength]byte {
+	return EthereumAddressWithHash(pub, sha3.NewLegacyKeccak256())
+}
+
+// EthereumAddressWithHash is like EthereumAddress, but uses keccak256 as the Keccak-256 hash function, e.g. to use
+// another implementation than the one of golang.org/x/crypto/sha3. It must be the original Keccak-256 as used by
+// Ethereum, which differs from the standardized SHA3-256. keccak256 is reset before use.
+func EthereumAddressWithHash(pub *secp256k1.Element, keccak256 hash.Hash) [ethereumAddressLength]byte {
+	var addr [ethereumAddressLength]byte
+
+	if pub == nil || pub.IsIdentity() {
+		return addr
+	}
+
+	keccak256.Reset()
+	_, _ = keccak256.Write(pub.EncodeUncompressed()[1:])
+
+	var digest [64]byte
+
+	d := keccak256.Sum(digest[:0])
+	copy(addr[:], d[len(d)-ethereumAddressLength:])
+
+	return addr
+}
+
+// checkEthereumDigest returns an error if the digest is not 32 bytes long, as required by go-ethereum.
+func checkEthereumDigest(digest []byte) error {
+	if len(digest) != ethereumDigestLength {
+		return fmt.Errorf("%w: digest must be %d bytes, got %d", ErrInvalidSignature, ethereumDigestLength,
+			len(digest))
+	}
+
+	return nil
+}
+
+// SignEthereum returns the 65-byte signature [R || S || V] of the 32-byte digest, where V is the recovery ID in
+// {0, 1}, with the same layout as go-ethereum's crypto.Sign. The signature is deterministic and low-S, as required by
+// EIP-2. Use EthereumV to derive the v value of a transaction.
+func SignEthereum(key *secp256k1.PrivateKey, digest []byte) ([]byte, error) {
+	if err := checkEthereumDigest(digest); err != nil {
+		return nil, err
+	}
+
+	sig, recoveryID, err := SignRecoverable(key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(sig.Encode(), recoveryID), nil
+}
+
+// decodeEthereum returns the signature and recovery ID of the 65-byte [R || S || V] signature.
+func decodeEthereum(sig []byte) (*Signature, byte, error) {
+	if len(sig) != EthereumSignatureLength {
+		return nil, 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, EthereumSignatureLength,
+			len(sig))
+	}
+
+	s := new(Signature)
+	if err := s.Decode(sig[:signatureLength]); err != nil {
+		return nil, 0, err
+	}
+
+	return s, sig[signatureLength], nil
+}
+
+// RecoverEthereum returns the public key for which sig, a 65-byte [R || S || V] signature with V in [0, 3], is a valid
+// signature of the 32-byte digest, as go-ethereum's crypto.SigToPub. As with go-ethereum, high s values are accepted
+// here, and transaction signatures should additionally be checked for low-S. It returns an error wrapping
+// ErrInvalidSignature on failure.
+func RecoverEthereum(digest, sig []byte) (*secp256k1.PublicKey, error) {
+	if err := checkEthereumDigest(digest); err != nil {
+		return nil, err
+	}
+
+	s, recoveryID, err := decodeEthereum(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return RecoverPublicKey(digest, s, recoveryID)
+}
+
+// Ecrecover is like RecoverEthereum, but returns the 65-byte uncompressed encoding of the public key, with the same
+// layout as go-ethereum's crypto.Ecrecover and the ecrecover precompile input.
+func Ecrecover(digest, sig []byte) ([]byte, error) {
+	pub, err := RecoverEthereum(digest, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return pub.BytesUncompressed(), nil
+}
+
+// VerifyEthereum returns whether sig, a 64-byte [R || S] signature, is a valid signature of the 32-byte digest for
+// the public key, which is given in its 33-byte compressed or 65-byte uncompressed encoding. As with go-ethereum's
+// crypto.VerifySignature, high-S signatures are rejected.
+func VerifyEthereum(pub, digest, sig []byte) bool {
+	if checkEthereumDigest(digest) != nil {
+		return false
+	}
+
+	k, err := secp256k1.NewPublicKey(pub)
+	if err != nil {
+		return false
+	}
+
+	s := new(Signature)
+	if err = s.Decode(sig); err != nil {
+		return false
+	}
+
+	return VerifyWithFlags(k, digest, s, VerifyLowS)
+}
+
+// EthereumV returns the v value of a transaction signature with the recovery ID. It is 27 + recoveryID for legacy
+// transactions if chainID is nil, and chainID * 2 + 35 + recoveryID with EIP-155 replay protection otherwise.
+func EthereumV(recoveryID byte, chainID *big.Int) *big.Int {
+	if chainID == nil {
+		return big.NewInt(int64(ethereumLegacyV + recoveryID))
+	}
+
+	v := new(big.Int).Lsh(chainID, 1)
+
+	return v.Add(v, big.NewInt(int64(ethereumEIP155V+recoveryID)))
+}
+
+// EthereumRecoveryID returns the recovery ID encoded in the v value of a transaction signature, and the chain ID for
+// EIP-155 values, or nil for legacy values of 27 or 28. It returns an error wrapping ErrInvalidSignature if v is
+// neither.
+func EthereumRecoveryID(v *big.Int) (recoveryID byte, chainID *big.Int, err error) {
+	if v == nil || v.Sign() < 0 {
+		return 0, nil, fmt.Errorf("%w: invalid v value", ErrInvalidSignature)
+	}
+
+	if v.IsUint64() && (v.Uint64() == ethereumLegacyV || v.Uint64() == ethereumLegacyV+1) {
+		return byte(v.Uint64() - ethereumLegacyV), nil, nil
+	}
+
+	if v.Cmp(big.NewInt(ethereumEIP155V)) < 0 {
+		return 0, nil, fmt.Errorf("%w: invalid v value %v", ErrInvalidSignature, v)
+	}
+
+	// v - 35 = chainID * 2 + recoveryID.
+	t := new(big.Int).Sub(v, big.NewInt(ethereumEIP155V))
+
+	return byte(t.Bit(0)), t.Rsh(t, 1), nil
+}
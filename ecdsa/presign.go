@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// The presignature functions below are the group-arithmetic part of threshold ECDSA protocols with presigning, such
+// as GG18, GG20, and CGGMP21. The interactive presigning phase, left to the caller, ends with a nonce point
+// R = k^-1 * G and, for each signer i, additive shares k_i of k and chi_i of k * x, where x is the private key. Once
+// the message is known, each signer sends the signature share sigma_i = k_i * e + r * chi_i in a single round, where e
+// is the digest as a scalar and r the x coordinate of R, and the shares sum to s = k * (e + r * x).
+//
+// Protocols with t-of-n secret sharing must convert the Shamir shares of the signers to additive shares beforehand,
+// e.g. with secp256k1.LagrangeCoefficient.
+
+// ReconstructNoncePoint returns the nonce point R = delta^-1 * Gamma of a presignature, from the signers' shares
+// Gamma_i = gamma_i * G and the revealed shares delta_i of delta = k * gamma, where Gamma is the sum of the Gamma_i and
+// delta that of the delta_i. It returns an error wrapping ErrInvalidShare if the inputs are empty, have different
+// lengths or nil entries, if delta is zero, or if R is the identity.
+func ReconstructNoncePoint(gammas []*secp256k1.Element, deltas []*secp256k1.Scalar) (*secp256k1.Element, error) {
+	if len(gammas) == 0 || len(gammas) != len(deltas) {
+		return nil, fmt.Errorf("%w: empty or mismatched shares", ErrInvalidShare)
+	}
+
+	gamma, delta := secp256k1.NewElement(), secp256k1.NewScalar()
+
+	for i, g := range gammas {
+		if g == nil || deltas[i] == nil {
+			return nil, fmt.Errorf("%w: nil share", ErrInvalidShare)
+		}
+
+		gamma.Add(g)
+		delta.Add(deltas[i])
+	}
+
+	if delta.IsZero() {
+		return nil, fmt.Errorf("%w: zero delta", ErrInvalidShare)
+	}
+
+	r := gamma.Multiply(delta.Invert())
+	if r.IsIdentity() {
+		return nil, fmt.Errorf("%w: identity nonce point", ErrInvalidShare)
+	}
+
+	return r, nil
+}
+
+// PresignatureCommitment is the public commitment K_i = k_i * R and Chi_i = chi_i * R of a signer's presignature
+// shares, which allows anyone to check the signer's signature share.
+type PresignatureCommitment struct {
+	K, Chi *secp256k1.Element
+}
+
+// Presignature is a signer's share of a presignature. It is erased when used, and must never be reused, as two
+// signature shares with the same presignature reveal the signer's key share.
+type Presignature struct {
+	r      *secp256k1.Element
+	k, chi *secp256k1.Scalar
+}
+
+// NewPresignature returns the presignature share of a signer with the nonce point R and its additive shares k_i of k
+// and chi_i of k * x. It returns an error wrapping ErrInvalidShare if R is nil or the identity, or a share is nil.
+func NewPresignature(r *secp256k1.Element, k, chi *secp256k1.Scalar) (*Presignature, error) {
+	if err := checkPeerPoint(r); err != nil {
+		return nil, err
+	}
+
+	if k == nil || chi == nil {
+		return nil, fmt.Errorf("%w: nil share", ErrInvalidShare)
+	}
+
+	return &Presignature{r: r.Copy(), k: k.Copy(), chi: chi.Copy()}, nil
+}
+
+// NoncePoint returns the nonce point R of the presignature.
+func (p *Presignature) NoncePoint() *secp256k1.Element {
+	return p.r.Copy()
+}
+
+// Commitment returns the public commitment to the signer's presignature shares, to publish with the presignature.
+func (p *Presignature) Commitment() *PresignatureCommitment {
+	return &PresignatureCommitment{K: p.r.Copy().Multiply(p.k), Chi: p.r.Copy().Multiply(p.chi)}
+}
+
+// Sign returns the signer's signature share sigma_i = k_i * e + r * chi_i of the message digest, and erases the
+// presignature. It returns an error wrapping ErrInvalidShare if the presignature has already been used.
+func (p *Presignature) Sign(digest []byte) (*secp256k1.Scalar, error) {
+	if p.k == nil {
+		return nil, fmt.Errorf("%w: presignature already used", ErrInvalidShare)
+	}
+
+	defer func() {
+		p.k.Zero()
+		p.chi.Zero()
+		p.k, p.chi = nil, nil
+	}()
+
+	r := xCoordinate(p.r)
+	if r.IsZero() {
+		return nil, fmt.Errorf("%w: zero r", ErrInvalidSignature)
+	}
+
+	return hashToScalar(digest).Multiply(p.k).Add(r.Multiply(p.chi)), nil
+}
+
+// VerifyPresignature returns whether the signers' commitments are consistent with the nonce point R and the public
+// key, i.e. whether the K_i sum to G and the Chi_i to the public key, which holds if the shares k_i sum to the
+// inverse of the discrete logarithm of R, and the shares chi_i to k * x. It is done once per presignature, before
+// checking the signature shares against the commitments.
+func VerifyPresignature(
+	r *secp256k1.Element,
+	pub *secp256k1.PublicKey,
+	commitments []*PresignatureCommitment,
+) bool {
+	if r == nil || r.IsIdentity() || pub == nil || len(commitments) == 0 {
+		return false
+	}
+
+	k, chi := secp256k1.NewElement(), secp256k1.NewElement()
+
+	for _, c := range commitments {
+		if c == nil || c.K == nil || c.Chi == nil {
+			return false
+		}
+
+		k.Add(c.K)
+		chi.Add(c.Chi)
+	}
+
+	return k.Equal(secp256k1.Base()) == 1 && chi.Equal(pub.Element()) == 1
+}
+
+// VerifySignatureShare returns whether sigma is the valid signature share of the message digest of the signer with
+// the commitment, for the nonce point R, i.e. whether sigma * R = e * K_i + r * Chi_i. This identifies the signers
+// that send invalid shares.
+func VerifySignatureShare(
+	r *secp256k1.Element,
+	commitment *PresignatureCommitment,
+	digest []byte,
+	sigma *secp256k1.Scalar,
+) bool {
+	if r == nil || r.IsIdentity() || commitment == nil || commitment.K == nil || commitment.Chi == nil ||
+		sigma == nil {
+		return false
+	}
+
+	expected := secp256k1.MultiScalarMultVartime(
+		[]*secp256k1.Scalar{hashToScalar(digest), xCoordinate(r)},
+		[]*secp256k1.Element{commitment.K, commitment.Chi},
+	)
+
+	return r.Copy().Multiply(sigma).Equal(expected) == 1
+}
+
+// CombineSignatureShares returns the signature of the message digest for the nonce point R, i.e. (r, s) with s the sum
+// of the signature shares, and its recovery ID. The signature is normalized to low-S, and verified under the public
+// key: an error wrapping ErrInvalidSignature is returned if it does not verify, in which case the invalid shares can
+// be identified with VerifySignatureShare.
+func CombineSignatureShares(
+	r *secp256k1.Element,
+	pub *secp256k1.PublicKey,
+	digest []byte,
+	shares []*secp256k1.Scalar,
+) (*Signature, byte, error) {
+	if err := checkPeerPoint(r); err != nil {
+		return nil, 0, err
+	}
+
+	s := secp256k1.NewScalar()
+
+	for _, sigma := range shares {
+		if sigma == nil {
+			return nil, 0, fmt.Errorf("%w: nil signature share", ErrInvalidShare)
+		}
+
+		s.Add(sigma)
+	}
+
+	sig := &Signature{R: xCoordinate(r), S: s}
+	recoveryID := normalize(r, sig.S)
+
+	if !Verify(pub, digest, sig) {
+		return nil, 0, fmt.Errorf("%w: the combined signature does not verify", ErrInvalidSignature)
+	}
+
+	return sig, recoveryID, nil
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/field"
+)
+
+// noncePoint returns the nonce point R of a signature with the given r and recovery ID, as returned by
+// SignRecoverable, or an error if there is no such point.
+func noncePoint(r *secp256k1.Scalar, recoveryID byte) (*secp256k1.Element, error) {
+	if recoveryID > 3 {
+		return nil, fmt.Errorf("%w: invalid recovery ID %d", ErrInvalidSignature, recoveryID)
+	}
+
+	x := new(big.Int).SetBytes(r.Encode())
+	if recoveryID&2 != 0 {
+		x.Add(x, new(big.Int).SetBytes(secp256k1.Order()))
+
+		if x.Cmp(new(big.Int).SetBytes(field.Order())) >= 0 {
+			return nil, fmt.Errorf("%w: x coordinate out of range", ErrInvalidSignature)
+		}
+	}
+
+	var enc [32]byte
+
+	p := secp256k1.NewElement()
+	if err := p.DecodeX([32]byte(x.FillBytes(enc[:])), recoveryID&1); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return p, nil
+}
+
+// RecoverPublicKey returns the public key for which sig is a valid signature of the message digest, given the
+// recovery ID returned by SignRecoverable, as specified in SEC 1, section 4.1.6. Both low and high s values are
+// accepted. It returns an error wrapping ErrInvalidSignature if r or s is not in [1, n-1], if the recovery ID is
+// invalid, or if no public key matches. Recovery only handles public values, and is not constant-time.
+func RecoverPublicKey(digest []byte, sig *Signature, recoveryID byte) (*secp256k1.PublicKey, error) {
+	if sig == nil || sig.R == nil || sig.S == nil || sig.R.IsZero() || sig.S.IsZero() {
+		return nil, fmt.Errorf("%w: nil or zero r or s", ErrInvalidSignature)
+	}
+
+	r, err := noncePoint(sig.R, recoveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Q = r^-1 * (s * R - e * G).
+	w := sig.R.Copy().Invert()
+	u1 := secp256k1.NewScalar().Subtract(hashToScalar(digest)).Multiply(w)
+	u2 := sig.S.Copy().Multiply(w)
+
+	q := secp256k1.DoubleScalarBaseMultVartime(u1, u2, r)
+	if q.IsIdentity() {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, secp256k1.ErrIdentity)
+	}
+
+	return secp256k1.NewPublicKey(q.Encode())
+}
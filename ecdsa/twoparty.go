@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// The two-party ECDSA functions below are the curve-side building blocks of Lindell's protocol ("Fast Secure
+// Two-Party ECDSA Signing", 2017). The private key x = x1 * x2 and the nonce k = k1 * k2 are shared multiplicatively,
+// so that each party derives the public key and the nonce point from its own share and the peer's public share.
+//
+// To sign, both parties exchange nonce points. The second party computes the coefficients a and b of the partial
+// signature with TwoPartyShare.Coefficients, and homomorphically evaluates Enc(a + b * x1) under the first party's
+// additively homomorphic encryption (e.g. Paillier) of x1, masked with a random multiple of the group order. The first
+// party decrypts it, reduces it modulo the group order, and completes the signature with TwoPartyShare.Complete. The
+// encryption scheme, the zero-knowledge proofs of the key generation, and the commitments of the nonce exchange are
+// left to the caller, as they are not specific to the curve.
+
+// TwoPartyShare is one party's multiplicative share of a two-party ECDSA private key, together with the joint public
+// key.
+type TwoPartyShare struct {
+	secret *secp256k1.Scalar
+	joint  *secp256k1.PublicKey
+}
+
+// checkPeerPoint returns an error wrapping ErrInvalidShare if the peer's point is nil or the identity.
+func checkPeerPoint(p *secp256k1.Element) error {
+	if p == nil || p.IsIdentity() {
+		return fmt.Errorf("%w: invalid peer point", ErrInvalidShare)
+	}
+
+	return nil
+}
+
+// NewTwoPartyShare returns the share of the party holding the non-zero secret, for which the peer's public share is
+// its secret times the base point. Both parties derive the same joint public key.
+func NewTwoPartyShare(secret *secp256k1.Scalar, peerPublicShare *secp256k1.Element) (*TwoPartyShare, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("%w: zero secret share", ErrInvalidShare)
+	}
+
+	if err := checkPeerPoint(peerPublicShare); err != nil {
+		return nil, err
+	}
+
+	joint, err := secp256k1.NewPublicKey(peerPublicShare.Copy().Multiply(secret).Encode())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidShare, err)
+	}
+
+	return &TwoPartyShare{secret: secret.Copy(), joint: joint}, nil
+}
+
+// PublicShare returns the public share of the party, i.e. its secret share times the base point, to send to the peer.
+func (s *TwoPartyShare) PublicShare() *secp256k1.Element {
+	return secp256k1.ScalarBaseMult(s.secret)
+}
+
+// PublicKey returns the joint public key, under which the signatures of both parties verify.
+func (s *TwoPartyShare) PublicKey() *secp256k1.PublicKey {
+	return s.joint
+}
+
+// TwoPartyNonce is one party's multiplicative share of the nonce of a signature. It is erased when used, and must
+// never be reused.
+type TwoPartyNonce struct {
+	k *secp256k1.Scalar
+}
+
+// NewTwoPartyNonce returns a fresh nonce share read from rand, e.g. crypto/rand.Reader. It returns an error if rand is
+// nil or fails, as for Scalar.RandomFrom.
+func NewTwoPartyNonce(rand io.Reader) (*TwoPartyNonce, error) {
+	k, err := secp256k1.NewScalar().RandomFrom(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoPartyNonce{k: k}, nil
+}
+
+// Point returns the public nonce point of the share, i.e. its secret times the base point, to send to the peer.
+func (n *TwoPartyNonce) Point() *secp256k1.Element {
+	return secp256k1.ScalarBaseMult(n.k)
+}
+
+// use returns the inverse of the nonce share and the joint nonce point with the peer's nonce point, and erases the
+// nonce.
+func (n *TwoPartyNonce) use(peerPoint *secp256k1.Element) (kInv *secp256k1.Scalar, p *secp256k1.Element, err error) {
+	if n == nil || n.k == nil || n.k.IsZero() {
+		return nil, nil, fmt.Errorf("%w: nonce already used", ErrInvalidShare)
+	}
+
+	if err = checkPeerPoint(peerPoint); err != nil {
+		return nil, nil, err
+	}
+
+	defer n.k.Zero()
+
+	return n.k.Copy().Invert(), peerPoint.Copy().Multiply(n.k), nil
+}
+
+// Coefficients returns, for the second party, the coefficients a = e / k2 and b = r * x2 / k2 of the partial
+// signature of the message digest, where e is the digest as a scalar and r the x coordinate of the joint nonce point.
+// The caller then homomorphically computes an encryption of a + b * x1 for the first party. The nonce share is erased.
+func (s *TwoPartyShare) Coefficients(
+	nonce *TwoPartyNonce,
+	peerNoncePoint *secp256k1.Element,
+	digest []byte,
+) (a, b *secp256k1.Scalar, err error) {
+	kInv, p, err := nonce.use(peerNoncePoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer kInv.Zero()
+
+	r := xCoordinate(p)
+	if r.IsZero() {
+		return nil, nil, fmt.Errorf("%w: zero r", ErrInvalidSignature)
+	}
+
+	return hashToScalar(digest).Multiply(kInv), r.Multiply(s.secret).Multiply(kInv), nil
+}
+
+// Complete returns, for the first party, the signature of the message digest from the decrypted partial
+// signature a + b * x1 reduced modulo the group order, and its recovery ID. The signature is normalized to low-S, and
+// verified under the joint public key, so that a cheating peer is detected: an error wrapping ErrInvalidSignature is
+// returned if it does not verify. The nonce share is erased, unless partial is nil or the digest is empty, which are
+// rejected first so that the nonce can still be used.
+func (s *TwoPartyShare) Complete(
+	nonce *TwoPartyNonce,
+	peerNoncePoint *secp256k1.Element,
+	digest []byte,
+	partial *secp256k1.Scalar,
+) (*Signature, byte, error) {
+	if partial == nil {
+		return nil, 0, fmt.Errorf("%w: nil partial signature", ErrInvalidSignature)
+	}
+
+	if len(digest) == 0 {
+		return nil, 0, fmt.Errorf("%w: empty digest", ErrInvalidSignature)
+	}
+
+	kInv, p, err := nonce.use(peerNoncePoint)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer kInv.Zero()
+
+	sig := &Signature{R: xCoordinate(p), S: partial.Copy().Multiply(kInv)}
+	recoveryID := normalize(p, sig.S)
+
+	if !Verify(s.joint, digest, sig) {
+		return nil, 0, fmt.Errorf("%w: the partial signature does not verify", ErrInvalidSignature)
+	}
+
+	return sig, recoveryID, nil
+}
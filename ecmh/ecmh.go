@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ecmh implements an elliptic curve multiset hash over secp256k1, on top of the secp256k1 package's
+// arithmetic (Maitin-Shepard et al., "Elliptic Curve Multiset Hash", 2016). Each item is mapped to a point of the curve,
+// and the hash of a multiset is the sum of the points of its items. It is therefore independent of the order of
+// insertion, and can be updated incrementally when items are added or removed, e.g. to commit to a UTXO set, or to
+// compare sets held by different parties without exchanging them.
+//
+// Items are mapped with the secp256k1_XMD:SHA-256_SSWU_NU_ encode-to-curve suite of RFC 9380, with the domain
+// separation tag "github.com/bytemare/secp256k1/ecmh:" + secp256k1.E2CSECP256K1.
+package ecmh
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// DigestLength is the byte size of a multiset digest.
+const DigestLength = sha256.Size
+
+// ErrInvalidEncoding indicates a malformed multiset hash encoding.
+var ErrInvalidEncoding = errors.New("invalid multiset hash encoding")
+
+var dst = []byte("github.com/bytemare/secp256k1/ecmh:" + secp256k1.E2CSECP256K1)
+
+// MultisetHash is the running hash of a multiset. Its zero value is the hash of the empty multiset.
+type MultisetHash struct {
+	sum secp256k1.Element
+}
+
+// New returns the hash of the empty multiset.
+func New() *MultisetHash {
+	return &MultisetHash{}
+}
+
+// Add adds the item to the multiset, and returns the receiver. Adding an item several times adds as many copies.
+func (m *MultisetHash) Add(item []byte) *MultisetHash {
+	m.sum.Add(secp256k1.EncodeToGroup(item, dst))
+	return m
+}
+
+// Remove removes one copy of the item from the multiset, and returns the receiver. Removing an item that is not in the
+// multiset is not an error: it is accounted as a negative multiplicity, and cancelled by a later Add.
+func (m *MultisetHash) Remove(item []byte) *MultisetHash {
+	m.sum.Subtract(secp256k1.EncodeToGroup(item, dst))
+	return m
+}
+
+// Union adds all the items of the other multiset to the receiver, and returns the receiver.
+func (m *MultisetHash) Union(other *MultisetHash) *MultisetHash {
+	m.sum.Add(&other.sum)
+	return m
+}
+
+// Difference removes all the items of the other multiset from the receiver, and returns the receiver.
+func (m *MultisetHash) Difference(other *MultisetHash) *MultisetHash {
+	m.sum.Subtract(&other.sum)
+	return m
+}
+
+// Copy returns a copy of the multiset hash.
+func (m *MultisetHash) Copy() *MultisetHash {
+	c := &MultisetHash{}
+	c.sum.Set(&m.sum)
+
+	return c
+}
+
+// IsEmpty returns whether the multiset hashes as the empty multiset.
+func (m *MultisetHash) IsEmpty() bool {
+	return m.sum.IsIdentity()
+}
+
+// Equal returns whether both multisets have the same hash.
+func (m *MultisetHash) Equal(other *MultisetHash) bool {
+	return other != nil && m.sum.Equal(&other.sum) == 1
+}
+
+// Digest returns the 32-byte digest of the multiset, i.e. the SHA-256 of the compressed encoding of the sum of its
+// points. As in the Bitcoin Cash ECMH specification, the digest of the empty multiset is 32 zero bytes.
+func (m *MultisetHash) Digest() []byte {
+	if m.IsEmpty() {
+		return make([]byte, DigestLength)
+	}
+
+	d := sha256.Sum256(m.sum.Encode())
+
+	return d[:]
+}
+
+// Encode returns the 33-byte compressed encoding of the sum of the points of the multiset, from which the hash can be
+// resumed with Decode. The empty multiset is encoded as 33 zero bytes.
+func (m *MultisetHash) Encode() []byte {
+	return m.sum.Encode()
+}
+
+// Decode sets the receiver to the decoding of the 33-byte encoding, and returns an error wrapping ErrInvalidEncoding
+// on failure. The receiver is left untouched on error.
+func (m *MultisetHash) Decode(data []byte) error {
+	p := secp256k1.NewElement()
+	if err := p.DecodeAllowIdentity(data); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+
+	m.sum.Set(p)
+
+	return nil
+}
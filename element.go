@@ -9,18 +9,13 @@
 package secp256k1
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"math/big"
-)
-
-var (
-	// errParamInvalidPointEncoding indicates an invalid point encoding has been provided.
-	errParamInvalidPointEncoding = errors.New("invalid point encoding")
-
-	// errIdentity indicates that the identity point (or point at infinity) has been encountered.
-	errIdentity = errors.New("infinity/identity point")
+	"slices"
+	"sync"
 )
 
 // Element implements the Element interface for the Secp256k1 group element.
@@ -29,9 +24,10 @@ type Element struct {
 	x, y, z big.Int
 }
 
+// identity is the point at infinity as (0 : 1 : 0), which the complete formulas handle without special cases.
 var identity = Element{
 	x: *fp.Zero(),
-	y: *fp.Zero(),
+	y: *fp.One(),
 	z: *fp.Zero(), // The Identity element is the only with z == 0
 }
 
@@ -71,7 +67,7 @@ func (e *Element) affine() (x, y *big.Int) {
 		return fp.Zero(), fp.Zero()
 	}
 
-	if fp.AreEqual(&e.z, scOne) {
+	if e.z.Cmp(scOne) == 0 {
 		return &e.x, &e.y
 	}
 
@@ -130,62 +126,81 @@ func (e *Element) setCoordinates(x, y, z *big.Int) {
 	e.z.Set(z)
 }
 
-// https://eprint.iacr.org/2015/1060.pdf
-func (e *Element) addProjectiveComplete(element *Element) *Element {
-	var t0, t1, t2, t3, t4, x3, y3, z3 big.Int
+// addScratch holds the temporaries of an addition. They are pooled so that their backing arrays are reused, and
+// additions and subtractions don't allocate.
+type addScratch struct {
+	t0, t1, t2, t3, t4, x3, y3, z3 big.Int
+	y1, z1, y2, z2, negY           big.Int
+}
 
-	fp.Mul(&t0, &e.x, &element.x) // t0 := X1 * X2
-	fp.Mul(&t1, &e.y, &element.y) // t1 := Y1 * Y2
-	fp.Mul(&t2, &e.z, &element.z) // t2 := Z1 * Z2
+var addScratchPool = sync.Pool{New: func() any { return new(addScratch) }}
 
-	fp.Add(&t3, &e.x, &e.y)             // t3 := X1 + Y1
-	fp.Add(&t4, &element.x, &element.y) // t4 := X2 + Y2
-	fp.Mul(&t3, &t3, &t4)               // t3 := t3 * t4
+// identityCoordinates sets y2 and z2 to the y and z coordinates of (x : y : z), replaced with those of (0 : 1 : 0) if x
+// and y are zero, as in the zero value of Element. It does not branch on the coordinates.
+func identityCoordinates(y2, z2, x, y, z *big.Int) {
+	cond := fp.CondIsZero(x) & fp.CondIsZero(y)
+	fp.CondSelect(y2, scOne, y, cond)
+	fp.CondSelect(z2, fp.Zero(), z, cond)
+}
 
-	fp.Add(&t4, &t0, &t1)   // t4 := t0 + t1
-	fp.Sub(&t3, &t3, &t4)   // t3 := t3 - t4
-	fp.Add(&t4, &e.y, &e.z) // t4 := Y1 + Z1
+// addProjectiveComplete sets e to e + (x2 : y2 : z2), following https://eprint.iacr.org/2015/1060.pdf, using the
+// temporaries in s. Taking the coordinates of the second operand allows subtracting without materializing its
+// negation. The formulas are complete for the identity as (0 : 1 : 0), so both operands are brought to that form and
+// the sequence of operations does not depend on whether either is the identity.
+func (e *Element) addProjectiveComplete(s *addScratch, x2, y2, z2 *big.Int) *Element {
+	t0, t1, t2, t3, t4, x3, y3, z3 := &s.t0, &s.t1, &s.t2, &s.t3, &s.t4, &s.x3, &s.y3, &s.z3
 
-	fp.Add(&x3, &element.y, &element.z) // X3 := Y2 + Z2
-	fp.Mul(&t4, &t4, &x3)               // t4 := t4 * X3
-	fp.Add(&x3, &t1, &t2)               // X3 := t1 + t2
+	identityCoordinates(&s.y2, &s.z2, x2, y2, z2)
+	identityCoordinates(&s.y1, &s.z1, &e.x, &e.y, &e.z)
+	y2, z2 = &s.y2, &s.z2
+	e.y.Set(&s.y1)
+	e.z.Set(&s.z1)
 
-	fp.Sub(&t4, &t4, &x3)               // t4 := t4 - X3
-	fp.Add(&x3, &e.x, &e.z)             // X3 := X1 + Z1
-	fp.Add(&y3, &element.x, &element.z) // Y3 := X2 + Z2
+	fp.Mul(t0, &e.x, x2) // t0 := X1 * X2
+	fp.Mul(t1, &e.y, y2) // t1 := Y1 * Y2
+	fp.Mul(t2, &e.z, z2) // t2 := Z1 * Z2
 
-	fp.Mul(&x3, &x3, &y3) // X3 := X3 * Y3
-	fp.Add(&y3, &t0, &t2) // Y3 := t0 + t2
-	fp.Sub(&y3, &x3, &y3) // Y3 := X3 - Y3
+	fp.Add(t3, &e.x, &e.y) // t3 := X1 + Y1
+	fp.Add(t4, x2, y2)     // t4 := X2 + Y2
+	fp.Mul(t3, t3, t4)     // t3 := t3 * t4
 
-	fp.Add(&x3, &t0, &t0) // X3 := t0 + t0
-	fp.Add(&t0, &x3, &t0) // t0 := X3 + t0
-	fp.Mul(&t2, b3, &t2)  // t2 := b3 * t2
+	fp.Add(t4, t0, t1)     // t4 := t0 + t1
+	fp.Sub(t3, t3, t4)     // t3 := t3 - t4
+	fp.Add(t4, &e.y, &e.z) // t4 := Y1 + Z1
 
-	fp.Add(&z3, &t1, &t2) // Z3 := t1 + t2
-	fp.Sub(&t1, &t1, &t2) // t1 := t1 - t2
-	fp.Mul(&y3, b3, &y3)  // Y3 := b3 * Y3
+	fp.Add(x3, y2, z2) // X3 := Y2 + Z2
+	fp.Mul(t4, t4, x3) // t4 := t4 * X3
+	fp.Add(x3, t1, t2) // X3 := t1 + t2
 
-	fp.Mul(&x3, &t4, &y3) // X3 := t4 * Y3
-	fp.Mul(&t2, &t3, &t1) // t2 := t3 * t1
-	fp.Sub(&x3, &t2, &x3) // X3 := t2 - X3
+	fp.Sub(t4, t4, x3)     // t4 := t4 - X3
+	fp.Add(x3, &e.x, &e.z) // X3 := X1 + Z1
+	fp.Add(y3, x2, z2)     // Y3 := X2 + Z2
 
-	fp.Mul(&y3, &y3, &t0) // Y3 := Y3 * t0
-	fp.Mul(&t1, &t1, &z3) // t1 := t1 * Z3
-	fp.Add(&y3, &t1, &y3) // Y3 := t1 + Y3
+	fp.Mul(x3, x3, y3) // X3 := X3 * Y3
+	fp.Add(y3, t0, t2) // Y3 := t0 + t2
+	fp.Sub(y3, x3, y3) // Y3 := X3 - Y3
 
-	fp.Mul(&t0, &t0, &t3) // t0 := t0 * t3
-	fp.Mul(&z3, &z3, &t4) // Z3 := Z3 * t4
-	fp.Add(&z3, &z3, &t0) // Z3 := Z3 + t0
+	fp.Add(x3, t0, t0) // X3 := t0 + t0
+	fp.Add(t0, x3, t0) // t0 := X3 + t0
+	fp.Mul(t2, b3, t2) // t2 := b3 * t2
 
-	switch {
-	case element.IsIdentity():
-		e.setCoordinates(&e.x, &e.y, &e.z)
-	case e.IsIdentity():
-		e.setCoordinates(&element.x, &element.y, &element.z)
-	default:
-		e.setCoordinates(&x3, &y3, &z3)
-	}
+	fp.Add(z3, t1, t2) // Z3 := t1 + t2
+	fp.Sub(t1, t1, t2) // t1 := t1 - t2
+	fp.Mul(y3, b3, y3) // Y3 := b3 * Y3
+
+	fp.Mul(x3, t4, y3) // X3 := t4 * Y3
+	fp.Mul(t2, t3, t1) // t2 := t3 * t1
+	fp.Sub(x3, t2, x3) // X3 := t2 - X3
+
+	fp.Mul(y3, y3, t0) // Y3 := Y3 * t0
+	fp.Mul(t1, t1, z3) // t1 := t1 * Z3
+	fp.Add(y3, t1, y3) // Y3 := t1 + Y3
+
+	fp.Mul(t0, t0, t3) // t0 := t0 * t3
+	fp.Mul(z3, z3, t4) // Z3 := Z3 * t4
+	fp.Add(z3, z3, t0) // Z3 := Z3 + t0
+
+	e.setCoordinates(x3, y3, z3)
 
 	return e
 }
@@ -195,7 +210,10 @@ func (e *Element) add(element *Element) *Element {
 		return e
 	}
 
-	return e.addProjectiveComplete(element)
+	s := addScratchPool.Get().(*addScratch)
+	defer addScratchPool.Put(s)
+
+	return e.addProjectiveComplete(s, &element.x, &element.y, &element.z)
 }
 
 // Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
@@ -244,7 +262,7 @@ func (e *Element) Double() *Element {
 }
 
 func (e *Element) negate() *Element {
-	e.y.Neg(&e.y)
+	fp.Neg(&e.y, &e.y)
 	return e
 }
 
@@ -257,44 +275,39 @@ func (e *Element) Negate() *Element {
 	return e.negate()
 }
 
-// Subtract subtracts the input from the receiver, and returns the receiver.
+// Subtract subtracts the input from the receiver, and returns the receiver. The input is negated into pooled storage
+// and not copied, so that, with the limbs backends, subtracting doesn't allocate.
 func (e *Element) Subtract(element *Element) *Element {
 	if element == nil {
 		return e
 	}
 
-	q := element.copy().negate()
+	s := addScratchPool.Get().(*addScratch)
+	defer addScratchPool.Put(s)
 
-	return e.add(q)
+	fp.Neg(&s.negY, &element.y)
+
+	return e.addProjectiveComplete(s, &element.x, &s.negY, &element.z)
 }
 
 func (e *Element) multiply(scalar *Scalar) *Element {
-	if fp.AreEqual(&scalar.scalar, scOne) {
-		return e
-	}
-
-	r0 := newElement()
-	r1 := e.copy()
-
-	for i := scalar.scalar.BitLen() - 1; i >= 0; i-- {
-		if scalar.scalar.Bit(i) == 0 {
-			r1.Add(r0)
-			r0.Double()
-		} else {
-			r0.Add(r1)
-			r1.Double()
-		}
-	}
-
-	return e.set(r0)
+	return e.glvMultiply(scalar)
 }
 
-// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it. If the
+// receiver is the generator as set by Base(), a precomputed table is used, which is several times faster. In both
+// cases, the sequence of group operations and table accesses does not branch on the scalar, including on its zero
+// windows. The field arithmetic is built on math/big, which does not guarantee constant-time execution. Use
+// MultiplyVartime for public scalars.
 func (e *Element) Multiply(scalar *Scalar) *Element {
 	if scalar == nil {
 		return e.Identity()
 	}
 
+	if e.isBase() {
+		return e.baseMultiply(scalar)
+	}
+
 	return e.multiply(scalar)
 }
 
@@ -317,9 +330,70 @@ func (e *Element) Equal(element *Element) int {
 	return e.isEqual(element)
 }
 
+// EqualVartime returns 1 if the elements are equivalent, and 0 otherwise. It compares projective coordinates by
+// cross-multiplication without inversions and returns as soon as a difference is found, so it is faster than Equal
+// but its execution time depends on the inputs. It must only be used on public elements.
+func (e *Element) EqualVartime(element *Element) int {
+	id1, id2 := e.IsIdentity(), element.IsIdentity()
+	if id1 || id2 {
+		if id1 && id2 {
+			return 1
+		}
+
+		return 0
+	}
+
+	var l, r big.Int
+
+	fp.Mul(&l, &e.x, &element.z)
+	fp.Mul(&r, &element.x, &e.z)
+
+	if l.Cmp(&r) != 0 {
+		return 0
+	}
+
+	fp.Mul(&l, &e.y, &element.z)
+	fp.Mul(&r, &element.y, &e.z)
+
+	if l.Cmp(&r) != 0 {
+		return 0
+	}
+
+	return 1
+}
+
+// Cmp compares the compressed encodings of the elements lexicographically, and returns -1 if e < element, 0 if they
+// are equal, and +1 if e > element, which defines a canonical ordering of elements, e.g. for sorting public keys as in
+// BIP-67 or MuSig2 key aggregation. The identity element, encoded with zeros, sorts first. Its execution time depends
+// on the inputs, see CmpConstantTime for secret elements.
+func (e *Element) Cmp(element *Element) int {
+	var a, b [elementLength]byte
+
+	e.encodeTo(a[:])
+	element.encodeTo(b[:])
+
+	return bytes.Compare(a[:], b[:])
+}
+
+// CmpConstantTime returns the same result as Cmp, but its execution time does not depend on where the encodings
+// differ.
+func (e *Element) CmpConstantTime(element *Element) int {
+	var a, b [elementLength]byte
+
+	e.encodeTo(a[:])
+	element.encodeTo(b[:])
+
+	return ctGreaterThan(a[:], b[:]) - ctGreaterThan(b[:], a[:])
+}
+
 // IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
 func (e *Element) IsIdentity() bool {
-	return e.z.Sign() == 0 || e.x.Sign() == 0 && e.y.Sign() == 0
+	return isIdentity(&e.x, &e.y, &e.z)
+}
+
+// isIdentity returns whether the projective coordinates are those of the point at infinity.
+func isIdentity(x, y, z *big.Int) bool {
+	return z.Sign() == 0 || x.Sign() == 0 && y.Sign() == 0
 }
 
 func (e *Element) set(element *Element) *Element {
@@ -330,11 +404,67 @@ func (e *Element) set(element *Element) *Element {
 	return e
 }
 
-// Set sets the receiver to the value of the argument, and returns the receiver.
+// SetAffine sets the receiver to the point with the affine coordinates x and y, and returns an error if they are nil,
+// not in [0, p-1], or not those of a point of the curve. The coordinates are copied, and the receiver is not modified
+// on error.
+func (e *Element) SetAffine(x, y *big.Int) error {
+	if x == nil || y == nil || x.Sign() < 0 || y.Sign() < 0 || x.Cmp(fp.Order()) >= 0 || y.Cmp(fp.Order()) >= 0 {
+		return fmt.Errorf("%w: invalid coordinates", ErrInvalidPointEncoding)
+	}
+
+	p := newElementWithAffine(x, y)
+	if !p.isOnCurve() {
+		return fmt.Errorf("%w: point is not on the curve", ErrInvalidPointEncoding)
+	}
+
+	e.set(p)
+
+	return nil
+}
+
+// CSelect sets the receiver to e1 if cond == 1, and to e2 if cond == 0, without branching on cond, and returns the
+// receiver. cond must be 0 or 1.
+func (e *Element) CSelect(cond int, e1, e2 *Element) *Element {
+	var x, y, z big.Int
+
+	fp.CondSelect(&x, &e1.x, &e2.x, cond)
+	fp.CondSelect(&y, &e1.y, &e2.y, cond)
+	fp.CondSelect(&z, &e1.z, &e2.z, cond)
+	e.setCoordinates(&x, &y, &z)
+
+	return e
+}
+
+// CMove sets the receiver to element if cond == 1, and leaves it unchanged if cond == 0, without branching on cond,
+// and returns the receiver. cond must be 0 or 1.
+func (e *Element) CMove(cond int, element *Element) *Element {
+	return e.CSelect(cond, element, e)
+}
+
+// CSwap swaps the receiver and other if cond == 1, and leaves both unchanged if cond == 0, without branching on cond.
+// cond must be 0 or 1.
+func (e *Element) CSwap(cond int, other *Element) {
+	tmp := e.copy()
+	e.CSelect(cond, other, e)
+	other.CSelect(cond, tmp, other)
+}
+
+// Set sets the receiver to the value of the argument, and returns the receiver. If element is nil, the receiver is
+// set to the identity element.
 func (e *Element) Set(element *Element) *Element {
+	if element == nil {
+		return e.Identity()
+	}
+
 	return e.set(element)
 }
 
+// Hash sets the receiver to HashToGroup(input, dst), and returns it. This avoids allocating a new Element on each
+// call in loops. The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (e *Element) Hash(input, dst []byte) *Element {
+	return e.set(hashToCurve(input, dst))
+}
+
 func (e *Element) copy() *Element {
 	return &Element{
 		x: *new(big.Int).Set(&e.x),
@@ -351,16 +481,90 @@ func (e *Element) Copy() *Element {
 // Encode returns the compressed byte encoding of the element.
 func (e *Element) Encode() []byte {
 	var output [elementLength]byte
+	e.encodeTo(output[:])
+
+	return output[:]
+}
 
+// encodeTo writes the compressed encoding of the element into out, which must be elementLength bytes long.
+func (e *Element) encodeTo(out []byte) {
 	if e.IsIdentity() {
-		return output[:]
+		clear(out)
+		return
 	}
 
 	x, y := e.affine()
-	output[0] = byte(2 | y.Bit(0)&1)
-	x.FillBytes(output[1:])
+	out[0] = byte(2 | y.Bit(0)&1)
+	x.FillBytes(out[1:])
+}
 
-	return output[:]
+// EncodeTo writes the compressed encoding of the element into the first 33 bytes of dst, and returns that sub-slice.
+// It does not allocate the output, and panics if dst is shorter than 33 bytes. Elements that are not in affine form
+// still require a field inversion, which may allocate internally.
+func (e *Element) EncodeTo(dst []byte) []byte {
+	if len(dst) < elementLength {
+		panic(errParamShortBuffer)
+	}
+
+	e.encodeTo(dst[:elementLength])
+
+	return dst[:elementLength]
+}
+
+// encodeUncompressedTo writes the uncompressed encoding of the element into out, which must be
+// elementUncompressedLength bytes long.
+func (e *Element) encodeUncompressedTo(out []byte) {
+	if e.IsIdentity() {
+		clear(out)
+		return
+	}
+
+	x, y := e.affine()
+	out[0] = 4
+	x.FillBytes(out[1 : 1+fieldLength])
+	y.FillBytes(out[1+fieldLength:])
+}
+
+// EncodeUncompressed returns the 65-byte uncompressed SEC 1 encoding of the element, i.e. 0x04 || x || y. The
+// identity element is encoded as 65 zero bytes.
+func (e *Element) EncodeUncompressed() []byte {
+	out := make([]byte, elementUncompressedLength)
+	e.encodeUncompressedTo(out)
+
+	return out
+}
+
+// EncodeUncompressedTo writes the uncompressed encoding of the element into the first 65 bytes of dst, and returns
+// that sub-slice. Like EncodeTo, it does not allocate the output, and panics if dst is shorter than 65 bytes.
+func (e *Element) EncodeUncompressedTo(dst []byte) []byte {
+	if len(dst) < elementUncompressedLength {
+		panic(errParamShortBuffer)
+	}
+
+	e.encodeUncompressedTo(dst[:elementUncompressedLength])
+
+	return dst[:elementUncompressedLength]
+}
+
+// ProjectiveCoordinates returns the big-endian encodings of the internal standard projective coordinates (X : Y : Z)
+// of the element, reduced modulo the field order, whose affine coordinates are (X/Z, Y/Z). This is an advanced API:
+// the representation of an element is not unique and depends on how it was computed, Z is zero for the identity, and
+// the values must be handled as secret if the element is. Use Encode for a canonical representation.
+func (e *Element) ProjectiveCoordinates() (x, y, z [fieldLength]byte) {
+	fp.Mod(new(big.Int).Set(&e.x)).FillBytes(x[:])
+	fp.Mod(new(big.Int).Set(&e.y)).FillBytes(y[:])
+	fp.Mod(new(big.Int).Set(&e.z)).FillBytes(z[:])
+
+	return x, y, z
+}
+
+// Key returns the compressed encoding of the element as a comparable value, e.g. to use elements as map keys for
+// deduplication or caching. Equal elements always have the same key, regardless of their internal representation.
+func (e *Element) Key() [elementLength]byte {
+	var out [elementLength]byte
+	e.encodeTo(out[:])
+
+	return out
 }
 
 // XCoordinate returns the encoded x coordinate of the element, which is the same as Encode().
@@ -368,6 +572,21 @@ func (e *Element) XCoordinate() []byte {
 	return e.Encode()[1:]
 }
 
+// EncodeXOnly returns the 32-byte x-only encoding of the element, as used for BIP-340 public keys, which drops the
+// parity of the y coordinate. The identity element is encoded as 32 zero bytes.
+func (e *Element) EncodeXOnly() [fieldLength]byte {
+	var out [fieldLength]byte
+	copy(out[:], e.Encode()[1:])
+
+	return out
+}
+
+// DecodeXOnly sets the receiver to the decoding of the x-only encoding, i.e. the point with that x coordinate and an
+// even y coordinate, and returns an error on failure. It is equivalent to LiftX.
+func (e *Element) DecodeXOnly(data [fieldLength]byte) error {
+	return e.LiftX(data)
+}
+
 // secp256Polynomial applies y^2=x^3+ax+b to recover y^2 from x.
 func secp256Polynomial(y, x *big.Int) {
 	fp.Mul(y, x, x)
@@ -375,7 +594,36 @@ func secp256Polynomial(y, x *big.Int) {
 	fp.Add(y, y, b)
 }
 
-// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+// isOnCurve returns whether the projective coordinates satisfy y^2 * z = x^3 + b * z^3.
+func (e *Element) isOnCurve() bool {
+	var lhs, rhs, t big.Int
+
+	fp.Square(&lhs, &e.y)
+	fp.Mul(&lhs, &lhs, &e.z)
+
+	fp.Square(&t, &e.z)
+	fp.Mul(&t, &t, &e.z)
+	fp.Mul(&t, &t, b)
+	fp.Square(&rhs, &e.x)
+	fp.Mul(&rhs, &rhs, &e.x)
+	fp.Add(&rhs, &rhs, &t)
+
+	return lhs.Cmp(&rhs) == 0
+}
+
+// IsOnCurve returns whether the element is a point of the curve, i.e. satisfies its equation, or is the identity.
+// Elements obtained through this package's API always are, so this is mainly useful as an explicit sanity check.
+func (e *Element) IsOnCurve() bool {
+	return e.IsIdentity() || e.isOnCurve()
+}
+
+// isIdentityEncoding returns whether data is the all-zero encoding of the identity element, as returned by Encode.
+func isIdentityEncoding(data []byte) bool {
+	return subtle.ConstantTimeCompare(data, make([]byte, elementLength)) == 1
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure. The identity element is
+// rejected with an error matching both ErrInvalidPointEncoding and ErrIdentity, use DecodeAllowIdentity to accept it.
 func (e *Element) Decode(data []byte) error {
 	/*
 		- check coordinates are in the correct range
@@ -384,33 +632,88 @@ func (e *Element) Decode(data []byte) error {
 		- point order validation is not necessary since the cofactor is 1
 	*/
 	if len(data) != elementLength {
-		return errParamInvalidPointEncoding
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidPointEncoding, elementLength, len(data))
+	}
+
+	if isIdentityEncoding(data) {
+		return fmt.Errorf("%w: %w", ErrInvalidPointEncoding, ErrIdentity)
+	}
+
+	return e.decode(data)
+}
+
+// DecodeAllowIdentity is like Decode, but accepts the all-zero encoding of the identity element as returned by
+// Encode, for protocols in which the identity is a legitimate value.
+func (e *Element) DecodeAllowIdentity(data []byte) error {
+	if len(data) == elementLength && isIdentityEncoding(data) {
+		e.Identity()
+		return nil
 	}
 
+	return e.Decode(data)
+}
+
+// decode sets the receiver to the decoding of the compressed encoding of a point other than the identity.
+func (e *Element) decode(data []byte) error {
 	if data[0] != 2 && data[0] != 3 {
-		return errParamInvalidPointEncoding
+		return fmt.Errorf("%w: invalid prefix %#x", ErrInvalidPointEncoding, data[0])
+	}
+
+	return e.decodeCompressed(data[0]&1, data[1:])
+}
+
+// LiftX sets the receiver to the point with the given x coordinate and an even y coordinate, as specified for x-only
+// public keys in BIP-340, and returns an error if x is not the x coordinate of a point of the curve.
+func (e *Element) LiftX(x [fieldLength]byte) error {
+	return e.decodeCompressed(0, x[:])
+}
+
+// DecodeX sets the receiver to the point with the given x coordinate and the y coordinate of parity yParity, which
+// must be 0 (even) or 1 (odd), and returns an error if the parity is invalid or if x is not the x coordinate of a
+// point of the curve. The receiver is not modified on error.
+func (e *Element) DecodeX(x [fieldLength]byte, yParity byte) error {
+	if yParity > 1 {
+		return fmt.Errorf("%w: invalid y parity %d", ErrInvalidPointEncoding, yParity)
+	}
+
+	return e.decodeCompressed(yParity, x[:])
+}
+
+// DecodeXCandidates returns both points with the given x coordinate, the first with an even y coordinate and the
+// second with an odd one, which are each other's negation. It returns an error if x is not the x coordinate of a point
+// of the curve.
+func DecodeXCandidates(x [fieldLength]byte) (even, odd *Element, err error) {
+	even = newElement()
+	if err = even.decodeCompressed(0, x[:]); err != nil {
+		return nil, nil, err
 	}
 
-	x := new(big.Int).SetBytes(data[1:])
+	return even, even.copy().negate(), nil
+}
+
+// decodeCompressed sets the receiver to the point with the given encoded x coordinate, and the y coordinate whose
+// parity is given by yBit.
+func (e *Element) decodeCompressed(yBit byte, data []byte) error {
+	x := new(big.Int).SetBytes(data)
 	if x.Cmp(fp.Order()) != -1 {
-		return errParamInvalidPointEncoding
+		return fmt.Errorf("%w: x coordinate is not lower than the field order", ErrInvalidPointEncoding)
 	}
 
 	var y big.Int
 	secp256Polynomial(&y, x)
 
 	if !fp.IsSquare(&y) {
-		return errParamInvalidPointEncoding
+		return fmt.Errorf("%w: x coordinate is not on the curve", ErrInvalidPointEncoding)
 	}
 
 	fp.SquareRoot(&y, &y)
 
-	cond := int(y.Bit(0)&1) ^ int(data[0]&1)
+	cond := int(y.Bit(0)&1) ^ int(yBit)
 	fp.CondNeg(&y, &y, cond)
 
 	// Identity Check
 	if x.Cmp(scZero) == 0 && y.Cmp(scZero) == 0 {
-		return errIdentity
+		return ErrIdentity
 	}
 
 	e.x.Set(x)
@@ -440,7 +743,34 @@ func (e *Element) MarshalBinary() ([]byte, error) {
 	return e.Encode(), nil
 }
 
+// AppendBinary implements encoding.BinaryAppender, appending the compressed byte encoding of the element to dst. It
+// only allocates if dst has insufficient capacity.
+func (e *Element) AppendBinary(dst []byte) ([]byte, error) {
+	dst = slices.Grow(dst, elementLength)
+	end := len(dst) + elementLength
+	e.encodeTo(dst[len(dst):end])
+
+	return dst[:end], nil
+}
+
 // UnmarshalBinary sets e to the decoding of the byte encoded element.
 func (e *Element) UnmarshalBinary(data []byte) error {
 	return e.Decode(data)
 }
+
+// MarshalText returns the fixed-sized hexadecimal encoding of the compressed element, which is all zeros for the
+// identity.
+func (e *Element) MarshalText() ([]byte, error) {
+	return []byte(e.Hex()), nil
+}
+
+// UnmarshalText sets e to the decoding of the hex encoded compressed element. Unlike DecodeHex, it accepts the
+// encoding of the identity, so that any element produced by MarshalText round trips, e.g. through JSON.
+func (e *Element) UnmarshalText(text []byte) error {
+	encoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return e.DecodeAllowIdentity(encoded)
+}
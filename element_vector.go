@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+// ElementVector is a vector of elements supporting element-wise arithmetic and multi-scalar multiplication, e.g. the
+// generator vectors of vector commitments and inner-product arguments. Operations are applied in place on the
+// receiver, which is returned to allow chaining. Combining vectors of different lengths panics.
+type ElementVector []*Element
+
+// NewElementVector returns a new vector of n elements set to the identity.
+func NewElementVector(n int) ElementVector {
+	v := make(ElementVector, n)
+	for i := range v {
+		v[i] = newElement()
+	}
+
+	return v
+}
+
+func checkVectorLengths(n, m int) {
+	if n != m {
+		panic(errVectorLength)
+	}
+}
+
+// Copy returns a deep copy of the vector.
+func (v ElementVector) Copy() ElementVector {
+	cpy := make(ElementVector, len(v))
+	for i, e := range v {
+		cpy[i] = e.copy()
+	}
+
+	return cpy
+}
+
+// Add sets v[i] = v[i] + w[i] for all i, and returns v.
+func (v ElementVector) Add(w ElementVector) ElementVector {
+	checkVectorLengths(len(v), len(w))
+
+	for i, e := range v {
+		e.Add(w[i])
+	}
+
+	return v
+}
+
+// Multiply sets v[i] = s[i] * v[i] for all i, and returns v.
+func (v ElementVector) Multiply(s ScalarVector) ElementVector {
+	checkVectorLengths(len(v), len(s))
+
+	for i, e := range v {
+		e.Multiply(s[i])
+	}
+
+	return v
+}
+
+// MultiplyScalar sets v[i] = scalar * v[i] for all i, and returns v.
+func (v ElementVector) MultiplyScalar(scalar *Scalar) ElementVector {
+	for _, e := range v {
+		e.Multiply(scalar)
+	}
+
+	return v
+}
+
+// Sum returns the sum of all the elements in the vector.
+func (v ElementVector) Sum() *Element {
+	res := newElement()
+	for _, e := range v {
+		res.Add(e)
+	}
+
+	return res
+}
+
+// MultiScalarMult returns the sum of s[i] * v[i], using constant-time multiplications, so it is suitable for secret
+// scalars. Use MultiScalarMultVartime for public values.
+func (v ElementVector) MultiScalarMult(s ScalarVector) *Element {
+	checkVectorLengths(len(v), len(s))
+
+	res := newElement()
+	for i, e := range v {
+		res.Add(e.copy().Multiply(s[i]))
+	}
+
+	return res
+}
+
+// MultiScalarMultVartime returns the sum of s[i] * v[i], as the package-level MultiScalarMultVartime. Its execution
+// time depends on its inputs, so it must only be used on public values.
+func (v ElementVector) MultiScalarMultVartime(s ScalarVector) *Element {
+	return MultiScalarMultVartime(s, v)
+}
+
+// Equal returns 1 if both vectors have the same length and hold the same elements, and 0 otherwise.
+func (v ElementVector) Equal(w ElementVector) int {
+	if len(v) != len(w) {
+		return 0
+	}
+
+	res := 1
+	for i, e := range v {
+		res &= e.Equal(w[i])
+	}
+
+	return res
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package elgamal implements exponential ElGamal encryption over secp256k1, on top of the secp256k1 package's
+// arithmetic. A scalar m is encrypted "in the exponent" for the public key P = x * G as (r * G, m * G + r * P), which
+// makes ciphertexts additively homomorphic: the sum of two ciphertexts decrypts to the sum of their plaintexts.
+//
+// Decryption only recovers m * G. Recovering m requires a discrete logarithm, which is only feasible for small
+// plaintexts, e.g. votes or counters, and is done here with the baby-step giant-step algorithm over a precomputed
+// Table.
+package elgamal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// CiphertextLength is the byte size of an encoded ciphertext.
+const CiphertextLength = 66
+
+var (
+	// ErrInvalidCiphertext indicates a malformed ciphertext encoding, or a nil input.
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+	// ErrOutOfRange indicates a decrypted plaintext that is not in the range of the decoding table.
+	ErrOutOfRange = errors.New("plaintext out of range")
+)
+
+// Ciphertext is an exponential ElGamal ciphertext (C1, C2) = (r * G, m * G + r * P).
+type Ciphertext struct {
+	C1, C2 *secp256k1.Element
+}
+
+// Encrypt returns the encryption of the scalar m for the public key, with a fresh random nonce read from rand, e.g.
+// crypto/rand.Reader. It returns an error if rand is nil or fails, as for Scalar.RandomFrom.
+func Encrypt(pub *secp256k1.PublicKey, m *secp256k1.Scalar, rand io.Reader) (*Ciphertext, error) {
+	r, err := secp256k1.NewScalar().RandomFrom(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Zero()
+
+	return EncryptWithNonce(pub, m, r), nil
+}
+
+// EncryptUint64 is like Encrypt, for the integer plaintext m.
+func EncryptUint64(pub *secp256k1.PublicKey, m uint64, rand io.Reader) (*Ciphertext, error) {
+	return Encrypt(pub, secp256k1.NewScalar().SetUInt64(m), rand)
+}
+
+// EncryptWithNonce returns the encryption of the scalar m for the public key with the given nonce r, e.g. to build
+// proofs about the ciphertext. The nonce must be secret, uniformly random, and never reused, otherwise the plaintext
+// leaks.
+func EncryptWithNonce(pub *secp256k1.PublicKey, m, r *secp256k1.Scalar) *Ciphertext {
+	return &Ciphertext{
+		C1: secp256k1.ScalarBaseMult(r),
+		C2: pub.Element().Multiply(r).Add(secp256k1.ScalarBaseMult(m)),
+	}
+}
+
+// Decrypt returns m * G, where m is the plaintext of the ciphertext for the private key.
+func Decrypt(key *secp256k1.PrivateKey, c *Ciphertext) (*secp256k1.Element, error) {
+	if c == nil || c.C1 == nil || c.C2 == nil {
+		return nil, fmt.Errorf("%w: nil ciphertext", ErrInvalidCiphertext)
+	}
+
+	d := key.Scalar()
+	defer d.Zero()
+
+	return c.C1.Copy().Multiply(d).Negate().Add(c.C2), nil
+}
+
+// DecryptUint64 returns the integer plaintext of the ciphertext for the private key, looked up in the table. It
+// returns an error wrapping ErrOutOfRange if the plaintext exceeds the table's maximum.
+func DecryptUint64(key *secp256k1.PrivateKey, c *Ciphertext, table *Table) (uint64, error) {
+	p, err := Decrypt(key, c)
+	if err != nil {
+		return 0, err
+	}
+
+	return table.Log(p)
+}
+
+// Copy returns a copy of the ciphertext.
+func (c *Ciphertext) Copy() *Ciphertext {
+	return &Ciphertext{C1: c.C1.Copy(), C2: c.C2.Copy()}
+}
+
+// Add sets the receiver to the component-wise sum of the receiver and the other ciphertext, which encrypts the sum of
+// their plaintexts, and returns it.
+func (c *Ciphertext) Add(other *Ciphertext) *Ciphertext {
+	c.C1.Add(other.C1)
+	c.C2.Add(other.C2)
+
+	return c
+}
+
+// Subtract sets the receiver to the component-wise difference of the receiver and the other ciphertext, which
+// encrypts the difference of their plaintexts, and returns it.
+func (c *Ciphertext) Subtract(other *Ciphertext) *Ciphertext {
+	c.C1.Subtract(other.C1)
+	c.C2.Subtract(other.C2)
+
+	return c
+}
+
+// Multiply sets the receiver to the ciphertext multiplied by the scalar k, which encrypts k times its plaintext, and
+// returns it.
+func (c *Ciphertext) Multiply(k *secp256k1.Scalar) *Ciphertext {
+	c.C1.Multiply(k)
+	c.C2.Multiply(k)
+
+	return c
+}
+
+// Rerandomize adds a fresh encryption of zero for the public key to the receiver, which then encrypts the same
+// plaintext but can't be linked to the original ciphertext. rand is used as for Encrypt. The receiver is left untouched
+// on error.
+func (c *Ciphertext) Rerandomize(pub *secp256k1.PublicKey, rand io.Reader) error {
+	zero, err := Encrypt(pub, secp256k1.NewScalar(), rand)
+	if err != nil {
+		return err
+	}
+
+	c.Add(zero)
+
+	return nil
+}
+
+// Encode returns the 66-byte encoding of the ciphertext, as the concatenation of the compressed encodings of C1 and
+// C2. The identity element is encoded as 33 zero bytes.
+func (c *Ciphertext) Encode() []byte {
+	return append(c.C1.Encode(), c.C2.Encode()...)
+}
+
+// Decode sets the receiver to the decoding of the 66-byte encoding, and returns an error wrapping
+// ErrInvalidCiphertext on failure. The receiver is left untouched on error.
+func (c *Ciphertext) Decode(data []byte) error {
+	if len(data) != CiphertextLength {
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidCiphertext, len(data))
+	}
+
+	c1, c2 := secp256k1.NewElement(), secp256k1.NewElement()
+	if err := c1.DecodeAllowIdentity(data[:CiphertextLength/2]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+
+	if err := c2.DecodeAllowIdentity(data[CiphertextLength/2:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+
+	c.C1, c.C2 = c1, c2
+
+	return nil
+}
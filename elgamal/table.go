@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package elgamal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// Table is a precomputed baby-step giant-step table, decoding m * G back to m for all m in [0, Max()]. It holds about
+// sqrt(Max()) points, and a lookup takes up to as many group operations. It is safe for concurrent use.
+type Table struct {
+	baby  map[[CiphertextLength / 2]byte]uint64
+	giant *secp256k1.Element
+	step  uint64
+	max   uint64
+}
+
+// NewTable returns a decoding table for the plaintexts in [0, maximum]. Building it takes about sqrt(maximum) group
+// operations, so it should be built once and reused.
+func NewTable(maximum uint64) *Table {
+	step := uint64(math.Sqrt(float64(maximum)))
+	for step*step <= maximum && step < math.MaxUint32 {
+		step++
+	}
+
+	t := &Table{
+		baby: make(map[[CiphertextLength / 2]byte]uint64, step),
+		step: step,
+		max:  maximum,
+	}
+
+	p := secp256k1.NewElement()
+	for j := range step {
+		t.baby[p.Key()] = j
+		p.Add(secp256k1.Base())
+	}
+
+	// p now holds step * G.
+	t.giant = p.Negate()
+
+	return t
+}
+
+// Max returns the largest plaintext the table decodes.
+func (t *Table) Max() uint64 {
+	return t.max
+}
+
+// Log returns m such that p = m * G, if m is in [0, Max()], and an error wrapping ErrOutOfRange otherwise. Its
+// execution time depends on m, so it must only be used on values that are public or that can leak through timing.
+func (t *Table) Log(p *secp256k1.Element) (uint64, error) {
+	if p == nil {
+		return 0, fmt.Errorf("%w: nil element", ErrOutOfRange)
+	}
+
+	q := p.Copy()
+	for i := range t.step {
+		if j, ok := t.baby[q.Key()]; ok {
+			if m := i*t.step + j; m <= t.max {
+				return m, nil
+			}
+
+			break
+		}
+
+		q.Add(t.giant)
+	}
+
+	return 0, ErrOutOfRange
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ellswift implements the ElligatorSwift encoding of secp256k1 points as 64-byte strings that are
+// indistinguishable from uniformly random ones, on top of the secp256k1 package's arithmetic. It lets protocols and
+// transports transmit public keys without revealing that they do, e.g. to resist traffic classification and
+// censorship.
+//
+// The encoding is the one of BIP-324 and libsecp256k1's ellswift module, a variant of Elligator Squared (Tibouchi,
+// 2014) for curves with j-invariant 0 (Chávez-Saab et al., "SwiftEC: Shallue–van de Woestijne Indifferentiable
+// Function To Elliptic Curves", 2022). An encoding is the concatenation of two 32-byte big-endian field elements u
+// and t: the point's x coordinate is XSwiftEC(u, t) as specified in BIP-324, and its y coordinate has the parity of t.
+// Every 64-byte string decodes to a point, and every point has many encodings, of which Encode draws one uniformly.
+package ellswift
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/field"
+)
+
+// EncodingLength is the byte size of an ElligatorSwift encoding.
+const EncodingLength = 2 * field.Length
+
+var (
+	// c = sqrt(-3).
+	c = field.NewElement().SetBytesMod([]byte{
+		0x0a, 0x2d, 0x2b, 0xa9, 0x35, 0x07, 0xf1, 0xdf, 0x23, 0x37, 0x70, 0xc2, 0xa7, 0x97, 0x96, 0x2c,
+		0xc6, 0x1f, 0x6d, 0x15, 0xda, 0x14, 0xec, 0xd4, 0x7d, 0x8d, 0x27, 0xae, 0x1c, 0xd5, 0xf8, 0x52,
+	})
+
+	one   = field.NewElement().One()
+	seven = field.NewElement().SetUint64(7)
+	half  = field.NewElement().Invert(field.NewElement().SetUint64(2))
+
+	// cMinus = (1 - c) / 2 and cPlus = (1 + c) / 2.
+	cMinus = halve(field.NewElement().Sub(one, c))
+	cPlus  = halve(field.NewElement().Add(one, c))
+)
+
+// halve sets e to e / 2, and returns it.
+func halve(e *field.Element) *field.Element {
+	return e.Mul(e, half)
+}
+
+// g returns x^3 + 7, the right-hand side of the curve equation.
+func g(x *field.Element) *field.Element {
+	r := field.NewElement().Square(x)
+	r.Mul(r, x)
+
+	return r.Add(r, seven)
+}
+
+// isX returns whether x is the x coordinate of a point of the curve.
+func isX(x *field.Element) bool {
+	_, ok := field.NewElement().Sqrt(g(x))
+	return ok
+}
+
+// xSwiftEC returns the x coordinate XSwiftEC(u, t) as specified in BIP-324.
+func xSwiftEC(u, t *field.Element) *field.Element {
+	u, t = u.Copy(), t.Copy()
+
+	if u.IsZero() {
+		u.One()
+	}
+
+	if t.IsZero() {
+		t.One()
+	}
+
+	gu := g(u)
+	t2 := field.NewElement().Square(t)
+
+	if field.NewElement().Add(gu, t2).IsZero() {
+		t.Add(t, t)
+		t2.Square(t)
+	}
+
+	// X = (u^3 + 7 - t^2) / (2 * t), Y = (X + t) / (c * u).
+	x := field.NewElement().Sub(gu, t2)
+	x.Mul(x, field.NewElement().Invert(field.NewElement().Add(t, t)))
+
+	y := field.NewElement().Add(x, t)
+	y.Mul(y, field.NewElement().Invert(field.NewElement().Mul(c, u)))
+
+	// Candidates are u + 4 * Y^2, -X / (2 * Y) - u / 2, and X / (2 * Y) - u / 2, of which at least one is on the curve.
+	x1 := field.NewElement().Square(y)
+	x1.Add(x1, x1).Add(x1, x1).Add(x1, u)
+
+	if isX(x1) {
+		return x1
+	}
+
+	q := field.NewElement().Mul(x, field.NewElement().Invert(field.NewElement().Add(y, y)))
+	uh := field.NewElement().Mul(u, half)
+
+	x2 := field.NewElement().Negate(q)
+	x2.Sub(x2, uh)
+
+	if isX(x2) {
+		return x2
+	}
+
+	return q.Sub(q, uh)
+}
+
+// xSwiftECInv returns a t such that xSwiftEC(u, t) = x, or nil if there is none for the case in [0, 7], following
+// XSwiftECInv as specified in BIP-324. x must be the x coordinate of a point of the curve.
+func xSwiftECInv(u, x *field.Element, caseNum int) *field.Element {
+	var v, s *field.Element
+
+	u2 := field.NewElement().Square(u)
+
+	if caseNum&2 == 0 {
+		if isX(field.NewElement().Negate(field.NewElement().Add(x, u))) {
+			return nil
+		}
+
+		// s = -(u^3 + 7) / (u^2 + u * v + v^2), with v = x.
+		v = x.Copy()
+		d := field.NewElement().Mul(u, v)
+		d.Add(d, u2).Add(d, field.NewElement().Square(v))
+
+		s = field.NewElement().Mul(g(u), d.Invert(d))
+		s.Negate(s)
+	} else {
+		s = field.NewElement().Sub(x, u)
+		if s.IsZero() {
+			return nil
+		}
+
+		// r = sqrt(-s * (4 * (u^3 + 7) + 3 * u^2 * s)), and v = (r / s - u) / 2.
+		a := g(u)
+		a.Add(a, a).Add(a, a)
+
+		b := field.NewElement().Mul(u2, s)
+		a.Add(a, b).Add(a, b).Add(a, b)
+		a.Mul(a, s).Negate(a)
+
+		r, ok := field.NewElement().Sqrt(a)
+		if !ok || (caseNum&1 == 1 && r.IsZero()) {
+			return nil
+		}
+
+		v = r.Mul(r, field.NewElement().Invert(s))
+		halve(v.Sub(v, u))
+	}
+
+	w, ok := field.NewElement().Sqrt(s)
+	if !ok {
+		return nil
+	}
+
+	// t = ±w * (u * (1 ± c) / 2 + v), with the signs selected by bits 0 and 2 of the case.
+	k := cMinus
+	if caseNum&1 == 1 {
+		k = cPlus
+	}
+
+	t := field.NewElement().Mul(u, k)
+	t.Add(t, v).Mul(t, w)
+
+	if caseNum&5 == 0 || caseNum&5 == 5 {
+		t.Negate(t)
+	}
+
+	return t
+}
+
+// Encode returns a uniformly random ElligatorSwift encoding of the element, drawing randomness from rand, e.g.
+// crypto/rand.Reader. It returns secp256k1.ErrIdentity for a nil or identity element, which has no encoding, and an
+// error wrapping secp256k1.ErrNilRandomSource or secp256k1.ErrRandomSource if rand is nil or fails. Encoding is meant for public keys, and its
+// execution time depends on the element and the randomness.
+func Encode(e *secp256k1.Element, rand io.Reader) ([EncodingLength]byte, error) {
+	var out [EncodingLength]byte
+
+	if e == nil || e.IsIdentity() {
+		return out, secp256k1.ErrIdentity
+	}
+
+	if rand == nil {
+		return out, secp256k1.ErrNilRandomSource
+	}
+
+	enc := e.Encode()
+	x := field.NewElement().SetBytesMod(enc[1:])
+	odd := enc[0] == 3
+
+	var r [field.Length + 1]byte
+
+	for {
+		if _, err := io.ReadFull(rand, r[:]); err != nil {
+			return out, fmt.Errorf("%w: %w", secp256k1.ErrRandomSource, err)
+		}
+
+		// A zero u or t would decode as 1, so they can't be used with their own preimages.
+		u := field.NewElement().SetBytesMod(r[:field.Length])
+		if u.IsZero() {
+			continue
+		}
+
+		t := xSwiftECInv(u, x, int(r[field.Length]&7))
+		if t == nil || t.IsZero() {
+			continue
+		}
+
+		// t and -t decode to the same x coordinate, and the sign of t selects the y coordinate.
+		if t.IsOdd() != odd {
+			t.Negate(t)
+		}
+
+		ub, tb := u.Bytes32(), t.Bytes32()
+		copy(out[:field.Length], ub[:])
+		copy(out[field.Length:], tb[:])
+
+		return out, nil
+	}
+}
+
+// Decode returns the element encoded by data. Every 64-byte string is a valid encoding, and u and t are reduced modulo
+// the field order, so Decode never fails and never returns the identity.
+func Decode(data [EncodingLength]byte) *secp256k1.Element {
+	u := field.NewElement().SetBytesMod(data[:field.Length])
+	t := field.NewElement().SetBytesMod(data[field.Length:])
+
+	var parity byte
+	if t.IsOdd() {
+		parity = 1
+	}
+
+	e := secp256k1.NewElement()
+	if err := e.DecodeX(xSwiftEC(u, t).Bytes32(), parity); err != nil {
+		panic(err) // xSwiftEC always returns the x coordinate of a point of the curve.
+	}
+
+	return e
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import "errors"
+
+// Errors returned by this package. Functions may wrap them with additional context, so they should be tested for with
+// errors.Is rather than compared directly.
+var (
+	// ErrInvalidScalarLength indicates an invalid scalar length.
+	ErrInvalidScalarLength = errors.New("invalid scalar length")
+
+	// ErrEmptyScalar indicates a forbidden nil or empty scalar.
+	ErrEmptyScalar = errors.New("nil or empty scalar")
+
+	// ErrScalarTooBig reports an error when the input scalar is too big.
+	ErrScalarTooBig = errors.New("scalar too big")
+
+	// ErrInvalidPointEncoding indicates an invalid point encoding has been provided.
+	ErrInvalidPointEncoding = errors.New("invalid point encoding")
+
+	// ErrIdentity indicates that the identity point (or point at infinity) has been encountered.
+	ErrIdentity = errors.New("infinity/identity point")
+
+	// ErrNilOperand indicates a nil operand, and is returned by the Strict variants of the arithmetic methods, e.g.
+	// Scalar.AddStrict.
+	ErrNilOperand = errors.New("nil operand")
+
+	// ErrNilRandomSource indicates a nil random source.
+	ErrNilRandomSource = errors.New("nil random source")
+
+	// ErrRandomSource indicates that the random source failed or did not yield a usable value.
+	ErrRandomSource = errors.New("random source failure")
+
+	// ErrInvalidPrivateKey indicates an invalid private key, which must be a canonical non-zero scalar.
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+
+	// ErrInvalidTweak indicates a key tweak that is zero where forbidden, or that yields a zero private key or the
+	// identity as a public key.
+	ErrInvalidTweak = errors.New("invalid tweak")
+
+	// ErrInvalidDER indicates a malformed DER key encoding.
+	ErrInvalidDER = errors.New("invalid DER key encoding")
+
+	// ErrInvalidPEM indicates PEM data without a block of the expected type.
+	ErrInvalidPEM = errors.New("invalid PEM key encoding")
+
+	// ErrInvalidJWK indicates a malformed JSON Web Key.
+	ErrInvalidJWK = errors.New("invalid JWK")
+
+	// ErrUnsupportedCurve indicates that a foreign key is not defined over secp256k1.
+	ErrUnsupportedCurve = errors.New("unsupported curve")
+
+	// ErrEmptyDST indicates a forbidden nil or empty domain separation tag.
+	ErrEmptyDST = errors.New("nil or empty DST")
+
+	// ErrShortDST indicates a domain separation tag shorter than required by the DST policy.
+	ErrShortDST = errors.New("DST too short")
+
+	// ErrUnknownSuite indicates an unsupported hash-to-curve suite identifier.
+	ErrUnknownSuite = errors.New("unknown hash-to-curve suite")
+
+	// ErrNoParticipants indicates an empty set of participant identifiers.
+	ErrNoParticipants = errors.New("empty set of participant identifiers")
+
+	// ErrNilIdentifier indicates a nil participant identifier.
+	ErrNilIdentifier = errors.New("nil participant identifier")
+
+	// ErrZeroIdentifier indicates a participant identifier set to zero.
+	ErrZeroIdentifier = errors.New("participant identifier is zero")
+
+	// ErrIdentifierNotInSet indicates that the identifier is not part of the participant set.
+	ErrIdentifierNotInSet = errors.New("identifier is not in the set of participants")
+
+	// ErrDuplicateIdentifier indicates that the participant set contains duplicate identifiers.
+	ErrDuplicateIdentifier = errors.New("duplicate participant identifier")
+)
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package field provides arithmetic in the base field of secp256k1, i.e. the integers modulo
+// p = 2^256 - 2^32 - 977, over which the curve points' coordinates are defined.
+package field
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+
+	"github.com/bytemare/secp256k1/internal/field"
+)
+
+// Length is the byte size of an encoded field element.
+const Length = 32
+
+// ErrInvalidEncoding indicates an encoding that is not 32 bytes long or not lower than the field order.
+var ErrInvalidEncoding = errors.New("invalid field element encoding")
+
+var fp = field.NewField(new(big.Int).SetBytes([]byte{
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 254, 255, 255, 252, 47,
+}))
+
+// Element is an element of the base field. Its zero value is the zero element. All methods set the receiver to the
+// result and return it, so that calls can be chained, and the receiver may alias any of the operands.
+type Element struct {
+	v big.Int
+}
+
+// NewElement returns a new element set to zero.
+func NewElement() *Element {
+	return &Element{}
+}
+
+// Order returns the big-endian encoding of the field order p.
+func Order() []byte {
+	return fp.Order().Bytes()
+}
+
+// Zero sets e to 0, and returns it.
+func (e *Element) Zero() *Element {
+	e.v.SetUint64(0)
+	return e
+}
+
+// One sets e to 1, and returns it.
+func (e *Element) One() *Element {
+	e.v.SetUint64(1)
+	return e
+}
+
+// SetUint64 sets e to i, and returns it.
+func (e *Element) SetUint64(i uint64) *Element {
+	e.v.SetUint64(i)
+	return e
+}
+
+// Set sets e to a, and returns it.
+func (e *Element) Set(a *Element) *Element {
+	e.v.Set(&a.v)
+	return e
+}
+
+// Copy returns a copy of e.
+func (e *Element) Copy() *Element {
+	return new(Element).Set(e)
+}
+
+// Add sets e to a + b, and returns it.
+func (e *Element) Add(a, b *Element) *Element {
+	fp.Add(&e.v, &a.v, &b.v)
+	return e
+}
+
+// Sub sets e to a - b, and returns it.
+func (e *Element) Sub(a, b *Element) *Element {
+	fp.Sub(&e.v, &a.v, &b.v)
+	return e
+}
+
+// Negate sets e to -a, and returns it.
+func (e *Element) Negate(a *Element) *Element {
+	fp.Neg(&e.v, &a.v)
+	return e
+}
+
+// Mul sets e to a * b, and returns it.
+func (e *Element) Mul(a, b *Element) *Element {
+	fp.Mul(&e.v, &a.v, &b.v)
+	return e
+}
+
+// Square sets e to a^2, and returns it.
+func (e *Element) Square(a *Element) *Element {
+	fp.Square(&e.v, &a.v)
+	return e
+}
+
+// Invert sets e to 1/a, and returns it. The inverse of zero is zero.
+func (e *Element) Invert(a *Element) *Element {
+	fp.Inv(&e.v, &a.v)
+	return e
+}
+
+// Pow sets e to a^exp, where exp is a big-endian unsigned integer, and returns it. The sequence of operations only
+// depends on the length of exp and not on its value, so exp may be secret. a^0 is 1, including for a = 0.
+func (e *Element) Pow(a *Element, exp []byte) *Element {
+	var r, t big.Int
+
+	base := new(big.Int).Set(&a.v) // a may alias e
+	r.SetUint64(1)
+
+	for _, b := range exp {
+		for i := 7; i >= 0; i-- {
+			fp.Square(&r, &r)
+			fp.Mul(&t, &r, base)
+			fp.CondSelect(&r, &t, &r, int(b>>i&1))
+		}
+	}
+
+	e.v.Set(&r)
+
+	return e
+}
+
+// Sqrt sets e to a square root of a and returns it with true if a is a square. Otherwise, e is left unchanged and
+// Sqrt returns e and false. Of the two square roots, the one returned is not specified, use IsOdd and Negate to
+// select one.
+func (e *Element) Sqrt(a *Element) (*Element, bool) {
+	var r, check big.Int
+
+	fp.SquareRoot(&r, &a.v)
+	fp.Square(&check, &r)
+
+	if check.Cmp(&a.v) != 0 {
+		return e, false
+	}
+
+	e.v.Set(&r)
+
+	return e, true
+}
+
+// Equal returns 1 if e and a are equal, and 0 otherwise.
+func (e *Element) Equal(a *Element) int {
+	be, ba := e.Bytes32(), a.Bytes32()
+	return subtle.ConstantTimeCompare(be[:], ba[:])
+}
+
+// IsZero returns whether e is 0.
+func (e *Element) IsZero() bool {
+	return e.v.Sign() == 0
+}
+
+// IsOdd returns whether e is odd, as used for the y-coordinate parity in compressed point encodings.
+func (e *Element) IsOdd() bool {
+	return e.v.Bit(0) == 1
+}
+
+// Bytes returns the 32-byte big-endian encoding of e.
+func (e *Element) Bytes() []byte {
+	b := e.Bytes32()
+	return b[:]
+}
+
+// Bytes32 returns the 32-byte big-endian encoding of e as an array, which doesn't escape to the heap.
+func (e *Element) Bytes32() [Length]byte {
+	var b [Length]byte
+
+	e.v.FillBytes(b[:])
+
+	return b
+}
+
+// SetBytes sets e to the 32-byte big-endian encoding in, and returns it. It returns ErrInvalidEncoding
+// ErrInvalidEncoding if in is not 32 bytes long or not lower than the field order, in which case e is unchanged.
+func (e *Element) SetBytes(in []byte) (*Element, error) {
+	if len(in) != Length {
+		return nil, ErrInvalidEncoding
+	}
+
+	var v big.Int
+	if v.SetBytes(in).Cmp(fp.Order()) >= 0 {
+		return nil, ErrInvalidEncoding
+	}
+
+	e.v.Set(&v)
+
+	return e, nil
+}
+
+// SetBytesMod sets e to the big-endian integer in, of any length, reduced modulo the field order, and returns it.
+func (e *Element) SetBytesMod(in []byte) *Element {
+	e.v.SetBytes(in)
+	fp.Mod(&e.v)
+
+	return e
+}
+
+// BigInt returns a copy of e as a big.Int in [0, p[.
+func (e *Element) BigInt() *big.Int {
+	return new(big.Int).Set(&e.v)
+}
+
+// SetBigInt sets e to x reduced modulo the field order, and returns it.
+func (e *Element) SetBigInt(x *big.Int) *Element {
+	fp.Mod(e.v.Set(x))
+	return e
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package frost implements the two-round FROST threshold Schnorr signing protocol with the FROST(secp256k1, SHA-256)
+// ciphersuite as specified in RFC 9591, on top of the secp256k1 package's arithmetic.
+//
+// Key generation, i.e. the distribution of the secret shares of the group's private key by a trusted dealer or a
+// distributed key generation protocol, is left to the caller. In the first round, each participant generates a nonce
+// with Commit and sends the commitment to the coordinator. In the second round, the coordinator sends the list of
+// commitments and the message to the participants, who create a Session from them and produce a signature share with
+// Session.Sign. The coordinator checks the shares with Session.VerifyShare, and aggregates them into a signature with
+// Session.Aggregate, which verifies under the group public key with Verify.
+package frost
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// contextString is the context string of the FROST(secp256k1, SHA-256) ciphersuite.
+const contextString = "FROST-secp256k1-SHA256-v1"
+
+var (
+	// ErrInvalidCommitment indicates a nil or invalid commitment, or a commitment list that is not sorted by
+	// participant identifier or that contains duplicate identifiers.
+	ErrInvalidCommitment = errors.New("invalid commitment")
+
+	// ErrInvalidNonce indicates a nonce that has already been used, or that does not match the participant's
+	// commitment.
+	ErrInvalidNonce = errors.New("invalid nonce")
+
+	// ErrInvalidSignatureShare indicates a nil or invalid signature share.
+	ErrInvalidSignatureShare = errors.New("invalid signature share")
+
+	// ErrInvalidSignature indicates an invalid signature encoding.
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// h1 derives binding factors, h2 the challenge, and h3 nonces, as hash_to_field with expand_message_xmd and SHA-256.
+func h1(input []byte) *secp256k1.Scalar {
+	return secp256k1.HashToScalar(input, []byte(contextString+"rho"))
+}
+
+func h2(input []byte) *secp256k1.Scalar {
+	return secp256k1.HashToScalar(input, []byte(contextString+"chal"))
+}
+
+func h3(input []byte) *secp256k1.Scalar {
+	return secp256k1.HashToScalar(input, []byte(contextString+"nonce"))
+}
+
+// h4 hashes the message, and h5 the commitment list.
+func h4(input []byte) []byte {
+	return sha256Sum(contextString+"msg", input)
+}
+
+func h5(input []byte) []byte {
+	return sha256Sum(contextString+"com", input)
+}
+
+func sha256Sum(prefix string, input []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(prefix))
+	h.Write(input)
+
+	return h.Sum(nil)
+}
+
+// challenge returns the Schnorr challenge of the group commitment r for the group public key and message.
+func challenge(r, groupKey *secp256k1.Element, message []byte) *secp256k1.Scalar {
+	input := make([]byte, 0, 2*secp256k1.ElementLength()+len(message))
+	input = append(input, r.Encode()...)
+	input = append(input, groupKey.Encode()...)
+
+	return h2(append(input, message...))
+}
+
+// signatureLength is the byte size of an encoded signature.
+const signatureLength = 65
+
+// Signature is a FROST signature (R, z), which is a Schnorr signature for the group public key.
+type Signature struct {
+	R *secp256k1.Element
+	Z *secp256k1.Scalar
+}
+
+// Encode returns the 65-byte encoding of the signature, i.e. the compressed encoding of R followed by that of z.
+func (sig *Signature) Encode() []byte {
+	out := make([]byte, 0, signatureLength)
+	out = append(out, sig.R.Encode()...)
+
+	return append(out, sig.Z.Encode()...)
+}
+
+// Decode sets the receiver to the decoding of the 65-byte encoding of a signature, and returns an error wrapping
+// ErrInvalidSignature on failure. The receiver is not modified on error.
+func (sig *Signature) Decode(data []byte) error {
+	if len(data) != signatureLength {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, signatureLength, len(data))
+	}
+
+	r, z := secp256k1.NewElement(), secp256k1.NewScalar()
+	if err := r.Decode(data[:secp256k1.ElementLength()]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	if err := z.Decode(data[secp256k1.ElementLength():]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	sig.R, sig.Z = r, z
+
+	return nil
+}
+
+// Verify returns whether sig is a valid signature of the message for the group public key, as specified in RFC 9591,
+// section 6.1. Verification only handles public values, and is not constant-time.
+func Verify(groupKey *secp256k1.Element, message []byte, sig *Signature) bool {
+	if groupKey == nil || groupKey.IsIdentity() || sig == nil || sig.R == nil || sig.Z == nil || sig.R.IsIdentity() {
+		return false
+	}
+
+	// z * G - c * PK = R.
+	c := challenge(sig.R, groupKey, message)
+	r := secp256k1.DoubleScalarBaseMultVartime(sig.Z, secp256k1.NewScalar().Subtract(c), groupKey)
+
+	return r.Equal(sig.R) == 1
+}
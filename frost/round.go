@@ -0,0 +1,305 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package frost
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// Commitment is a participant's public commitment to its hiding and binding nonces, sent in the first round.
+type Commitment struct {
+	ID      *secp256k1.Scalar
+	Hiding  *secp256k1.Element
+	Binding *secp256k1.Element
+}
+
+// check returns an error if the commitment or one of its fields is nil, or if the identifier is zero or a nonce
+// commitment is the identity.
+func (c *Commitment) check() error {
+	if c == nil || c.ID == nil || c.Hiding == nil || c.Binding == nil {
+		return fmt.Errorf("%w: nil commitment or field", ErrInvalidCommitment)
+	}
+
+	if c.ID.IsZero() {
+		return fmt.Errorf("%w: %w", ErrInvalidCommitment, secp256k1.ErrZeroIdentifier)
+	}
+
+	if c.Hiding.IsIdentity() || c.Binding.IsIdentity() {
+		return fmt.Errorf("%w: %w", ErrInvalidCommitment, secp256k1.ErrIdentity)
+	}
+
+	return nil
+}
+
+// Nonce holds a participant's secret hiding and binding nonces. It must only be used for a single signature: it is
+// erased by Session.Sign, and must never be serialized or copied, since signing two different messages with the same
+// nonce reveals the participant's secret share.
+type Nonce struct {
+	hiding, binding *secp256k1.Scalar
+}
+
+// erase zeroes the nonces, so that a subsequent use fails.
+func (n *Nonce) erase() {
+	n.hiding.Zero()
+	n.binding.Zero()
+}
+
+// nonceGenerate returns a nonce derived from 32 bytes read from rand and the secret, as specified by nonce_generate
+// in RFC 9591.
+func nonceGenerate(rand io.Reader, secret *secp256k1.Scalar) (*secp256k1.Scalar, error) {
+	input := make([]byte, 32, 32+secp256k1.ScalarLength())
+	if _, err := io.ReadFull(rand, input); err != nil {
+		return nil, fmt.Errorf("%w: %w", secp256k1.ErrRandomSource, err)
+	}
+
+	return h3(append(input, secret.Encode()...)), nil
+}
+
+// Commit returns a fresh nonce and the corresponding commitment of the participant with identifier id and secret
+// share, as specified in RFC 9591, section 5.1. The secret share is mixed into the nonces, so that they remain secret
+// if the random source is weak. rand is e.g. crypto/rand.Reader. It returns an error if the identifier is zero, and
+// one wrapping secp256k1.ErrNilRandomSource or secp256k1.ErrRandomSource if rand is nil or fails.
+func Commit(id, secret *secp256k1.Scalar, rand io.Reader) (*Nonce, *Commitment, error) {
+	if id == nil || secret == nil {
+		return nil, nil, secp256k1.ErrNilIdentifier
+	}
+
+	if id.IsZero() {
+		return nil, nil, secp256k1.ErrZeroIdentifier
+	}
+
+	if rand == nil {
+		return nil, nil, secp256k1.ErrNilRandomSource
+	}
+
+	hiding, err := nonceGenerate(rand, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	binding, err := nonceGenerate(rand, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &Nonce{hiding: hiding, binding: binding}, &Commitment{
+		ID:      id.Copy(),
+		Hiding:  secp256k1.ScalarBaseMult(hiding),
+		Binding: secp256k1.ScalarBaseMult(binding),
+	}, nil
+}
+
+// Session holds the values shared by the participants to sign a message in the second round, derived from the group
+// public key, the list of commitments of the signing participants, and the message.
+type Session struct {
+	groupKey     *secp256k1.Element
+	commitments  []*Commitment
+	participants []*secp256k1.Scalar
+	factors      []*secp256k1.Scalar
+	r            *secp256k1.Element
+	challenge    *secp256k1.Scalar
+}
+
+// NewSession returns the signing session of the message for the group public key and the commitments of the signing
+// participants, which must be sorted by increasing identifier, as specified in RFC 9591. It computes the binding
+// factors, the group commitment, and the challenge. It returns an error wrapping ErrInvalidCommitment if the list is
+// empty, not sorted, contains duplicate identifiers, or if one of the commitments is invalid.
+func NewSession(groupKey *secp256k1.Element, commitments []*Commitment, message []byte) (*Session, error) {
+	if groupKey == nil || groupKey.IsIdentity() {
+		return nil, fmt.Errorf("%w: nil or identity group public key", secp256k1.ErrIdentity)
+	}
+
+	if len(commitments) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCommitment, secp256k1.ErrNoParticipants)
+	}
+
+	s := &Session{
+		groupKey:     groupKey.Copy(),
+		commitments:  make([]*Commitment, len(commitments)),
+		participants: make([]*secp256k1.Scalar, len(commitments)),
+	}
+
+	for i, c := range commitments {
+		if err := c.check(); err != nil {
+			return nil, err
+		}
+
+		if i > 0 && c.ID.LessOrEqual(commitments[i-1].ID) == 1 {
+			return nil, fmt.Errorf("%w: identifiers are not sorted or not unique", ErrInvalidCommitment)
+		}
+
+		s.commitments[i] = &Commitment{ID: c.ID.Copy(), Hiding: c.Hiding.Copy(), Binding: c.Binding.Copy()}
+		s.participants[i] = s.commitments[i].ID
+	}
+
+	s.factors = bindingFactors(s.groupKey, s.commitments, message)
+	s.r = groupCommitment(s.commitments, s.factors)
+	s.challenge = challenge(s.r, s.groupKey, message)
+
+	return s, nil
+}
+
+// encodeCommitments returns the encoding of the commitment list, as specified by encode_group_commitment_list.
+func encodeCommitments(commitments []*Commitment) []byte {
+	out := make([]byte, 0, len(commitments)*(secp256k1.ScalarLength()+2*secp256k1.ElementLength()))
+	for _, c := range commitments {
+		out = append(out, c.ID.Encode()...)
+		out = append(out, c.Hiding.Encode()...)
+		out = append(out, c.Binding.Encode()...)
+	}
+
+	return out
+}
+
+// bindingFactors returns the binding factors of the participants, in the order of the commitment list, as specified
+// by compute_binding_factors.
+func bindingFactors(groupKey *secp256k1.Element, commitments []*Commitment, message []byte) []*secp256k1.Scalar {
+	prefix := append(groupKey.Encode(), h4(message)...)
+	prefix = append(prefix, h5(encodeCommitments(commitments))...)
+	factors := make([]*secp256k1.Scalar, len(commitments))
+
+	for i, c := range commitments {
+		factors[i] = h1(append(prefix[:len(prefix):len(prefix)], c.ID.Encode()...))
+	}
+
+	return factors
+}
+
+// groupCommitment returns the sum of the participants' hiding commitments and binding commitments multiplied by
+// their binding factors, as specified by compute_group_commitment.
+func groupCommitment(commitments []*Commitment, factors []*secp256k1.Scalar) *secp256k1.Element {
+	r := secp256k1.NewElement()
+	for i, c := range commitments {
+		r.Add(c.Hiding).Add(c.Binding.Copy().Multiply(factors[i]))
+	}
+
+	return r
+}
+
+// index returns the index of the participant in the commitment list, or an error if it is not part of it.
+func (s *Session) index(id *secp256k1.Scalar) (int, error) {
+	if id == nil {
+		return 0, secp256k1.ErrNilIdentifier
+	}
+
+	for i, p := range s.participants {
+		if p.Equal(id) == 1 {
+			return i, nil
+		}
+	}
+
+	return 0, secp256k1.ErrIdentifierNotInSet
+}
+
+// BindingFactor returns the binding factor of the participant, or an error if it is not one of the signers.
+func (s *Session) BindingFactor(id *secp256k1.Scalar) (*secp256k1.Scalar, error) {
+	i, err := s.index(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.factors[i].Copy(), nil
+}
+
+// GroupCommitment returns the group commitment R, which is the first component of the signature.
+func (s *Session) GroupCommitment() *secp256k1.Element {
+	return s.r.Copy()
+}
+
+// Challenge returns the Schnorr challenge of the group commitment for the group public key and the message.
+func (s *Session) Challenge() *secp256k1.Scalar {
+	return s.challenge.Copy()
+}
+
+// Sign returns the participant's signature share with its secret share and nonce, as specified in RFC 9591,
+// section 5.2, and erases the nonce so that it can't be used again. It returns an error wrapping ErrInvalidNonce if
+// the nonce has already been used or does not match the participant's commitment, or an error if the participant is
+// not one of the signers.
+func (s *Session) Sign(id, secret *secp256k1.Scalar, nonce *Nonce) (*secp256k1.Scalar, error) {
+	if secret == nil || nonce == nil {
+		return nil, fmt.Errorf("%w: nil secret share or nonce", ErrInvalidNonce)
+	}
+
+	defer nonce.erase()
+
+	i, err := s.index(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if nonce.hiding.IsZero() || nonce.binding.IsZero() {
+		return nil, fmt.Errorf("%w: nonce already used", ErrInvalidNonce)
+	}
+
+	c := s.commitments[i]
+	if secp256k1.ScalarBaseMult(nonce.hiding).Equal(c.Hiding) != 1 ||
+		secp256k1.ScalarBaseMult(nonce.binding).Equal(c.Binding) != 1 {
+		return nil, fmt.Errorf("%w: nonce does not match the commitment", ErrInvalidNonce)
+	}
+
+	lambda, err := secp256k1.LagrangeCoefficient(id, s.participants)
+	if err != nil {
+		return nil, err
+	}
+
+	// z = hiding + binding * rho + lambda * secret * c.
+	share := nonce.binding.Copy().Multiply(s.factors[i]).Add(nonce.hiding)
+
+	return share.Add(lambda.Multiply(secret).Multiply(s.challenge)), nil
+}
+
+// VerifyShare returns whether share is a valid signature share of the participant with the public key share, i.e. the
+// public key of its secret share, as specified in RFC 9591, section 5.4. This allows identifying a participant that
+// produced an invalid share, which aggregation does not.
+func (s *Session) VerifyShare(id *secp256k1.Scalar, publicShare *secp256k1.Element, share *secp256k1.Scalar) bool {
+	if publicShare == nil || share == nil {
+		return false
+	}
+
+	i, err := s.index(id)
+	if err != nil {
+		return false
+	}
+
+	lambda, err := secp256k1.LagrangeCoefficient(id, s.participants)
+	if err != nil {
+		return false
+	}
+
+	// z * G = hiding + binding * rho + publicShare * (c * lambda).
+	c := s.commitments[i]
+	r := c.Binding.Copy().Multiply(s.factors[i]).Add(c.Hiding)
+	r.Add(publicShare.Copy().Multiply(lambda.Multiply(s.challenge)))
+
+	return secp256k1.ScalarBaseMult(share).Equal(r) == 1
+}
+
+// Aggregate returns the signature combining the signature shares of all signing participants, as specified in
+// RFC 9591, section 5.3. The result is only valid if all shares are, which can be checked with VerifyShare.
+func (s *Session) Aggregate(shares []*secp256k1.Scalar) (*Signature, error) {
+	if len(shares) != len(s.participants) {
+		return nil, fmt.Errorf("%w: expected %d shares, got %d", ErrInvalidSignatureShare, len(s.participants),
+			len(shares))
+	}
+
+	z := secp256k1.NewScalar()
+
+	for i, share := range shares {
+		if share == nil {
+			return nil, fmt.Errorf("%w: nil share at index %d", ErrInvalidSignatureShare, i)
+		}
+
+		z.Add(share)
+	}
+
+	return &Signature{R: s.r.Copy(), Z: z}, nil
+}
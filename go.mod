@@ -2,10 +2,10 @@ module github.com/bytemare/secp256k1
 
 go 1.22.2
 
-require github.com/bytemare/hash2curve v0.3.0
-
 require (
-	github.com/bytemare/hash v0.3.0 // indirect
-	golang.org/x/crypto v0.27.0 // indirect
-	golang.org/x/sys v0.25.0 // indirect
+	github.com/bytemare/hash v0.3.0
+	github.com/bytemare/hash2curve v0.3.0
+	golang.org/x/crypto v0.27.0
 )
+
+require golang.org/x/sys v0.25.0 // indirect
@@ -9,7 +9,19 @@
 // Package secp256k1 allows simple and abstracted operations in the Secp256k1 group.
 package secp256k1
 
-import "slices"
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/bytemare/hash"
+	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/secp256k1/internal/field"
+)
 
 const (
 	// H2CSECP256K1 represents the hash-to-curve string identifier for Secp256k1.
@@ -17,6 +29,12 @@ const (
 
 	// E2CSECP256K1 represents the encode-to-curve string identifier for Secp256k1.
 	E2CSECP256K1 = "secp256k1_XMD:SHA-256_SSWU_NU_"
+
+	// H2CSECP256K1XOF represents the hash-to-curve string identifier for Secp256k1 with SHAKE256 expansion.
+	H2CSECP256K1XOF = "secp256k1_XOF:SHAKE256_SSWU_RO_"
+
+	// E2CSECP256K1XOF represents the encode-to-curve string identifier for Secp256k1 with SHAKE256 expansion.
+	E2CSECP256K1XOF = "secp256k1_XOF:SHAKE256_SSWU_NU_"
 )
 
 // Base returns the group's base point a.k.a. canonical generator.
@@ -24,29 +42,224 @@ func Base() *Element {
 	return newElement().Base()
 }
 
+// generatorHDST is the domain separation tag used to derive GeneratorH.
+const generatorHDST = "github.com/bytemare/secp256k1:GeneratorH:" + H2CSECP256K1
+
+// generatorH is the second generator, computed on first use.
+var generatorH = sync.OnceValue(func() *Element {
+	return hashToCurve(nil, []byte(generatorHDST))
+})
+
+// GeneratorH returns a second generator H of the group, independent of the base point G, i.e. whose discrete
+// logarithm with respect to G is unknown, as needed e.g. for Pedersen commitments. It is derived in a
+// nothing-up-my-sleeve fashion as HashToGroup(nil, "github.com/bytemare/secp256k1:GeneratorH:" + H2CSECP256K1),
+// and the returned element can be freely modified.
+func GeneratorH() *Element {
+	return generatorH().copy()
+}
+
+// generatorsDST is the prefix of the indexed domain separation tags used by DeriveGenerators.
+const generatorsDST = "github.com/bytemare/secp256k1:Generators:" + H2CSECP256K1
+
+// DeriveGenerators returns n generators of the group derived from the label, whose discrete logarithms with respect
+// to each other, to the base point G, and to GeneratorH are unknown, as needed e.g. for vector Pedersen commitments and
+// Bulletproofs. The i-th generator is HashToGroup(label, DST_i), where DST_i is
+// "github.com/bytemare/secp256k1:Generators:" + H2CSECP256K1 followed by the 4-byte big-endian encoding of i, so that
+// the first generators of a set don't depend on its size. Distinct labels yield independent sets.
+func DeriveGenerators(label []byte, n int) []*Element {
+	generators := make([]*Element, n)
+	dst := make([]byte, len(generatorsDST)+4)
+	copy(dst, generatorsDST)
+
+	for i := range generators {
+		binary.BigEndian.PutUint32(dst[len(generatorsDST):], uint32(i))
+		generators[i] = hashToCurve(label, dst)
+	}
+
+	return generators
+}
+
+// ScalarBaseMult returns scalar * G, where G is the group's base point, using a precomputed table. This is equivalent
+// to, and as fast as, Base().Multiply(scalar).
+func ScalarBaseMult(scalar *Scalar) *Element {
+	return Base().Multiply(scalar)
+}
+
+// DoubleScalarBaseMultVartime returns a * G + b * p, where G is the group's base point, as typically computed in
+// signature verification. It interleaves both multiplications to share their doublings, and is faster than computing
+// them separately. Its execution time depends on its inputs, so it must only be used on public values. Nil scalars
+// are treated as zero, and a nil point as the identity.
+func DoubleScalarBaseMultVartime(a, b *Scalar, p *Element) *Element {
+	if a == nil {
+		a = newScalar()
+	}
+
+	if b == nil || p == nil {
+		b, p = newScalar(), newElement()
+	}
+
+	return newElement().doubleScalarBaseMultVartime(a, b, p)
+}
+
+// IsOnCurve returns whether the big-endian encoded affine coordinates x and y, of 32 bytes each, are canonical (i.e.
+// lower than the field order) and satisfy the curve equation y^2 = x^3 + 7. It can be used to validate raw points
+// imported from foreign systems before building an Element from them.
+func IsOnCurve(x, y []byte) bool {
+	if len(x) != fieldLength || len(y) != fieldLength {
+		return false
+	}
+
+	px, py := new(big.Int).SetBytes(x), new(big.Int).SetBytes(y)
+	if px.Cmp(fp.Order()) >= 0 || py.Cmp(fp.Order()) >= 0 {
+		return false
+	}
+
+	return newElementWithAffine(px, py).isOnCurve()
+}
+
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToScalar(input, dst []byte) *Scalar {
 	return hashToScalar(input, dst)
 }
 
+// HashToScalars returns count independent safe mappings of the arbitrary input to Scalars, using a single
+// expand_message_xmd call. Due to the expansion's output limit, count must not exceed 170, otherwise HashToScalars
+// panics.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalars(input, dst []byte, count uint) []*Scalar {
+	return hashToScalars(input, dst, count)
+}
+
+// HashToScalarXOF returns a safe mapping of the arbitrary input to a Scalar, using expand_message_xof with the given
+// extendable-output function (e.g. hash.SHAKE128 or hash.SHAKE256) as specified in RFC 9380. It panics if xof is not
+// an extendable-output function.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalarXOF(xof hash.Hash, input, dst []byte) *Scalar {
+	return hashToScalarXOF(xof, input, dst)
+}
+
 // HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToGroup(input, dst []byte) *Element {
 	return hashToCurve(input, dst)
 }
 
+// HashToGroupBatch returns HashToGroup(input, dst) for each of the inputs, in the same order. It is faster than
+// separate calls, since the DST is processed and the hash state allocated only once.
+func HashToGroupBatch(inputs [][]byte, dst []byte) []*Element {
+	return hashToCurveBatch(inputs, dst)
+}
+
 // EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func EncodeToGroup(input, dst []byte) *Element {
 	return encodeToCurve(input, dst)
 }
 
+// HashToGroupXOF returns a safe mapping of the arbitrary input to an Element in the Group, using the
+// secp256k1_XOF:SHAKE256_SSWU_RO_ suite, i.e. expand_message_xof with SHAKE256 instead of expand_message_xmd with
+// SHA-256. The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToGroupXOF(input, dst []byte) *Element {
+	return hashToCurveXOF(input, dst)
+}
+
+// EncodeToGroupXOF returns a non-uniform mapping of the arbitrary input to an Element in the Group, using the
+// secp256k1_XOF:SHAKE256_SSWU_NU_ suite. The DST must not be empty or nil, and is recommended to be longer than 16
+// bytes.
+func EncodeToGroupXOF(input, dst []byte) *Element {
+	return encodeToCurveXOF(input, dst)
+}
+
+// ExpandMessageXOF implements expand_message_xof from RFC 9380 with SHAKE256, returning length uniform bytes derived
+// from the input and the DST. Use HashToScalarXOF with hash.SHAKE256 for the corresponding scalar mapping.
+func ExpandMessageXOF(input, dst []byte, length uint) []byte {
+	return hash2curve.ExpandXOF(hash.SHAKE256.GetXOF(), input, dst, length)
+}
+
+// TaggedHash returns the BIP-340 tagged hash SHA-256(SHA-256(tag) || SHA-256(tag) || data), where data is the
+// concatenation of the given slices. Tagging gives each use of the hash function in a protocol, e.g. the challenges
+// of Schnorr signatures, Taproot, and MuSig2, its own domain.
+func TaggedHash(tag string, data ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(t[:])
+	h.Write(t[:])
+
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
+
+// H2CSuiteXMD returns the hash-to-curve suite identifier for Secp256k1 with expand_message_xmd over id, e.g.
+// "secp256k1_XMD:SHA-512_SSWU_RO_" for crypto.SHA512.
+func H2CSuiteXMD(id crypto.Hash) string {
+	return "secp256k1_XMD:" + id.String() + "_SSWU_RO_"
+}
+
+// E2CSuiteXMD returns the encode-to-curve suite identifier for Secp256k1 with expand_message_xmd over id, e.g.
+// "secp256k1_XMD:SHA-512_SSWU_NU_" for crypto.SHA512.
+func E2CSuiteXMD(id crypto.Hash) string {
+	return "secp256k1_XMD:" + id.String() + "_SSWU_NU_"
+}
+
+// HashToGroupXMD is like HashToGroup, but uses expand_message_xmd over the given hash function instead of SHA-256,
+// for the H2CSuiteXMD(id) suite. The hash function must be linked into the binary (e.g. by importing crypto/sha512)
+// and have an output of at least 32 bytes, otherwise this function panics.
+func HashToGroupXMD(id crypto.Hash, input, dst []byte) *Element {
+	return hashToCurveXMD(checkXMD(id), input, dst)
+}
+
+// EncodeToGroupXMD is like EncodeToGroup, but uses expand_message_xmd over the given hash function instead of
+// SHA-256, for the E2CSuiteXMD(id) suite. The same requirements as for HashToGroupXMD apply.
+func EncodeToGroupXMD(id crypto.Hash, input, dst []byte) *Element {
+	return encodeToCurveXMD(checkXMD(id), input, dst)
+}
+
+// HashToScalarXMD is like HashToScalar, but uses expand_message_xmd over the given hash function instead of SHA-256.
+// The same requirements as for HashToGroupXMD apply.
+func HashToScalarXMD(id crypto.Hash, input, dst []byte) *Scalar {
+	return hashToScalarsXMD(checkXMD(id), input, dst, 1)[0]
+}
+
+// HashToFieldElements returns count base field elements derived from the input and DST with hash_to_field from RFC
+// 9380, using expand_message_xmd with SHA-256 as in the secp256k1_XMD:SHA-256_SSWU_RO_ suite, as big-endian encodings.
+// With count = 2 and MapToGroup, this yields the intermediate values of HashToGroup, and with count = 1 those of
+// EncodeToGroup. The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToFieldElements(input, dst []byte, count uint) [][fieldLength]byte {
+	u := hash2curve.HashToFieldXMD(hashID, input, dst, count, 1, secLength, fp.Order())
+	res := make([][fieldLength]byte, len(u))
+
+	for i, fe := range u {
+		fe.FillBytes(res[i][:])
+	}
+
+	return res
+}
+
+// MapToGroup maps the big-endian encoded base field element to an Element with the simplified SWU map to the
+// 3-isogenous curve followed by the isogeny, i.e. the map_to_curve step of the secp256k1 hash-to-curve suites, without
+// the message expansion. The input is reduced modulo the field order. The output is not uniformly distributed, so this
+// is only meant for protocols that compose the map themselves.
+func MapToGroup(fieldElement [fieldLength]byte) *Element {
+	u := fp.Mod(new(big.Int).SetBytes(fieldElement[:]))
+	return isogeny3iso(map2IsoCurve(u))
+}
+
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
 func Ciphersuite() string {
 	return H2CSECP256K1
 }
 
+// Backend returns the name of the field arithmetic backend selected at build time: "limbs64" on amd64 and arm64,
+// "limbs32" on 386, arm, mips, mipsle and wasm, and "generic" otherwise. Building with the purego tag forces the
+// portable "generic" backend, which only relies on math/big, on all platforms.
+func Backend() string {
+	return field.Backend
+}
+
 // ScalarLength returns the byte size of an encoded scalar.
 func ScalarLength() int {
 	return scalarLength
@@ -61,3 +274,26 @@ func ElementLength() int {
 func Order() []byte {
 	return slices.Clone(groupOrderBytes)
 }
+
+// FieldOrder returns the big-endian encoding of the order p = 2^256 - 2^32 - 977 of the base field, over which the
+// coordinates of the points are defined.
+func FieldOrder() []byte {
+	return slices.Clone(fieldOrderBytes)
+}
+
+// B returns the 32-byte big-endian encoding of the constant b = 7 of the curve equation y^2 = x^3 + a * x + b, where
+// a = 0.
+func B() []byte {
+	return b.FillBytes(make([]byte, fieldLength))
+}
+
+// BaseCoordinates returns the 32-byte big-endian encodings of the affine coordinates of the base point.
+func BaseCoordinates() (x, y []byte) {
+	return slices.Clone(baseXBytes), slices.Clone(baseYBytes)
+}
+
+// Cofactor returns the cofactor of the curve, i.e. the number of points divided by the group order, which is 1: every
+// point of the curve other than the identity generates the whole group.
+func Cofactor() int {
+	return 1
+}
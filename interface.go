@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+// PrimeOrderGroup is the small set of operations generic protocols, e.g. OPRF, OPAQUE, or FROST, need from a
+// prime-order group, with S and E its scalar and element types. Protocol code written against it, with S and E further
+// constrained to the methods it uses, can be instantiated with Group and any other implementation without shims.
+type PrimeOrderGroup[S, E any] interface {
+	// NewScalar returns a new scalar set to 0.
+	NewScalar() S
+
+	// NewElement returns a new element set to the identity.
+	NewElement() E
+
+	// Base returns the group's canonical generator.
+	Base() E
+
+	// HashToScalar returns a uniform mapping of the input to a scalar, with the domain separation tag dst.
+	HashToScalar(input, dst []byte) S
+
+	// HashToGroup returns a uniform mapping of the input to an element, with the domain separation tag dst.
+	HashToGroup(input, dst []byte) E
+
+	// EncodeToGroup returns a non-uniform mapping of the input to an element, with the domain separation tag dst.
+	EncodeToGroup(input, dst []byte) E
+
+	// Ciphersuite returns the hash-to-curve ciphersuite identifier of the group.
+	Ciphersuite() string
+
+	// ScalarLength returns the byte size of an encoded scalar.
+	ScalarLength() int
+
+	// ElementLength returns the byte size of an encoded element.
+	ElementLength() int
+
+	// Order returns the big-endian encoding of the group order.
+	Order() []byte
+}
+
+var _ PrimeOrderGroup[*Scalar, *Element] = Group{}
+
+// Group is the secp256k1 group, implementing PrimeOrderGroup[*Scalar, *Element] with the package's functions. Its zero
+// value is ready to use.
+type Group struct{}
+
+// NewScalar returns a new scalar set to 0.
+func (Group) NewScalar() *Scalar {
+	return NewScalar()
+}
+
+// NewElement returns a new element set to the identity.
+func (Group) NewElement() *Element {
+	return NewElement()
+}
+
+// Base returns the group's base point a.k.a. canonical generator.
+func (Group) Base() *Element {
+	return Base()
+}
+
+// HashToScalar returns HashToScalar(input, dst).
+func (Group) HashToScalar(input, dst []byte) *Scalar {
+	return HashToScalar(input, dst)
+}
+
+// HashToGroup returns HashToGroup(input, dst).
+func (Group) HashToGroup(input, dst []byte) *Element {
+	return HashToGroup(input, dst)
+}
+
+// EncodeToGroup returns EncodeToGroup(input, dst).
+func (Group) EncodeToGroup(input, dst []byte) *Element {
+	return EncodeToGroup(input, dst)
+}
+
+// Ciphersuite returns the hash-to-curve ciphersuite identifier, H2CSECP256K1.
+func (Group) Ciphersuite() string {
+	return Ciphersuite()
+}
+
+// ScalarLength returns the byte size of an encoded scalar.
+func (Group) ScalarLength() int {
+	return ScalarLength()
+}
+
+// ElementLength returns the byte size of an encoded element.
+func (Group) ElementLength() int {
+	return ElementLength()
+}
+
+// Order returns the big-endian encoding of the order of the group.
+func (Group) Order() []byte {
+	return Order()
+}
@@ -7,25 +7,45 @@
 // https://spdx.org/licenses/MIT.html
 
 // Package field provides modular operations over very high integers.
+//
+// The multiplications modulo the secp256k1 orders use an optimized backend, selected at build time by the files'
+// build constraints, and reported by Backend:
+//   - "limbs64" on amd64 and arm64, with 64-bit limbs,
+//   - "limbs32" on 386, arm, mips, mipsle and wasm, with 32-bit limbs,
+//   - "generic" otherwise, using math/big only.
+//
+// Building with the purego tag always selects the generic backend, e.g. for builds that must only use reviewed,
+// portable code. Any future assembly backend must also be excluded by the purego tag.
 package field
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
 )
 
 var (
 	zero = big.NewInt(0)
 	one  = big.NewInt(1)
+
+	// errRejectionSampling indicates that the random source keeps yielding values out of the field.
+	errRejectionSampling = errors.New("random source repeatedly yields values out of range")
 )
 
+// maxRejections bounds rejection sampling so that a broken random source can't make it loop forever. For the
+// secp256k1 orders, a single rejection happens with probability lower than 2^-127.
+const maxRejections = 64
+
 // Field represents a Galois Field.
 type Field struct {
 	order       *big.Int
 	pMinus1div2 *big.Int // used in IsSquare
 	pMinus2     *big.Int // used for Field big.Int inversion
 	exp         *big.Int
+	mul         func(res, x, y *big.Int) bool // optional optimized multiplication, see optimizedMul
 }
 
 // NewField returns a newly instantiated field for the given prime order.
@@ -49,6 +69,7 @@ func NewField(prime *big.Int) Field {
 		pMinus1div2: pMinus1div2,
 		pMinus2:     pMinus2,
 		exp:         exp,
+		mul:         optimizedMul(prime),
 	}
 }
 
@@ -64,17 +85,39 @@ func (f Field) One() *big.Int {
 
 // Random sets res to a random big.Int in the Field.
 func (f Field) Random(res *big.Int) *big.Int {
-	tmp, err := rand.Int(rand.Reader, f.order)
-	if err != nil {
+	if err := f.RandomFrom(res, rand.Reader); err != nil {
 		// We can as well not panic and try again in a loop
-		panic(fmt.Errorf("unexpected error in generating random bytes : %w", err))
+		panic(err)
 	}
 
-	res.Set(tmp)
-
 	return res
 }
 
+// RandomFrom sets res to a uniformly random big.Int in the Field, reading randomness from r. It uses rejection
+// sampling: candidates of the bit length of the order are drawn until one is strictly lower than the order, so the
+// result is free of modulo bias. res is left untouched on error.
+func (f Field) RandomFrom(res *big.Int, r io.Reader) error {
+	var tmp big.Int
+
+	buf := make([]byte, (f.BitLen()+7)/8)
+	mask := byte(0xff >> (len(buf)*8 - f.BitLen()))
+
+	for range maxRejections {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("unexpected error in generating random bytes : %w", err)
+		}
+
+		buf[0] &= mask
+
+		if tmp.SetBytes(buf).Cmp(f.order) < 0 {
+			res.Set(&tmp)
+			return nil
+		}
+	}
+
+	return errRejectionSampling
+}
+
 // Order returns the size of the Field.
 func (f Field) Order() *big.Int {
 	return f.order
@@ -95,6 +138,17 @@ func (f Field) IsZero(e *big.Int) bool {
 	return e.Sign() == 0
 }
 
+// CondIsZero returns 1 if x is equivalent to zero, and 0 otherwise. x is handled through a fixed-size buffer, so that
+// the result does not depend on a branch on x.
+func (f Field) CondIsZero(x *big.Int) int {
+	var bx, zero [64]byte
+
+	length := (f.BitLen() + 7) / 8
+	f.reduced(x).FillBytes(bx[:length])
+
+	return subtle.ConstantTimeCompare(bx[:length], zero[:length])
+}
+
 // Inv sets res to the modular inverse of x mod field order.
 func (f Field) Inv(res, x *big.Int) {
 	f.Exponent(res, x, f.pMinus2)
@@ -128,40 +182,82 @@ func (f Field) Mod(x *big.Int) *big.Int {
 
 // Neg sets res to the -x modulo the field order.
 func (f Field) Neg(res, x *big.Int) *big.Int {
-	return f.Mod(res.Neg(x))
+	return f.Sub(res, zero, x)
 }
 
-// CondNeg sets res to -x if cond == 1.
+// CondNeg sets res to -x if cond == 1, and to x if cond == 0, without branching on cond.
 func (f Field) CondNeg(res, x *big.Int, cond int) {
-	var neg, cpy big.Int
-	cpy.Set(x)
+	var neg big.Int
 	f.Neg(&neg, x)
+	f.CondSelect(res, &neg, x, cond)
+}
+
+// inRange returns whether x is in [0, order[.
+func (f Field) inRange(x *big.Int) bool {
+	return x.Sign() >= 0 && x.Cmp(f.order) < 0
+}
 
-	if cond == 1 {
-		res.Set(&neg)
-	} else {
-		res.Set(&cpy)
+// reduced returns x if it is in [0, order[, and a reduced copy of x otherwise.
+func (f Field) reduced(x *big.Int) *big.Int {
+	if f.inRange(x) {
+		return x
 	}
+
+	return f.Mod(new(big.Int).Set(x))
 }
 
-// Add sets res to x + y modulo the field order.
+// CondSelect sets res to x if cond == 1, and to y if cond == 0. x and y are reduced modulo the field order, and are
+// handled through fixed-size buffers with a constant-time copy, so that the selection does not branch on cond.
+func (f Field) CondSelect(res, x, y *big.Int, cond int) {
+	var bx, by [64]byte
+
+	length := (f.BitLen() + 7) / 8
+	f.reduced(x).FillBytes(bx[:length])
+	f.reduced(y).FillBytes(by[:length])
+	subtle.ConstantTimeCopy(cond, by[:length], bx[:length])
+	res.SetBytes(by[:length])
+}
+
+// Add sets res to x + y modulo the field order. If x and y are reduced, the sum is reduced with a subtraction of the
+// order instead of a division, which doesn't allocate once res has the capacity for the sum.
 func (f Field) Add(res, x, y *big.Int) {
-	f.Mod(res.Add(x, y))
+	if !f.inRange(x) || !f.inRange(y) {
+		f.Mod(res.Add(x, y))
+		return
+	}
+
+	res.Add(x, y)
+	if res.Cmp(f.order) >= 0 {
+		res.Sub(res, f.order)
+	}
 }
 
-// Sub sets res to x - y modulo the field order.
+// Sub sets res to x - y modulo the field order. As with Add, reduced operands don't require a division.
 func (f Field) Sub(res, x, y *big.Int) *big.Int {
-	return f.Mod(res.Sub(x, y))
+	if !f.inRange(x) || !f.inRange(y) {
+		return f.Mod(res.Sub(x, y))
+	}
+
+	res.Sub(x, y)
+	if res.Sign() < 0 {
+		res.Add(res, f.order)
+	}
+
+	return res
 }
 
 // Mul sets res to the multiplication of x and y modulo the field order.
 func (f Field) Mul(res, x, y *big.Int) {
+	if f.mul != nil && f.mul(res, x, y) {
+		return
+	}
+
 	f.Mod(res.Mul(x, y))
 }
 
 // Square sets res to the square of x modulo the field order.
 func (f Field) Square(res, x *big.Int) {
-	f.Mod(res.Mul(x, x))
+	f.Mul(res, x, x)
 }
 
 func (f Field) sqrt3mod4(res, e *big.Int) *big.Int {
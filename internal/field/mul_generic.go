@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !(amd64 || arm64 || 386 || arm || mips || mipsle || wasm) || purego
+
+package field
+
+import "math/big"
+
+// Backend is the name of the arithmetic backend selected for this build.
+const Backend = "generic"
+
+// optimizedMul always returns nil on this platform, so that the generic arithmetic is used.
+func optimizedMul(_ *big.Int) func(res, x, y *big.Int) bool {
+	return nil
+}
@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build (amd64 || arm64) && !purego
+
+package field
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Backend is the name of the arithmetic backend selected for this build.
+const Backend = "limbs64"
+
+// On these platforms, big.Word is 64 bits wide, and bits.Mul64 and bits.Add64 are compiler intrinsics, so that the
+// multiplications modulo the secp256k1 base field order p and group order n can use 4 64-bit limbs and the special
+// form of the orders, 2^256 - c with a small c, instead of a generic division.
+
+// reductionConstant is c = 2^256 - p = 2^32 + 977.
+const reductionConstant = 0x1000003d1
+
+// pLimbs are the little-endian 64-bit limbs of p.
+var pLimbs = [4]uint64{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+
+// limbs returns the little-endian 64-bit limbs of x and true, or false if x is negative or larger than 256 bits.
+func limbs(x *big.Int) ([4]uint64, bool) {
+	var l [4]uint64
+
+	w := x.Bits()
+	if x.Sign() < 0 || len(w) > len(l) {
+		return l, false
+	}
+
+	for i, v := range w {
+		l[i] = uint64(v)
+	}
+
+	return l, true
+}
+
+// mul512 returns the 512-bit product of a and b.
+func mul512(a, b *[4]uint64) [8]uint64 {
+	var t [8]uint64
+
+	for i := range 4 {
+		var carry uint64
+
+		for j := range 4 {
+			hi, lo := bits.Mul64(a[i], b[j])
+
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+
+		t[i+4] = carry
+	}
+
+	return t
+}
+
+// reduce512 returns t modulo p, using 2^256 = c mod p to fold the upper half twice.
+func reduce512(t *[8]uint64) [4]uint64 {
+	var r [4]uint64
+
+	var carry, c uint64
+
+	for i := range 4 {
+		hi, lo := bits.Mul64(t[4+i], reductionConstant)
+		lo, c = bits.Add64(lo, t[i], 0)
+		hi += c
+		lo, c = bits.Add64(lo, carry, 0)
+		hi += c
+		r[i] = lo
+		carry = hi
+	}
+
+	// carry < 2^34, so carry * c < 2^67.
+	hi, lo := bits.Mul64(carry, reductionConstant)
+	r[0], c = bits.Add64(r[0], lo, 0)
+	r[1], c = bits.Add64(r[1], hi, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], c = bits.Add64(r[3], 0, c)
+
+	// On overflow, r is below 2^67 and adding 2^256 = c mod p can't overflow again.
+	r[0], c = bits.Add64(r[0], c*reductionConstant, 0)
+	r[1], c = bits.Add64(r[1], 0, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], _ = bits.Add64(r[3], 0, c)
+
+	// r < 2^256 < 2p, so a single conditional subtraction of p is needed.
+	var s [4]uint64
+
+	var borrow uint64
+	for i := range 4 {
+		s[i], borrow = bits.Sub64(r[i], pLimbs[i], borrow)
+	}
+
+	mask := -borrow // all ones if r < p
+	for i := range 4 {
+		r[i] = r[i]&mask | s[i]&^mask
+	}
+
+	return r
+}
+
+// mulSecp256k1 sets res to x * y mod p, and returns true. It returns false without modifying res if x or y are not
+// in the expected range, in which case the generic arithmetic must be used.
+func mulSecp256k1(res, x, y *big.Int) bool {
+	a, ok := limbs(x)
+	if !ok {
+		return false
+	}
+
+	b, ok := limbs(y)
+	if !ok {
+		return false
+	}
+
+	t := mul512(&a, &b)
+	setLimbs(res, reduce512(&t))
+
+	return true
+}
+
+// orderReductionConstant holds the little-endian 64-bit limbs of c = 2^256 - n, n being the secp256k1 group order.
+var orderReductionConstant = [3]uint64{0x402da1732fc9bebf, 0x4551231950b75fc4, 1}
+
+// nLimbs are the little-endian 64-bit limbs of n.
+var nLimbs = [4]uint64{0xbfd25e8cd0364141, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0xffffffffffffffff}
+
+// foldOrder returns t[0:4] + t[4:8] * c, with c = 2^256 - n of 129 bits, which is equal to t mod n.
+func foldOrder(t *[8]uint64) [8]uint64 {
+	var r [8]uint64
+
+	copy(r[:4], t[:4])
+
+	for i := range 4 {
+		var carry, c uint64
+
+		for j, cj := range orderReductionConstant {
+			hi, lo := bits.Mul64(t[4+i], cj)
+			lo, c = bits.Add64(lo, r[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			r[i+j] = lo
+			carry = hi
+		}
+
+		for k := i + len(orderReductionConstant); k < len(r); k++ {
+			r[k], carry = bits.Add64(r[k], carry, 0)
+		}
+	}
+
+	return r
+}
+
+// reduceOrder returns t modulo n. Each fold shrinks the upper half, from 256 bits to 130, 4 and then at most 1 bit,
+// so that four folds always bring t below 2^256 < 2n, and a single conditional subtraction of n is needed.
+func reduceOrder(t *[8]uint64) [4]uint64 {
+	for range 4 {
+		*t = foldOrder(t)
+	}
+
+	var r, s [4]uint64
+
+	copy(r[:], t[:4])
+
+	var borrow uint64
+	for i := range 4 {
+		s[i], borrow = bits.Sub64(r[i], nLimbs[i], borrow)
+	}
+
+	mask := -borrow // all ones if r < n
+	for i := range 4 {
+		r[i] = r[i]&mask | s[i]&^mask
+	}
+
+	return r
+}
+
+// mulSecp256k1Order sets res to x * y mod n, and returns true. It returns false without modifying res if x or y are
+// not in the expected range, in which case the generic arithmetic must be used.
+func mulSecp256k1Order(res, x, y *big.Int) bool {
+	a, ok := limbs(x)
+	if !ok {
+		return false
+	}
+
+	b, ok := limbs(y)
+	if !ok {
+		return false
+	}
+
+	t := mul512(&a, &b)
+	setLimbs(res, reduceOrder(&t))
+
+	return true
+}
+
+// setLimbs sets res to the little-endian 64-bit limbs r, reusing the backing array of res if it is large enough.
+func setLimbs(res *big.Int, r [4]uint64) {
+	w := res.Bits()
+	if cap(w) < len(r) {
+		w = make([]big.Word, len(r))
+	}
+
+	w = w[:len(r)]
+	for i, v := range r {
+		w[i] = big.Word(v)
+	}
+
+	res.SetBits(w)
+}
+
+// optimizedMul returns the optimized multiplication for the given field order, or nil if there is none.
+func optimizedMul(order *big.Int) func(res, x, y *big.Int) bool {
+	switch {
+	case order.Cmp(secp256k1Order) == 0:
+		return mulSecp256k1
+	case order.Cmp(secp256k1GroupOrder) == 0:
+		return mulSecp256k1Order
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build (386 || arm || mips || mipsle || wasm) && !purego
+
+package field
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Backend is the name of the arithmetic backend selected for this build.
+const Backend = "limbs32"
+
+// On these platforms, 64x64-bit multiplications are emulated, so the multiplications modulo the secp256k1 orders use
+// 8 saturated 32-bit limbs, whose products are native 64-bit multiplications, and the special form of the orders,
+// 2^256 - c with a small c, instead of a generic division. On wasm, big.Word is 64 bits wide and is split in two limbs.
+
+// limbs32 is the number of 32-bit limbs of a 256-bit integer.
+const limbs32 = 8
+
+type (
+	// limbs256 are the little-endian 32-bit limbs of a 256-bit integer.
+	limbs256 [limbs32]uint32
+
+	// limbs512 are the little-endian 32-bit limbs of a 512-bit integer.
+	limbs512 [2 * limbs32]uint32
+)
+
+// reduction holds the constants of an order m = 2^256 - c.
+type reduction struct {
+	c []uint32 // the little-endian 32-bit limbs of c
+	m limbs256
+}
+
+var (
+	// baseReduction is for p = 2^256 - (2^32 + 977).
+	baseReduction = reduction{
+		c: []uint32{0x3d1, 1},
+		m: limbs256{
+			0xfffffc2f, 0xfffffffe, 0xffffffff, 0xffffffff, 0xffffffff, 0xffffffff, 0xffffffff, 0xffffffff,
+		},
+	}
+
+	// orderReduction is for n = 2^256 - 0x14551231950b75fc4402da1732fc9bebf.
+	orderReduction = reduction{
+		c: []uint32{0x2fc9bebf, 0x402da173, 0x50b75fc4, 0x45512319, 1},
+		m: limbs256{
+			0xd0364141, 0xbfd25e8c, 0xaf48a03b, 0xbaaedce6, 0xfffffffe, 0xffffffff, 0xffffffff, 0xffffffff,
+		},
+	}
+)
+
+// toLimbs32 returns the little-endian 32-bit limbs of x and true, or false if x is negative or larger than 256 bits.
+func toLimbs32(x *big.Int) (limbs256, bool) {
+	var l limbs256
+
+	if x.Sign() < 0 || x.BitLen() > 256 {
+		return l, false
+	}
+
+	for i, v := range x.Bits() {
+		if bits.UintSize == 64 {
+			l[2*i] = uint32(v)
+			l[2*i+1] = uint32(uint64(v) >> 32)
+		} else {
+			l[i] = uint32(v)
+		}
+	}
+
+	return l, true
+}
+
+// setLimbs32 sets res to the little-endian 32-bit limbs r, reusing the backing array of res if it is large enough.
+func setLimbs32(res *big.Int, r *limbs256) {
+	n := limbs32 * 32 / bits.UintSize
+
+	w := res.Bits()
+	if cap(w) < n {
+		w = make([]big.Word, n)
+	}
+
+	w = w[:n]
+	for i := range w {
+		if bits.UintSize == 64 {
+			w[i] = big.Word(uint64(r[2*i+1])<<32 | uint64(r[2*i]))
+		} else {
+			w[i] = big.Word(r[i])
+		}
+	}
+
+	res.SetBits(w)
+}
+
+// mul256 returns the 512-bit product of a and b.
+func mul256(a, b *limbs256) limbs512 {
+	var t limbs512
+
+	for i := range limbs32 {
+		var carry uint64
+
+		for j := range limbs32 {
+			v := uint64(a[i])*uint64(b[j]) + uint64(t[i+j]) + carry
+			t[i+j] = uint32(v)
+			carry = v >> 32
+		}
+
+		t[i+limbs32] = uint32(carry)
+	}
+
+	return t
+}
+
+// fold sets s to t[0:8] + t[8:8+hi] * c, which is equal to t modulo m if the other limbs of t are zero.
+func (r *reduction) fold(s, t *limbs512, hi int) {
+	*s = limbs512{}
+
+	for i := range hi {
+		var carry uint64
+
+		for j, cj := range r.c {
+			v := uint64(t[limbs32+i])*uint64(cj) + uint64(s[i+j]) + carry
+			s[i+j] = uint32(v)
+			carry = v >> 32
+		}
+
+		s[i+len(r.c)] = uint32(carry)
+	}
+
+	var carry uint64
+
+	for k := range max(limbs32, hi+len(r.c)) + 1 {
+		v := uint64(s[k]) + carry
+		if k < limbs32 {
+			v += uint64(t[k])
+		}
+
+		s[k] = uint32(v)
+		carry = v >> 32
+	}
+}
+
+// reduce returns t modulo m. For c of at most 129 bits, each fold shrinks the upper half, from 256 bits to at most
+// 130, 4 and then 1 bit, i.e. 5, 1 and 1 limbs, so that four folds always bring t below 2^256 < 2m, and a single
+// conditional subtraction of m is needed.
+func (r *reduction) reduce(t *limbs512) limbs256 {
+	var s limbs512
+
+	r.fold(&s, t, limbs32)
+	r.fold(t, &s, len(r.c))
+	r.fold(&s, t, 1)
+	r.fold(t, &s, 1)
+
+	var res, d limbs256
+
+	copy(res[:], t[:limbs32])
+
+	var borrow uint32
+	for i := range limbs32 {
+		d[i], borrow = bits.Sub32(res[i], r.m[i], borrow)
+	}
+
+	mask := -borrow // all ones if res < m
+	for i := range limbs32 {
+		res[i] = res[i]&mask | d[i]&^mask
+	}
+
+	return res
+}
+
+// mul sets res to x * y mod m, and returns true. It returns false without modifying res if x or y are not in the
+// expected range, in which case the generic arithmetic must be used.
+func (r *reduction) mul(res, x, y *big.Int) bool {
+	a, ok := toLimbs32(x)
+	if !ok {
+		return false
+	}
+
+	b, ok := toLimbs32(y)
+	if !ok {
+		return false
+	}
+
+	t := mul256(&a, &b)
+	l := r.reduce(&t)
+	setLimbs32(res, &l)
+
+	return true
+}
+
+// optimizedMul returns the optimized multiplication for the given field order, or nil if there is none.
+func optimizedMul(order *big.Int) func(res, x, y *big.Int) bool {
+	switch {
+	case order.Cmp(secp256k1Order) == 0:
+		return baseReduction.mul
+	case order.Cmp(secp256k1GroupOrder) == 0:
+		return orderReduction.mul
+	default:
+		return nil
+	}
+}
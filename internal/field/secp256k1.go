@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package field
+
+import "math/big"
+
+// The secp256k1 orders, for which the platform-specific backends may provide an optimized multiplication.
+var (
+	// secp256k1Order is the order of the secp256k1 base field, p = 2^256 - 2^32 - 977.
+	secp256k1Order = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(0x1000003d1))
+
+	// secp256k1GroupOrder is the order of the secp256k1 group, i.e. of its scalar field.
+	secp256k1GroupOrder, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+)
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package reference
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/field"
+)
+
+// ErrMismatch indicates a result of the secp256k1 package that differs from the reference.
+var ErrMismatch = errors.New("mismatch with the reference implementation")
+
+func mismatch(op string, got, expected []byte) error {
+	return fmt.Errorf("%w: %s: got %x, expected %x", ErrMismatch, op, got, expected)
+}
+
+func scalarInt(s *secp256k1.Scalar) *big.Int {
+	return new(big.Int).SetBytes(s.Encode())
+}
+
+func checkScalar(op string, got *secp256k1.Scalar, expected *big.Int) error {
+	if e := expected.FillBytes(make([]byte, 32)); !bytes.Equal(got.Encode(), e) {
+		return mismatch(op, got.Encode(), e)
+	}
+
+	return nil
+}
+
+// point returns the reference point of the element.
+func point(e *secp256k1.Element) (Point, error) {
+	p, ok := Decode(e.Encode())
+	if !ok || !p.IsOnCurve() {
+		return Point{}, fmt.Errorf("%w: invalid element encoding %x", ErrMismatch, e.Encode())
+	}
+
+	return p, nil
+}
+
+func checkPoint(op string, got *secp256k1.Element, expected Point) error {
+	if e := Encode(expected); !bytes.Equal(got.Encode(), e) {
+		return mismatch(op, got.Encode(), e)
+	}
+
+	return nil
+}
+
+// CrossCheckScalars returns an error wrapping ErrMismatch if the scalar operations of the secp256k1 package on a and b
+// differ from the reference: addition, subtraction, multiplication, inversion, and exponentiation with Pow and
+// PowUint64, the latter with the low 64 bits of b.
+func CrossCheckScalars(a, b *secp256k1.Scalar) error {
+	x, y := scalarInt(a), scalarInt(b)
+	low := new(big.Int).SetUint64(y.Uint64())
+
+	for _, c := range []struct {
+		op       string
+		got      *secp256k1.Scalar
+		expected *big.Int
+	}{
+		{"add", a.Copy().Add(b), ScalarAdd(x, y)},
+		{"subtract", a.Copy().Subtract(b), ScalarSub(x, y)},
+		{"multiply", a.Copy().Multiply(b), ScalarMul(x, y)},
+		{"invert", a.Copy().Invert(), ScalarInv(x)},
+		{"pow", a.Copy().Pow(b), ScalarPow(x, y)},
+		{"pow uint64", a.Copy().PowUint64(low.Uint64()), ScalarPow(x, low)},
+	} {
+		if err := checkScalar(c.op, c.got, c.expected); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CrossCheckElements returns an error wrapping ErrMismatch if the element operations of the secp256k1 package on p,
+// q, and k differ from the reference: addition, subtraction, doubling, negation, constant-time and variable-time
+// multiplication by k, base point multiplication, and double base multiplication k * G + k * q.
+func CrossCheckElements(p, q *secp256k1.Element, k *secp256k1.Scalar) error {
+	rp, err := point(p)
+	if err != nil {
+		return err
+	}
+
+	rq, err := point(q)
+	if err != nil {
+		return err
+	}
+
+	rk := scalarInt(k)
+
+	for _, c := range []struct {
+		op       string
+		got      *secp256k1.Element
+		expected Point
+	}{
+		{"add", p.Copy().Add(q), Add(rp, rq)},
+		{"subtract", p.Copy().Subtract(q), Add(rp, Neg(rq))},
+		{"double", p.Copy().Double(), Double(rp)},
+		{"negate", p.Copy().Negate(), Neg(rp)},
+		{"multiply", p.Copy().Multiply(k), ScalarMult(rk, rp)},
+		{"multiply vartime", p.Copy().MultiplyVartime(k), ScalarMult(rk, rp)},
+		{"base multiply", secp256k1.ScalarBaseMult(k), ScalarBaseMult(rk)},
+		{
+			"double base multiply",
+			secp256k1.DoubleScalarBaseMultVartime(k, k, q),
+			Add(ScalarBaseMult(rk), ScalarMult(rk, rq)),
+		},
+	} {
+		if err = checkPoint(c.op, c.got, c.expected); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CrossCheckField returns an error wrapping ErrMismatch if the base field operations of the field package on a and b
+// differ from the reference, computed with math/big modulo P: addition, subtraction, multiplication, squaring,
+// inversion, exponentiation with Pow, and square roots.
+func CrossCheckField(a, b *field.Element) error {
+	x, y := a.BigInt(), b.BigInt()
+	mod := func(r *big.Int) *big.Int { return r.Mod(r, P) }
+
+	var inv *big.Int
+	if x.Sign() == 0 {
+		inv = new(big.Int)
+	} else {
+		inv = new(big.Int).ModInverse(x, P)
+	}
+
+	for _, c := range []struct {
+		op       string
+		got      *field.Element
+		expected *big.Int
+	}{
+		{"add", field.NewElement().Add(a, b), mod(new(big.Int).Add(x, y))},
+		{"subtract", field.NewElement().Sub(a, b), mod(new(big.Int).Sub(x, y))},
+		{"multiply", field.NewElement().Mul(a, b), mod(new(big.Int).Mul(x, y))},
+		{"square", field.NewElement().Square(a), mod(new(big.Int).Mul(x, x))},
+		{"negate", field.NewElement().Negate(a), mod(new(big.Int).Neg(x))},
+		{"invert", field.NewElement().Invert(a), inv},
+		{"pow", field.NewElement().Pow(a, b.Bytes()), new(big.Int).Exp(x, y, P)},
+	} {
+		if got, e := c.got.Bytes(), c.expected.FillBytes(make([]byte, 32)); !bytes.Equal(got, e) {
+			return mismatch(c.op, got, e)
+		}
+	}
+
+	r, ok := field.NewElement().Sqrt(a)
+	if expected := new(big.Int).ModSqrt(x, P); (expected != nil) != ok ||
+		(ok && mod(new(big.Int).Mul(r.BigInt(), r.BigInt())).Cmp(x) != 0) {
+		return fmt.Errorf("%w: sqrt of %x", ErrMismatch, a.Bytes())
+	}
+
+	return nil
+}
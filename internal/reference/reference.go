@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package reference implements the scalar and point arithmetic of secp256k1 naively with math/big, using the affine
+// formulas of textbooks and crypto/elliptic, for differential testing of the optimized arithmetic of the secp256k1
+// package. It favours obviousness over speed and is not constant-time, so it must never be used outside of tests.
+package reference
+
+import (
+	"math/big"
+)
+
+var (
+	// P is the order of the base field.
+	P, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+	// N is the order of the group.
+	N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+	// G is the base point.
+	G = Point{
+		X: hexInt("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		Y: hexInt("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"),
+	}
+
+	seven = big.NewInt(7)
+)
+
+func hexInt(h string) *big.Int {
+	i, _ := new(big.Int).SetString(h, 16)
+	return i
+}
+
+// ScalarAdd returns a + b mod N.
+func ScalarAdd(a, b *big.Int) *big.Int {
+	r := new(big.Int).Add(a, b)
+	return r.Mod(r, N)
+}
+
+// ScalarSub returns a - b mod N.
+func ScalarSub(a, b *big.Int) *big.Int {
+	r := new(big.Int).Sub(a, b)
+	return r.Mod(r, N)
+}
+
+// ScalarMul returns a * b mod N.
+func ScalarMul(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+	return r.Mod(r, N)
+}
+
+// ScalarInv returns 1 / a mod N, and 0 for a = 0.
+func ScalarInv(a *big.Int) *big.Int {
+	if new(big.Int).Mod(a, N).Sign() == 0 {
+		return new(big.Int)
+	}
+
+	return new(big.Int).ModInverse(a, N)
+}
+
+// ScalarPow returns a^e mod N, with 0^0 = 1.
+func ScalarPow(a, e *big.Int) *big.Int {
+	return new(big.Int).Exp(a, e, N)
+}
+
+// Point is a point of the curve in affine coordinates. The identity is represented by nil coordinates.
+type Point struct {
+	X, Y *big.Int
+}
+
+// IsIdentity returns whether p is the identity.
+func (p Point) IsIdentity() bool {
+	return p.X == nil
+}
+
+// IsOnCurve returns whether p is the identity, or satisfies y^2 = x^3 + 7 mod P with coordinates in [0, P[.
+func (p Point) IsOnCurve() bool {
+	if p.IsIdentity() {
+		return true
+	}
+
+	if p.X.Sign() < 0 || p.X.Cmp(P) >= 0 || p.Y.Sign() < 0 || p.Y.Cmp(P) >= 0 {
+		return false
+	}
+
+	lhs := new(big.Int).Mul(p.Y, p.Y)
+	rhs := new(big.Int).Mul(p.X, p.X)
+	rhs.Mul(rhs, p.X).Add(rhs, seven)
+
+	return lhs.Mod(lhs, P).Cmp(rhs.Mod(rhs, P)) == 0
+}
+
+// Neg returns -p.
+func Neg(p Point) Point {
+	if p.IsIdentity() {
+		return p
+	}
+
+	y := new(big.Int).Sub(P, p.Y)
+
+	return Point{X: new(big.Int).Set(p.X), Y: y.Mod(y, P)}
+}
+
+// Add returns p + q, with the chord-and-tangent rule.
+func Add(p, q Point) Point {
+	switch {
+	case p.IsIdentity():
+		return q
+	case q.IsIdentity():
+		return p
+	case p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0:
+		return Double(p)
+	case p.X.Cmp(q.X) == 0:
+		return Point{} // q = -p
+	}
+
+	// l = (y2 - y1) / (x2 - x1), x3 = l^2 - x1 - x2, y3 = l(x1 - x3) - y1.
+	l := new(big.Int).Sub(q.Y, p.Y)
+	l.Mul(l, new(big.Int).ModInverse(new(big.Int).Mod(new(big.Int).Sub(q.X, p.X), P), P))
+
+	return line(l.Mod(l, P), p, q.X)
+}
+
+// Double returns 2p, with the tangent rule.
+func Double(p Point) Point {
+	if p.IsIdentity() || p.Y.Sign() == 0 {
+		return Point{}
+	}
+
+	// l = 3 * x^2 / (2 * y).
+	l := new(big.Int).Mul(p.X, p.X)
+	l.Mul(l, big.NewInt(3))
+	l.Mul(l, new(big.Int).ModInverse(new(big.Int).Lsh(p.Y, 1), P))
+
+	return line(l.Mod(l, P), p, p.X)
+}
+
+// line returns the third intersection of the line of slope l through p with the curve, negated, where x2 is the x
+// coordinate of the second intersection.
+func line(l *big.Int, p Point, x2 *big.Int) Point {
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, p.X).Sub(x, x2).Mod(x, P)
+
+	y := new(big.Int).Sub(p.X, x)
+	y.Mul(y, l).Sub(y, p.Y).Mod(y, P)
+
+	return Point{X: x, Y: y}
+}
+
+// ScalarMult returns k * p, with left-to-right double-and-add over the bits of k mod N.
+func ScalarMult(k *big.Int, p Point) Point {
+	k = new(big.Int).Mod(k, N)
+	r := Point{}
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = Double(r)
+
+		if k.Bit(i) == 1 {
+			r = Add(r, p)
+		}
+	}
+
+	return r
+}
+
+// ScalarBaseMult returns k * G.
+func ScalarBaseMult(k *big.Int) Point {
+	return ScalarMult(k, G)
+}
+
+// Encode returns the 33-byte compressed SEC 1 encoding of p, and 33 zero bytes for the identity.
+func Encode(p Point) []byte {
+	out := make([]byte, 33)
+	if p.IsIdentity() {
+		return out
+	}
+
+	out[0] = byte(2 | p.Y.Bit(0))
+	p.X.FillBytes(out[1:])
+
+	return out
+}
+
+// Decode returns the point of the 33-byte compressed SEC 1 encoding, or of the 33 zero bytes of the identity, and
+// false if the encoding is invalid.
+func Decode(in []byte) (Point, bool) {
+	if len(in) != 33 {
+		return Point{}, false
+	}
+
+	if new(big.Int).SetBytes(in).Sign() == 0 {
+		return Point{}, true
+	}
+
+	x := new(big.Int).SetBytes(in[1:])
+	if (in[0] != 2 && in[0] != 3) || x.Cmp(P) >= 0 {
+		return Point{}, false
+	}
+
+	y2 := new(big.Int).Mul(x, x)
+	y2.Mul(y2, x).Add(y2, seven).Mod(y2, P)
+
+	y := new(big.Int).ModSqrt(y2, P)
+	if y == nil {
+		return Point{}, false
+	}
+
+	if y.Bit(0) != uint(in[0]&1) {
+		y.Sub(P, y).Mod(y, P)
+	}
+
+	return Point{X: x, Y: y}, true
+}
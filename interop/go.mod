@@ -0,0 +1,17 @@
+module github.com/bytemare/secp256k1/interop
+
+go 1.22.2
+
+require (
+	github.com/bytemare/secp256k1 v0.0.0-00010101000000-000000000000
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.1
+)
+
+require (
+	github.com/bytemare/hash v0.3.0 // indirect
+	github.com/bytemare/hash2curve v0.3.0 // indirect
+	golang.org/x/crypto v0.27.0 // indirect
+	golang.org/x/sys v0.25.0 // indirect
+)
+
+replace github.com/bytemare/secp256k1 => ../
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package interop converts keys and scalars between this module and the dcrd secp256k1 package. Since the btcec/v2
+// PublicKey, PrivateKey, and ModNScalar types are aliases of the dcrd ones, these helpers work as-is with btcec.
+package interop
+
+import (
+	"fmt"
+
+	dcrd "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// ElementFromPublicKey returns the Element corresponding to the public key.
+func ElementFromPublicKey(pub *dcrd.PublicKey) (*secp256k1.Element, error) {
+	if pub == nil {
+		return nil, fmt.Errorf("%w: nil public key", secp256k1.ErrInvalidPointEncoding)
+	}
+
+	e := secp256k1.NewElement()
+	if err := e.Decode(pub.SerializeCompressed()); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// PublicKeyFromElement returns the public key corresponding to the Element, which must not be the identity.
+func PublicKeyFromElement(e *secp256k1.Element) (*dcrd.PublicKey, error) {
+	if e == nil || e.IsIdentity() {
+		return nil, secp256k1.ErrIdentity
+	}
+
+	pub, err := dcrd.ParsePubKey(e.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", secp256k1.ErrInvalidPointEncoding, err)
+	}
+
+	return pub, nil
+}
+
+// ScalarFromModNScalar returns the Scalar with the same value as s.
+func ScalarFromModNScalar(s *dcrd.ModNScalar) *secp256k1.Scalar {
+	b := s.Bytes()
+
+	// A ModNScalar is always reduced, so this can't fail.
+	res := secp256k1.NewScalar()
+	if err := res.Decode(b[:]); err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// ModNScalarFromScalar returns the ModNScalar with the same value as s.
+func ModNScalarFromScalar(s *secp256k1.Scalar) *dcrd.ModNScalar {
+	b := s.Bytes32()
+	res := new(dcrd.ModNScalar)
+	res.SetBytes(&b)
+
+	return res
+}
+
+// ScalarFromPrivateKey returns the secret Scalar of the private key, which must not be zero.
+func ScalarFromPrivateKey(priv *dcrd.PrivateKey) (*secp256k1.Scalar, error) {
+	if priv == nil || priv.Key.IsZero() {
+		return nil, secp256k1.ErrInvalidPrivateKey
+	}
+
+	return ScalarFromModNScalar(&priv.Key), nil
+}
+
+// PrivateKeyFromScalar returns the private key with the Scalar as its secret, which must not be zero.
+func PrivateKeyFromScalar(s *secp256k1.Scalar) (*dcrd.PrivateKey, error) {
+	if s == nil || s.IsZero() {
+		return nil, secp256k1.ErrInvalidPrivateKey
+	}
+
+	return dcrd.NewPrivateKey(ModNScalarFromScalar(s)), nil
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package interop_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	dcrd "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/interop"
+)
+
+const errExpectedEquality = "expected equality"
+
+func TestInterop_Keys(t *testing.T) {
+	scalar := secp256k1.NewScalar().Random()
+
+	priv, err := interop.PrivateKeyFromScalar(scalar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(priv.Serialize(), scalar.Encode()) {
+		t.Fatal("unexpected private key")
+	}
+
+	// The public keys computed on both sides must match.
+	pub, err := interop.ElementFromPublicKey(priv.PubKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pub.Equal(secp256k1.Base().Multiply(scalar)) != 1 {
+		t.Fatal("unexpected public key")
+	}
+
+	dcrdPub, err := interop.PublicKeyFromElement(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !dcrdPub.IsEqual(priv.PubKey()) {
+		t.Fatal("unexpected public key")
+	}
+
+	res, err := interop.ScalarFromPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(scalar) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Errors.
+	if _, err = interop.PrivateKeyFromScalar(secp256k1.NewScalar()); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+
+	if _, err = interop.ScalarFromPrivateKey(dcrd.NewPrivateKey(new(dcrd.ModNScalar))); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+
+	if _, err = interop.PublicKeyFromElement(secp256k1.NewElement()); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrIdentity, err)
+	}
+
+	if _, err = interop.ElementFromPublicKey(nil); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+	}
+}
+
+func TestInterop_Scalars(t *testing.T) {
+	for _, s := range []*secp256k1.Scalar{
+		secp256k1.NewScalar(),
+		secp256k1.NewScalar().One(),
+		secp256k1.NewScalar().MinusOne(),
+		secp256k1.NewScalar().Random(),
+	} {
+		m := interop.ModNScalarFromScalar(s)
+
+		if b := m.Bytes(); !bytes.Equal(b[:], s.Encode()) {
+			t.Fatal("unexpected ModNScalar")
+		}
+
+		if interop.ScalarFromModNScalar(m).Equal(s) != 1 {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+
+	// Arithmetic agrees on both sides.
+	a, b := secp256k1.NewScalar().Random(), secp256k1.NewScalar().Random()
+	product := new(dcrd.ModNScalar).Mul2(interop.ModNScalarFromScalar(a), interop.ModNScalarFromScalar(b))
+
+	if interop.ScalarFromModNScalar(product).Equal(a.Copy().Multiply(b)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
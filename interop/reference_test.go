@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package interop_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	dcrd "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	dcrdecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+// The tests below check outputs of the secp256k1 module against the dcrd implementation. They live in this module to
+// keep dcrd out of the dependencies of the core module.
+
+func newTestKey(t *testing.T) *secp256k1.PrivateKey {
+	t.Helper()
+
+	k, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return k
+}
+
+func TestECDSA_Interop(t *testing.T) {
+	for range 16 {
+		k := newTestKey(t)
+		digest := sha256.Sum256(k.Bytes())
+
+		sig, err := ecdsa.Sign(k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Both implementations use RFC 6979 and normalize to low-S.
+		ref := dcrdecdsa.Sign(dcrd.PrivKeyFromBytes(k.Bytes()), digest[:])
+		refR, refS := ref.R(), ref.S()
+
+		refRBytes, refSBytes := refR.Bytes(), refS.Bytes()
+
+		if !bytes.Equal(sig.R.Encode(), refRBytes[:]) ||
+			!bytes.Equal(sig.S.Encode(), refSBytes[:]) {
+			t.Fatal("signatures differ")
+		}
+
+		var r, s dcrd.ModNScalar
+		r.SetByteSlice(sig.R.Encode())
+		s.SetByteSlice(sig.S.Encode())
+
+		pub, err := dcrd.ParsePubKey(k.PublicKey().Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !dcrdecdsa.NewSignature(&r, &s).Verify(digest[:], pub) {
+			t.Fatal("signature rejected by decred")
+		}
+
+		if !ecdsa.Verify(k.PublicKey(), digest[:], &ecdsa.Signature{
+			R: secp256k1.NewScalar().SetBytesMod(refRBytes[:]),
+			S: secp256k1.NewScalar().SetBytesMod(refSBytes[:]),
+		}) {
+			t.Fatal("decred signature rejected")
+		}
+	}
+}
+
+func TestECDSA_DER_Interop(t *testing.T) {
+	for range 32 {
+		k := newTestKey(t)
+		digest := sha256.Sum256(k.Bytes())
+
+		sig, err := ecdsa.Sign(k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ref := dcrdecdsa.Sign(dcrd.PrivKeyFromBytes(k.Bytes()), digest[:]).Serialize()
+		if !bytes.Equal(sig.EncodeDER(), ref) {
+			t.Fatalf("encodings differ: %x %x", sig.EncodeDER(), ref)
+		}
+
+		dec := new(ecdsa.Signature)
+		if err = dec.DecodeDER(ref); err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa.Verify(k.PublicKey(), digest[:], dec) {
+			t.Fatal("expected valid signature")
+		}
+
+		if _, err = dcrdecdsa.ParseDERSignature(sig.EncodeDER()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestECDSA_RecoveryID_Interop(t *testing.T) {
+	for range 16 {
+		k := newTestKey(t)
+		digest := sha256.Sum256(k.Bytes())
+
+		sig, recoveryID, err := ecdsa.SignRecoverable(k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// decred's compact signatures encode the recovery ID as 27 + recoveryID.
+		compact := dcrdecdsa.SignCompact(dcrd.PrivKeyFromBytes(k.Bytes()), digest[:], false)
+		if compact[0]-27 != recoveryID || !bytes.Equal(compact[1:], sig.Encode()) {
+			t.Fatal("unexpected recovery ID")
+		}
+	}
+}
+
+func TestBitcoinMessage_Interop(t *testing.T) {
+	message := []byte("Hello, Bitcoin!")
+
+	for _, compressed := range []bool{true, false} {
+		k := newTestKey(t)
+		addressType := ecdsa.BitcoinP2PKH
+		if !compressed {
+			addressType = ecdsa.BitcoinP2PKHUncompressed
+		}
+
+		sig, err := ecdsa.SignBitcoinMessage(k, message, addressType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// decred's compact signatures use the same header encoding as Bitcoin Core for P2PKH.
+		expected := dcrdecdsa.SignCompact(dcrd.PrivKeyFromBytes(k.Bytes()), ecdsa.BitcoinMessageHash(message), compressed)
+
+		decoded, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(decoded, expected) {
+			t.Fatalf("unexpected signature, compressed: %v", compressed)
+		}
+
+		address := ecdsa.BitcoinP2PKHAddress(k.PublicKey().Element(), compressed, ecdsa.BitcoinMainnet)
+		if !ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, address, sig, message) {
+			t.Fatalf("expected valid signature, compressed: %v", compressed)
+		}
+	}
+}
+
+func TestECDH_Interop(t *testing.T) {
+	alice, bob := newTestKey(t), newTestKey(t)
+
+	secret, err := alice.ECDH(bob.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// libsecp256k1's default hashes the compressed shared point.
+	var point, shared dcrd.JacobianPoint
+	dcrd.PrivKeyFromBytes(bob.Bytes()).PubKey().AsJacobian(&point)
+	dcrd.ScalarMultNonConst(&dcrd.PrivKeyFromBytes(alice.Bytes()).Key, &point, &shared)
+	shared.ToAffine()
+
+	expected := sha256.Sum256(dcrd.NewPublicKey(&shared.X, &shared.Y).SerializeCompressed())
+	if !bytes.Equal(secret, expected[:]) {
+		t.Fatal("unexpected shared secret")
+	}
+
+	// The raw variant returns the x coordinate, as decred's GenerateSharedSecret.
+	raw, err := alice.ECDHRawX(bob.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dcrdRaw := dcrd.GenerateSharedSecret(dcrd.PrivKeyFromBytes(alice.Bytes()), dcrd.PrivKeyFromBytes(bob.Bytes()).PubKey())
+	if !bytes.Equal(raw, dcrdRaw) {
+		t.Fatal("unexpected raw shared secret")
+	}
+}
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ipa implements the Bulletproofs inner-product argument over secp256k1, on top of the secp256k1 package's
+// arithmetic, as a building block for range proofs and other zero-knowledge arguments.
+//
+// For generator vectors G and H of length n, a power of 2, and a point U, the prover convinces the verifier that it
+// knows vectors a and b such that P = <a, G> + <b, H> + <a, b> * U, with a proof of 2 * log2(n) points and 2 scalars.
+// The prover folds the vectors in half in each round, with challenges drawn from a Transcript, and the verifier checks
+// the folded statement with a single multi-scalar multiplication. The argument is not zero-knowledge by itself: it
+// reveals information about a and b, which protocols built on it must blind beforehand.
+//
+// The folding follows Bünz et al., "Bulletproofs: Short Proofs for Confidential Transactions and More" (2018),
+// Protocol 2, in the variant of the dalek-cryptography implementation. Generators should be derived with
+// secp256k1.DeriveGenerators, so that no discrete logarithm relation between them is known.
+package ipa
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/bytemare/secp256k1"
+)
+
+const (
+	scalarLength  = 32
+	elementLength = 33
+)
+
+var (
+	// ErrInvalidProof indicates a malformed proof encoding.
+	ErrInvalidProof = errors.New("invalid inner-product proof")
+
+	errVectorLength = errors.New("vector lengths must be equal powers of 2")
+)
+
+// Proof is an inner-product proof, i.e. the cross-term commitments L and R of each round, and the folded scalars.
+type Proof struct {
+	L, R secp256k1.ElementVector
+	A, B *secp256k1.Scalar
+}
+
+// isPowerOfTwo returns whether n is a non-zero power of 2.
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// domainSeparator absorbs the vector length into the transcript.
+func domainSeparator(t *Transcript, n int) {
+	t.AppendMessage("dom-sep", binary.BigEndian.AppendUint64([]byte("ipp v1"), uint64(n)))
+}
+
+// Commit returns the statement P = <a, G> + <b, H> + <a, b> * U for the vectors. It panics if the vectors don't all
+// have the same length.
+func Commit(g, h secp256k1.ElementVector, u *secp256k1.Element, a, b secp256k1.ScalarVector) *secp256k1.Element {
+	if len(g) != len(h) || len(g) != len(a) || len(a) != len(b) {
+		panic(errVectorLength)
+	}
+
+	p := g.MultiScalarMult(a).Add(h.MultiScalarMult(b))
+
+	return p.Add(u.Copy().Multiply(a.InnerProduct(b)))
+}
+
+// Prove returns a proof of knowledge of the vectors a and b such that P = Commit(g, h, u, a, b), with the challenges
+// drawn from the transcript. The scalar multiplications are constant time, since a and b are usually secret. The
+// inputs are not modified. It panics if the vectors don't all have the same length, a power of 2.
+func Prove(t *Transcript, g, h secp256k1.ElementVector, u *secp256k1.Element, a, b secp256k1.ScalarVector) *Proof {
+	n := len(g)
+	if !isPowerOfTwo(n) || len(h) != n || len(a) != n || len(b) != n {
+		panic(errVectorLength)
+	}
+
+	domainSeparator(t, n)
+
+	g, h, a, b = g.Copy(), h.Copy(), a.Copy(), b.Copy()
+	rounds := bits.Len(uint(n)) - 1
+	proof := &Proof{L: make(secp256k1.ElementVector, 0, rounds), R: make(secp256k1.ElementVector, 0, rounds)}
+
+	for n > 1 {
+		n /= 2
+		aLo, aHi, bLo, bHi := a[:n], a[n:], b[:n], b[n:]
+		gLo, gHi, hLo, hHi := g[:n], g[n:], h[:n], h[n:]
+
+		l := gHi.MultiScalarMult(aLo).Add(hLo.MultiScalarMult(bHi)).Add(u.Copy().Multiply(aLo.InnerProduct(bHi)))
+		r := gLo.MultiScalarMult(aHi).Add(hHi.MultiScalarMult(bLo)).Add(u.Copy().Multiply(aHi.InnerProduct(bLo)))
+
+		t.AppendElement("L", l)
+		t.AppendElement("R", r)
+		proof.L = append(proof.L, l)
+		proof.R = append(proof.R, r)
+
+		x := t.ChallengeScalar("u")
+		xInv := x.Copy().Invert()
+
+		// a' = x * a_lo + x^-1 * a_hi, b' = x^-1 * b_lo + x * b_hi, G' = x^-1 * G_lo + x * G_hi, and
+		// H' = x * H_lo + x^-1 * H_hi.
+		a = aLo.MultiplyScalar(x).Add(aHi.MultiplyScalar(xInv))
+		b = bLo.MultiplyScalar(xInv).Add(bHi.MultiplyScalar(x))
+		g = gLo.MultiplyScalar(xInv).Add(gHi.MultiplyScalar(x))
+		h = hLo.MultiplyScalar(x).Add(hHi.MultiplyScalar(xInv))
+	}
+
+	proof.A, proof.B = a[0], b[0]
+
+	return proof
+}
+
+// Verify returns whether the proof shows knowledge of vectors a and b such that P = Commit(g, h, u, a, b), with the
+// challenges drawn from the transcript, which must be in the same state as the prover's was. The check is done with a
+// single variable-time multi-scalar multiplication over all the generators and the proof's points.
+func Verify(t *Transcript, g, h secp256k1.ElementVector, u, p *secp256k1.Element, proof *Proof) bool {
+	n := len(g)
+	if !isPowerOfTwo(n) || len(h) != n || proof == nil || proof.A == nil || proof.B == nil ||
+		len(proof.L) != bits.Len(uint(n))-1 || len(proof.R) != len(proof.L) {
+		return false
+	}
+
+	domainSeparator(t, n)
+
+	rounds := len(proof.L)
+	x := make(secp256k1.ScalarVector, rounds)
+	xInv := make(secp256k1.ScalarVector, rounds)
+
+	for j := range rounds {
+		if proof.L[j] == nil || proof.R[j] == nil {
+			return false
+		}
+
+		t.AppendElement("L", proof.L[j])
+		t.AppendElement("R", proof.R[j])
+		x[j] = t.ChallengeScalar("u")
+		xInv[j] = x[j].Copy().Invert()
+	}
+
+	// s[i] is the coefficient of G[i] in the folded generator, i.e. the product over the rounds j of x_j if the bit of
+	// i for that round is set, and x_j^-1 otherwise. The first round splits on the most significant bit. The
+	// coefficient of H[i] is s[i]^-1 = s[n-1-i].
+	s := make(secp256k1.ScalarVector, n)
+	s[0] = secp256k1.NewScalar().One()
+
+	for _, xi := range xInv {
+		s[0].Multiply(xi)
+	}
+
+	for i := 1; i < n; i++ {
+		lg := bits.Len(uint(i)) - 1
+		xj := x[rounds-1-lg]
+		s[i] = s[i-1<<lg].Copy().Multiply(xj).Multiply(xj)
+	}
+
+	// a * s * G + b * s^-1 * H + a * b * U - P - sum(x_j^2 * L_j + x_j^-2 * R_j) must be the identity.
+	scalars := make(secp256k1.ScalarVector, 0, 2*n+2+2*rounds)
+	points := make(secp256k1.ElementVector, 0, cap(scalars))
+
+	for i := range n {
+		scalars = append(scalars, proof.A.Copy().Multiply(s[i]))
+		points = append(points, g[i])
+	}
+
+	for i := range n {
+		scalars = append(scalars, proof.B.Copy().Multiply(s[n-1-i]))
+		points = append(points, h[i])
+	}
+
+	scalars = append(scalars, proof.A.Copy().Multiply(proof.B), secp256k1.NewScalar().MinusOne())
+	points = append(points, u, p)
+
+	for j := range rounds {
+		scalars = append(scalars,
+			secp256k1.NewScalar().Subtract(x[j].Copy().Multiply(x[j])),
+			secp256k1.NewScalar().Subtract(xInv[j].Copy().Multiply(xInv[j])),
+		)
+		points = append(points, proof.L[j], proof.R[j])
+	}
+
+	return points.MultiScalarMultVartime(scalars).IsIdentity()
+}
+
+// Encode returns the encoding L_0 || R_0 || ... || L_{k-1} || R_{k-1} || a || b of the proof, of 66 * k + 64 bytes for
+// k rounds.
+func (p *Proof) Encode() []byte {
+	out := make([]byte, 0, 2*elementLength*len(p.L)+2*scalarLength)
+	for j := range p.L {
+		out = append(out, p.L[j].Encode()...)
+		out = append(out, p.R[j].Encode()...)
+	}
+
+	out = append(out, p.A.Encode()...)
+
+	return append(out, p.B.Encode()...)
+}
+
+// Decode sets the receiver to the decoding of the proof, whose number of rounds is deduced from its length, and returns
+// an error wrapping ErrInvalidProof on failure. The receiver is left untouched on error.
+func (p *Proof) Decode(data []byte) error {
+	points := len(data) - 2*scalarLength
+	if points < 0 || points%(2*elementLength) != 0 {
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidProof, len(data))
+	}
+
+	rounds := points / (2 * elementLength)
+	l, r := secp256k1.NewElementVector(rounds), secp256k1.NewElementVector(rounds)
+
+	for j := range rounds {
+		offset := 2 * elementLength * j
+		if err := l[j].Decode(data[offset : offset+elementLength]); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+		}
+
+		if err := r[j].Decode(data[offset+elementLength : offset+2*elementLength]); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+		}
+	}
+
+	a, b := secp256k1.NewScalar(), secp256k1.NewScalar()
+	if err := a.Decode(data[points : points+scalarLength]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	if err := b.Decode(data[points+scalarLength:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	p.L, p.R, p.A, p.B = l, r, a, b
+
+	return nil
+}
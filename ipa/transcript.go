@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/bytemare/secp256k1"
+)
+
+const dstChallenge = "github.com/bytemare/secp256k1/ipa:challenge"
+
+// Transcript is a Fiat-Shamir transcript, which derives the verifier's challenges from everything the prover sent
+// before them. Prover and verifier must append the same labelled messages in the same order, and should start with the
+// public statement, e.g. the commitment, so that challenges are bound to it. It is a SHA-256 hash chain over
+// length-prefixed labels and messages.
+type Transcript struct {
+	state [sha256.Size]byte
+}
+
+// NewTranscript returns a new transcript for the protocol identified by the label.
+func NewTranscript(label string) *Transcript {
+	t := &Transcript{}
+	t.AppendMessage("protocol", []byte(label))
+
+	return t
+}
+
+func appendLengthPrefixed(out, data []byte) []byte {
+	out = binary.BigEndian.AppendUint64(out, uint64(len(data)))
+	return append(out, data...)
+}
+
+// AppendMessage absorbs the labelled message into the transcript.
+func (t *Transcript) AppendMessage(label string, message []byte) {
+	input := append(t.state[:], 'm')
+	input = appendLengthPrefixed(input, []byte(label))
+	t.state = sha256.Sum256(appendLengthPrefixed(input, message))
+}
+
+// AppendElement absorbs the compressed encoding of the labelled element into the transcript.
+func (t *Transcript) AppendElement(label string, e *secp256k1.Element) {
+	t.AppendMessage(label, e.Encode())
+}
+
+// AppendScalar absorbs the encoding of the labelled scalar into the transcript.
+func (t *Transcript) AppendScalar(label string, s *secp256k1.Scalar) {
+	t.AppendMessage(label, s.Encode())
+}
+
+// ChallengeScalar returns the labelled challenge derived from the transcript, and absorbs it. It is uniformly
+// distributed and non-zero, so it can be inverted.
+func (t *Transcript) ChallengeScalar(label string) *secp256k1.Scalar {
+	for {
+		input := append(t.state[:], 'c')
+		input = appendLengthPrefixed(input, []byte(label))
+		c := secp256k1.HashToScalar(input, []byte(dstChallenge))
+		t.AppendScalar(label, c)
+
+		if !c.IsZero() {
+			return c
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// JWK parameters for secp256k1 keys, as registered by RFC 8812.
+const (
+	jwkKeyType = "EC"
+	jwkCurve   = "secp256k1"
+)
+
+// jwk holds the members of an RFC 7517 JSON Web Key for secp256k1 that are used by this package. Other members are
+// ignored when parsing.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	D   string `json:"d,omitempty"`
+}
+
+// newJWK returns the JWK for the public key pub.
+func newJWK(pub *PublicKey) jwk {
+	enc := pub.BytesUncompressed()
+
+	return jwk{
+		Kty: jwkKeyType,
+		Crv: jwkCurve,
+		X:   base64.RawURLEncoding.EncodeToString(enc[1 : 1+fieldLength]),
+		Y:   base64.RawURLEncoding.EncodeToString(enc[1+fieldLength:]),
+	}
+}
+
+// decodeJWKMember returns the 32-byte value of the base64url-encoded member.
+func decodeJWKMember(name, value string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: member %q: %w", ErrInvalidJWK, name, err)
+	}
+
+	if len(b) != fieldLength {
+		return nil, fmt.Errorf("%w: member %q must be %d bytes long", ErrInvalidJWK, name, fieldLength)
+	}
+
+	return b, nil
+}
+
+// parseJWK parses data as a JWK for secp256k1, and returns it with its public key.
+func parseJWK(data []byte) (*jwk, *PublicKey, error) {
+	var k jwk
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidJWK, err)
+	}
+
+	if k.Kty != jwkKeyType || k.Crv != jwkCurve {
+		return nil, nil, fmt.Errorf("%w: key type %q and curve %q", ErrUnsupportedCurve, k.Kty, k.Crv)
+	}
+
+	x, err := decodeJWKMember("x", k.X)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	y, err := decodeJWKMember("y", k.Y)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pub := &PublicKey{}
+	if err = pub.point.SetAffine(new(big.Int).SetBytes(x), new(big.Int).SetBytes(y)); err != nil {
+		return nil, nil, err
+	}
+
+	return &k, pub, nil
+}
+
+// MarshalJWK returns the RFC 7517 JSON Web Key of the public key, with the "EC" key type and the "secp256k1" curve
+// of RFC 8812, as used with the ES256K algorithm, e.g. {"kty":"EC","crv":"secp256k1","x":"...","y":"..."}.
+func (k *PublicKey) MarshalJWK() ([]byte, error) {
+	return json.Marshal(newJWK(k))
+}
+
+// ParsePublicKeyJWK parses a public key from a JSON Web Key for secp256k1, as returned by PublicKey.MarshalJWK. The
+// private "d" member and other members are ignored. It returns an error wrapping ErrInvalidJWK if data is malformed,
+// ErrUnsupportedCurve if the key is not an EC key on secp256k1, and ErrInvalidPointEncoding if the point is invalid.
+func ParsePublicKeyJWK(data []byte) (*PublicKey, error) {
+	_, pub, err := parseJWK(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return pub, nil
+}
+
+// MarshalJWK returns the RFC 7517 JSON Web Key of the private key, i.e. the one of its public key with the secret
+// scalar in the "d" member.
+func (k *PrivateKey) MarshalJWK() ([]byte, error) {
+	j := newJWK(&k.pub)
+	j.D = base64.RawURLEncoding.EncodeToString(k.Bytes())
+
+	return json.Marshal(j)
+}
+
+// ParsePrivateKeyJWK parses a private key from a JSON Web Key for secp256k1 with a "d" member, as returned by
+// PrivateKey.MarshalJWK. The public key in the "x" and "y" members must match the secret scalar. It returns the same
+// errors as ParsePublicKeyJWK, an error wrapping ErrInvalidPrivateKey if the secret scalar is missing or not in
+// [1, n-1], and one wrapping ErrInvalidJWK if the public key doesn't match.
+func ParsePrivateKeyJWK(data []byte) (*PrivateKey, error) {
+	j, pub, err := parseJWK(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.D == "" {
+		return nil, fmt.Errorf("%w: missing member \"d\"", ErrInvalidPrivateKey)
+	}
+
+	d, err := decodeJWKMember("d", j.D)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPrivateKey, err)
+	}
+
+	defer clear(d)
+
+	key, err := NewPrivateKey(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.pub.Equal(pub) {
+		return nil, fmt.Errorf("%w: public key does not match the private key", ErrInvalidJWK)
+	}
+
+	return key, nil
+}
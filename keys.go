@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// PrivateKey is a secp256k1 private key, i.e. a non-zero secret scalar, together with its public key.
+type PrivateKey struct {
+	pub PublicKey
+	d   Scalar
+}
+
+// PublicKey is a secp256k1 public key, i.e. a point of the curve other than the identity.
+type PublicKey struct {
+	point Element
+}
+
+// GenerateKey returns a new private key with a secret scalar drawn from rand, e.g. crypto/rand.Reader. It returns an
+// error if rand is nil or fails, as for Scalar.RandomFrom.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	s, err := newScalar().RandomFrom(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPrivateKey(s), nil
+}
+
+// newPrivateKey returns the private key for the non-zero scalar s.
+func newPrivateKey(s *Scalar) *PrivateKey {
+	k := &PrivateKey{}
+	k.d.Set(s)
+	k.pub.point.set(ScalarBaseMult(s))
+
+	return k
+}
+
+// NewPrivateKey returns the private key encoded in key, which must be the 32-byte big-endian encoding of a scalar in
+// [1, n-1], as returned by PrivateKey.Bytes. It returns an error wrapping ErrInvalidPrivateKey otherwise.
+func NewPrivateKey(key []byte) (*PrivateKey, error) {
+	s := newScalar()
+	if err := s.Decode(key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPrivateKey, err)
+	}
+
+	if s.IsZero() {
+		return nil, fmt.Errorf("%w: zero scalar", ErrInvalidPrivateKey)
+	}
+
+	return newPrivateKey(s), nil
+}
+
+// Scalar returns a copy of the secret scalar of the private key.
+func (k *PrivateKey) Scalar() *Scalar {
+	return k.d.Copy()
+}
+
+// PublicKey returns the public key of the private key.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{point: *k.pub.point.copy()}
+}
+
+// Public returns the public key of the private key as a *PublicKey, and implements the crypto.Signer and
+// crypto.Decrypter Public method signature.
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return k.PublicKey()
+}
+
+// Equal returns whether x is a *PrivateKey with the same secret scalar, in constant time.
+func (k *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	other, ok := x.(*PrivateKey)
+	if !ok {
+		return false
+	}
+
+	return k.d.Equal(&other.d) == 1
+}
+
+// Bytes returns the 32-byte big-endian encoding of the secret scalar.
+func (k *PrivateKey) Bytes() []byte {
+	return k.d.Encode()
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, and returns the same as Bytes.
+func (k *PrivateKey) MarshalBinary() ([]byte, error) {
+	return k.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, and accepts the same encoding as
+// NewPrivateKey. The receiver is not modified on error.
+func (k *PrivateKey) UnmarshalBinary(data []byte) error {
+	key, err := NewPrivateKey(data)
+	if err != nil {
+		return err
+	}
+
+	*k = *key
+
+	return nil
+}
+
+// NewPublicKey returns the public key encoded in key, which can be the 33-byte compressed encoding returned by
+// PublicKey.Bytes, or the 65-byte uncompressed encoding returned by PublicKey.BytesUncompressed. It returns an error
+// wrapping ErrInvalidPointEncoding if the encoding is invalid, or if it is not that of a point of the curve.
+func NewPublicKey(key []byte) (*PublicKey, error) {
+	k := &PublicKey{}
+
+	if len(key) == elementUncompressedLength && key[0] == 4 {
+		x := new(big.Int).SetBytes(key[1 : 1+fieldLength])
+		y := new(big.Int).SetBytes(key[1+fieldLength:])
+
+		if err := k.point.SetAffine(x, y); err != nil {
+			return nil, err
+		}
+
+		return k, nil
+	}
+
+	if err := k.point.Decode(key); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Element returns a copy of the point of the public key.
+func (k *PublicKey) Element() *Element {
+	return k.point.copy()
+}
+
+// Equal returns whether x is a *PublicKey with the same point.
+func (k *PublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+
+	be, bo := k.point.Key(), other.point.Key()
+
+	return subtle.ConstantTimeCompare(be[:], bo[:]) == 1
+}
+
+// Bytes returns the 33-byte compressed encoding of the public key.
+func (k *PublicKey) Bytes() []byte {
+	return k.point.Encode()
+}
+
+// BytesUncompressed returns the 65-byte uncompressed encoding of the public key, as in SEC 1.
+func (k *PublicKey) BytesUncompressed() []byte {
+	return k.point.EncodeUncompressed()
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, and returns the same as Bytes.
+func (k *PublicKey) MarshalBinary() ([]byte, error) {
+	return k.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, and accepts the same encodings as
+// NewPublicKey. The receiver is not modified on error.
+func (k *PublicKey) UnmarshalBinary(data []byte) error {
+	key, err := NewPublicKey(data)
+	if err != nil {
+		return err
+	}
+
+	*k = *key
+
+	return nil
+}
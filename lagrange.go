@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+// LagrangeCoefficient returns the Lagrange interpolation coefficient at 0 of the participant with identifier id, in
+// the given set of participant identifiers. id must be part of participants, and identifiers must be non-zero and
+// unique.
+func LagrangeCoefficient(id *Scalar, participants []*Scalar) (*Scalar, error) {
+	return LagrangeCoefficientAt(newScalar(), id, participants)
+}
+
+// LagrangeCoefficientAt returns the Lagrange interpolation coefficient at x of the participant with identifier id, in
+// the given set of participant identifiers, i.e. the product of (x - xj) / (id - xj) for all other xj. id must be
+// part of participants, and identifiers must be non-zero and unique.
+func LagrangeCoefficientAt(x, id *Scalar, participants []*Scalar) (*Scalar, error) {
+	if x == nil || id == nil {
+		return nil, ErrNilIdentifier
+	}
+
+	if err := checkParticipants(participants); err != nil {
+		return nil, err
+	}
+
+	numerator := newScalar().One()
+	denominator := newScalar().One()
+	found := false
+	diff := newScalar()
+
+	for _, xj := range participants {
+		if id.Equal(xj) == 1 {
+			found = true
+			continue
+		}
+
+		numerator.Multiply(diff.Set(x).Subtract(xj))
+		denominator.Multiply(diff.Set(id).Subtract(xj))
+	}
+
+	if !found {
+		return nil, ErrIdentifierNotInSet
+	}
+
+	return numerator.Multiply(denominator.Invert()), nil
+}
+
+// LagrangeCoefficients returns the Lagrange interpolation coefficients at 0 for all participants, in the same order as
+// the given identifiers. Identifiers must be non-zero and unique.
+func LagrangeCoefficients(participants []*Scalar) ([]*Scalar, error) {
+	coefficients := make([]*Scalar, len(participants))
+
+	for i, id := range participants {
+		c, err := LagrangeCoefficient(id, participants)
+		if err != nil {
+			return nil, err
+		}
+
+		coefficients[i] = c
+	}
+
+	return coefficients, nil
+}
+
+func checkParticipants(participants []*Scalar) error {
+	if len(participants) == 0 {
+		return ErrNoParticipants
+	}
+
+	for i, xi := range participants {
+		if xi == nil {
+			return ErrNilIdentifier
+		}
+
+		if xi.IsZero() {
+			return ErrZeroIdentifier
+		}
+
+		for _, xj := range participants[i+1:] {
+			if xi.Equal(xj) == 1 {
+				return ErrDuplicateIdentifier
+			}
+		}
+	}
+
+	return nil
+}
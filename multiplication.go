@@ -0,0 +1,368 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"sync"
+)
+
+// hexInt returns the big integer for the hexadecimal constant h, and is only used for the constants below.
+func hexInt(h string) *big.Int {
+	i, ok := new(big.Int).SetString(h, 16)
+	if !ok {
+		panic("invalid constant " + h)
+	}
+
+	return i
+}
+
+// Constants of the secp256k1 endomorphism φ(x, y) = (βx, y) = λ(x, y), and of the lattice basis {(a1, b1), (a2, b2)}
+// used to split a scalar k into k1 + k2λ with k1 and k2 of about 128 bits (GLV method).
+var (
+	glvBeta   = hexInt("7ae96a2b657c07106e64479eac3434e99cf0497512f58995c1396c28719501ee")
+	glvLambda = hexInt("5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72")
+	glvA1     = hexInt("3086d221a7d46bcde86c90e49284eb15")
+	glvMinB1  = hexInt("e4437ed6010e88286f547fa90abfe4c3")
+	glvA2     = hexInt("114ca50f7a8e2f3f657c1108d9d44cfd8")
+	glvB2     = glvA1
+
+	// glvG1 = round(2^384 * b2 / n) and glvG2 = round(2^384 * -b1 / n), so that the rounded divisions by n of the
+	// split are multiplications and shifts.
+	glvG1 = hexInt("3086d221a7d46bcde86c90e49284eb153daa8a1471e8ca7fe893209a45dbb031")
+	glvG2 = hexInt("e4437ed6010e88286f547fa90abfe4c4221208ac9df506c61571b4ae8ac47f71")
+)
+
+const (
+	// glvWindow is the window width of the fixed-window multiplication of the half-size scalars.
+	glvWindow = 4
+
+	// glvWindows is the number of windows covering the half-size scalars, whose absolute value is below 2^129.
+	glvWindows = 132 / glvWindow
+
+	// glvShift is the shift of the approximated divisions of glvSplit.
+	glvShift = 384
+)
+
+// glvRound is 2^(glvShift - 1), added before the shift to round to the nearest.
+var glvRound = new(big.Int).Lsh(big.NewInt(1), glvShift-1)
+
+// glvSplit returns k1 and k2 such that k = k1 + k2 * λ mod n and |k1|, |k2| < 2^129. The secret scalar k is never
+// divided, since big.Int division takes a variable time: the rounded quotients by n are approximated by a
+// multiplication with a precomputed constant and a shift, as in libsecp256k1, which only changes k1 and k2 by at most
+// one lattice vector.
+func glvSplit(k *big.Int) (k1, k2 *big.Int) {
+	var c1, c2, t big.Int
+
+	// c1 = round(b2 * k / n) = round(k * g1 / 2^384), c2 = round(-b1 * k / n) = round(k * g2 / 2^384).
+	c1.Mul(k, glvG1).Add(&c1, glvRound).Rsh(&c1, glvShift)
+	c2.Mul(k, glvG2).Add(&c2, glvRound).Rsh(&c2, glvShift)
+
+	// k1 = k - c1 * a1 - c2 * a2, k2 = -c1 * b1 - c2 * b2.
+	k1 = new(big.Int).Sub(k, t.Mul(&c1, glvA1))
+	k1.Sub(k1, t.Mul(&c2, glvA2))
+	k2 = new(big.Int).Mul(&c1, glvMinB1)
+	k2.Sub(k2, t.Mul(&c2, glvB2))
+
+	return k1, k2
+}
+
+// endomorphism sets e to φ(p) = (βx, y) = λ * p.
+func (e *Element) endomorphism(p *Element) *Element {
+	e.set(p)
+	fp.Mul(&e.x, &e.x, glvBeta)
+
+	return e
+}
+
+// condNegate sets e to -e if cond == 1.
+func (e *Element) condNegate(cond int) *Element {
+	fp.CondNeg(&e.y, &e.y, cond)
+	return e
+}
+
+// multiples returns the table [0, P, 2P, ..., (2^w - 1)P].
+func multiples(p *Element, w int) []*Element {
+	table := make([]*Element, 1<<w)
+	table[0] = newElement()
+
+	for i := 1; i < len(table); i++ {
+		table[i] = table[i-1].copy().add(p)
+	}
+
+	return table
+}
+
+// window returns the w-bit window of the non-negative k starting at bit offset.
+func window(k *big.Int, offset, w int) uint {
+	var d uint
+	for i := w - 1; i >= 0; i-- {
+		d = d<<1 | k.Bit(offset+i)
+	}
+
+	return d
+}
+
+// lookup sets e to table[digit] and returns it, reading all entries of the table with conditional moves so that
+// the memory access pattern does not depend on the secret digit.
+func (e *Element) lookup(table []*Element, digit uint) *Element {
+	e.Identity()
+
+	for j, t := range table {
+		e.CMove(subtle.ConstantTimeEq(int32(j), int32(digit)), t)
+	}
+
+	return e
+}
+
+// glvMultiply sets e to scalar * e by splitting the scalar with the endomorphism into two half-size scalars, and
+// jointly multiplying with fixed windows. The number and sequence of group operations and table accesses does not
+// depend on the scalar, and it halves the doublings compared to a full-size ladder.
+func (e *Element) glvMultiply(scalar *Scalar) *Element {
+	k1, k2 := glvSplit(&scalar.scalar)
+
+	// Absorb the signs of the half-size scalars into the points.
+	p1 := e.copy().condNegate(k1.Sign() >> 1 & 1)
+	p2 := newElement().endomorphism(e).condNegate(k2.Sign() >> 1 & 1)
+	k1.Abs(k1)
+	k2.Abs(k2)
+
+	t1 := multiples(p1, glvWindow)
+	t2 := multiples(p2, glvWindow)
+	r, q := newElement(), newElement()
+
+	for i := glvWindows - 1; i >= 0; i-- {
+		for range glvWindow {
+			r.Double()
+		}
+
+		r.add(q.lookup(t1, window(k1, i*glvWindow, glvWindow)))
+		r.add(q.lookup(t2, window(k2, i*glvWindow, glvWindow)))
+	}
+
+	return e.set(r)
+}
+
+// wnafWindow is the window width used for variable-time multiplication, with a table of 2^(w-2) = 8 odd multiples.
+const wnafWindow = 5
+
+const (
+	// baseWindow is the window width of the fixed-base table.
+	baseWindow = 4
+
+	// baseWindows is the number of windows needed to cover a scalar.
+	baseWindows = scalarLength * 8 / baseWindow
+)
+
+// fixedBaseTable holds, for each window i, the multiples j * 2^(4i) * P for j in [0, 16[ of a fixed point P.
+type fixedBaseTable [baseWindows][1 << baseWindow]*Element
+
+// newFixedBaseTable returns the fixed-base table for p, which costs about 1000 additions.
+func newFixedBaseTable(p *Element) *fixedBaseTable {
+	var table fixedBaseTable
+
+	g := p.copy()
+	for i := range table {
+		table[i][0] = newElement()
+		for j := 1; j < len(table[i]); j++ {
+			table[i][j] = table[i][j-1].copy().add(g)
+		}
+
+		g = table[i][len(table[i])-1].copy().add(g) // 2^(4(i+1)) * P
+	}
+
+	return &table
+}
+
+// multiply sets e to scalar * P with the table for P, which requires no doublings.
+func (t *fixedBaseTable) multiply(e *Element, scalar *Scalar) *Element {
+	enc := scalar.Encode()
+	r, q := newElement(), newElement()
+
+	for i := range baseWindows {
+		b := enc[scalarLength-1-i/2]
+		digit := (b >> (baseWindow * (i % 2))) & (1<<baseWindow - 1)
+		r.add(q.lookup(t[i][:], uint(digit)))
+	}
+
+	return e.set(r)
+}
+
+// baseTable holds the fixed-base table for the generator G. It is computed on first use.
+var baseTable = sync.OnceValue(func() *fixedBaseTable {
+	return newFixedBaseTable(newElement().Base())
+})
+
+// isBase returns whether e is the generator in the normalized representation set by Base(), which is cheap to check.
+func (e *Element) isBase() bool {
+	return e.z.Cmp(scOne) == 0 && e.x.Cmp(baseX) == 0 && e.y.Cmp(baseY) == 0
+}
+
+// baseMultiply sets e to scalar * G using the precomputed fixed-base table.
+func (e *Element) baseMultiply(scalar *Scalar) *Element {
+	return baseTable().multiply(e, scalar)
+}
+
+// PrecomputedElement holds a precomputed table of multiples of a fixed point, to speed up repeated multiplications
+// of that point, e.g. a public key used to verify many signatures. Building the table costs about four Multiply calls,
+// and each multiplication with it is then about twice as fast as Multiply. It is safe for concurrent use.
+type PrecomputedElement struct {
+	table *fixedBaseTable
+}
+
+// Precompute returns a precomputed table of multiples of the element.
+func (e *Element) Precompute() *PrecomputedElement {
+	return &PrecomputedElement{table: newFixedBaseTable(e)}
+}
+
+// Multiply returns a new element set to scalar * P, where P is the precomputed element. The sequence of group
+// operations does not depend on the scalar. A nil scalar is treated as zero.
+func (p *PrecomputedElement) Multiply(scalar *Scalar) *Element {
+	if scalar == nil {
+		return newElement()
+	}
+
+	return p.table.multiply(newElement(), scalar)
+}
+
+// oddMultiples returns the table [P, 3P, 5P, ..., (2^(w-1)-1)P] of odd multiples of p for a wNAF of width w.
+func oddMultiples(p *Element, w int) []*Element {
+	table := make([]*Element, 1<<(w-2))
+	table[0] = p.copy()
+	p2 := p.copy().Double()
+
+	for i := 1; i < len(table); i++ {
+		table[i] = table[i-1].copy().add(p2)
+	}
+
+	return table
+}
+
+// addNAFDigit adds d * P to e, where table holds the odd multiples of P and d is a wNAF digit.
+func (e *Element) addNAFDigit(table []*Element, d int8) {
+	switch {
+	case d > 0:
+		e.add(table[d/2])
+	case d < 0:
+		e.Subtract(table[-d/2])
+	}
+}
+
+// topDigit returns the index of the most significant non-zero digit of naf, or -1 if all digits are zero.
+func topDigit(naf []int8) int {
+	for i := len(naf) - 1; i >= 0; i-- {
+		if naf[i] != 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// baseWNAFWindow is the window width for the generator in variable-time multi-scalar multiplication, for which a
+// larger table of odd multiples is precomputed once.
+const baseWNAFWindow = 8
+
+// baseOddMultiples holds the odd multiples of the generator for a wNAF of width baseWNAFWindow.
+var baseOddMultiples = sync.OnceValue(func() []*Element {
+	return oddMultiples(newElement().Base(), baseWNAFWindow)
+})
+
+// doubleScalarBaseMultVartime sets e to a * G + b * p with interleaved wNAF recodings sharing the same doublings.
+func (e *Element) doubleScalarBaseMultVartime(a, b *Scalar, p *Element) *Element {
+	nafA := a.NAF(baseWNAFWindow)
+	nafB := b.NAF(wnafWindow)
+	tableA := baseOddMultiples()
+	tableB := oddMultiples(p, wnafWindow)
+	r := newElement()
+
+	for i := max(topDigit(nafA), topDigit(nafB)); i >= 0; i-- {
+		r.Double()
+		r.addNAFDigit(tableA, nafA[i])
+		r.addNAFDigit(tableB, nafB[i])
+	}
+
+	return e.set(r)
+}
+
+// MultiplyVartime sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns
+// it. It uses a width-5 wNAF recoding and is much faster than Multiply, but its execution time depends on the scalar
+// and the point, so it must only be used on public values, e.g. for signature verification.
+func (e *Element) MultiplyVartime(scalar *Scalar) *Element {
+	if scalar == nil {
+		return e.Identity()
+	}
+
+	if scalar.IsZero() || e.IsIdentity() {
+		return e.Identity()
+	}
+
+	table := oddMultiples(e, wnafWindow)
+	naf := scalar.NAF(wnafWindow)
+	r := newElement()
+
+	for i := topDigit(naf); i >= 0; i-- {
+		r.Double()
+		r.addNAFDigit(table, naf[i])
+	}
+
+	return e.set(r)
+}
+
+// MultiScalarMultVartime returns the sum of scalars[i] * points[i], as typically computed in batch verification. It
+// interleaves the wNAF recodings of all multiplications to share their doublings (Straus' method), and uses the
+// precomputed table for the generator when it is one of the points. Its execution time depends on its inputs, so it
+// must only be used on public values. Nil scalars are treated as zero, and nil points as the identity. It panics if
+// the slices have different lengths.
+func MultiScalarMultVartime(scalars []*Scalar, points []*Element) *Element {
+	if len(scalars) != len(points) {
+		panic(errVectorLength)
+	}
+
+	nafs := make([][]int8, 0, len(scalars))
+	tables := make([][]*Element, 0, len(scalars))
+	top := -1
+
+	for i, s := range scalars {
+		p := points[i]
+		if s == nil || p == nil {
+			continue
+		}
+
+		if s.IsZero() || p.IsIdentity() {
+			continue
+		}
+
+		var naf []int8
+
+		if p.isBase() {
+			naf = s.NAF(baseWNAFWindow)
+			tables = append(tables, baseOddMultiples())
+		} else {
+			naf = s.NAF(wnafWindow)
+			tables = append(tables, oddMultiples(p, wnafWindow))
+		}
+
+		nafs = append(nafs, naf)
+		top = max(top, topDigit(naf))
+	}
+
+	r := newElement()
+
+	for i := top; i >= 0; i-- {
+		r.Double()
+
+		for j, naf := range nafs {
+			r.addNAFDigit(tables[j], naf[i])
+		}
+	}
+
+	return r
+}
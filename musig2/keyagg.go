@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package musig2
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// KeySort returns a copy of the public keys sorted in the lexicographical order of their compressed encodings, as
+// specified by KeySort in BIP-327. Sorting the keys before aggregation makes the aggregate key independent of the
+// order in which the signers' keys were collected.
+func KeySort(keys []*secp256k1.PublicKey) []*secp256k1.PublicKey {
+	sorted := slices.Clone(keys)
+	slices.SortStableFunc(sorted, func(a, b *secp256k1.PublicKey) int {
+		return bytes.Compare(a.Bytes(), b.Bytes())
+	})
+
+	return sorted
+}
+
+// KeyAggContext holds the aggregate public key of a list of public keys, and the accumulated tweaks applied to it.
+// It is the keygen context of BIP-327.
+type KeyAggContext struct {
+	q    *secp256k1.Element
+	gacc *secp256k1.Scalar
+	tacc *secp256k1.Scalar
+
+	// keys holds the compressed encodings of the aggregated keys, in the order given to KeyAgg.
+	keys [][]byte
+
+	// list is the hash of the list of keys, and second the first key different from the first one, which gets a
+	// coefficient of 1.
+	list   []byte
+	second []byte
+}
+
+// KeyAgg returns the aggregation of the public keys in the given order, as specified in BIP-327. Keys may appear more
+// than once. It returns an error if the list is empty or, with negligible probability, if the aggregate is the
+// identity.
+func KeyAgg(keys []*secp256k1.PublicKey) (*KeyAggContext, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	ctx := &KeyAggContext{
+		q:      secp256k1.NewElement(),
+		gacc:   secp256k1.NewScalar().One(),
+		tacc:   secp256k1.NewScalar(),
+		keys:   make([][]byte, len(keys)),
+		second: make([]byte, secp256k1.ElementLength()),
+	}
+
+	for i, k := range keys {
+		if k == nil {
+			return nil, fmt.Errorf("%w: nil public key at index %d", secp256k1.ErrInvalidPointEncoding, i)
+		}
+
+		ctx.keys[i] = k.Bytes()
+	}
+
+	ctx.list = secp256k1.TaggedHash(tagKeyAggList, ctx.keys...)
+
+	for _, k := range ctx.keys[1:] {
+		if !bytes.Equal(k, ctx.keys[0]) {
+			ctx.second = k
+			break
+		}
+	}
+
+	for i, k := range keys {
+		ctx.q.Add(k.Element().Multiply(ctx.coefficient(ctx.keys[i])))
+	}
+
+	if ctx.q.IsIdentity() {
+		return nil, secp256k1.ErrIdentity
+	}
+
+	return ctx, nil
+}
+
+// coefficient returns the KeyAgg coefficient of the encoded public key.
+func (c *KeyAggContext) coefficient(key []byte) *secp256k1.Scalar {
+	if bytes.Equal(key, c.second) {
+		return secp256k1.NewScalar().One()
+	}
+
+	return hashToScalar(tagKeyAggCoeff, c.list, key)
+}
+
+// Coefficient returns the KeyAgg coefficient of the public key, or an error if it is not one of the aggregated keys.
+func (c *KeyAggContext) Coefficient(key *secp256k1.PublicKey) (*secp256k1.Scalar, error) {
+	if key == nil || !slices.ContainsFunc(c.keys, func(k []byte) bool { return bytes.Equal(k, key.Bytes()) }) {
+		return nil, ErrKeyNotInSession
+	}
+
+	return c.coefficient(key.Bytes()), nil
+}
+
+// ApplyTweak adds the 32-byte big-endian tweak t to the aggregate key Q, as specified in BIP-327: a plain tweak sets Q
+// to Q + t * G, e.g. for BIP-32 derivation, and an x-only tweak sets it to the point with even y and the same x as Q,
+// plus t * G, e.g. for BIP-341 Taproot. It returns an error wrapping ErrInvalidTweak if t is not lower than the group
+// order or if the result is the identity, in which case the receiver is not modified.
+func (c *KeyAggContext) ApplyTweak(tweak []byte, xOnly bool) error {
+	t := secp256k1.NewScalar()
+	if err := t.Decode(tweak); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTweak, err)
+	}
+
+	g := secp256k1.NewScalar().One()
+	if xOnly {
+		g = parity(c.q)
+	}
+
+	q := c.q.Copy().Multiply(g).Add(secp256k1.ScalarBaseMult(t))
+	if q.IsIdentity() {
+		return fmt.Errorf("%w: %w", ErrInvalidTweak, secp256k1.ErrIdentity)
+	}
+
+	c.q = q
+	c.gacc.Multiply(g)
+	c.tacc.Multiply(g).Add(t)
+
+	return nil
+}
+
+// copy returns a deep copy of the context.
+func (c *KeyAggContext) copy() *KeyAggContext {
+	cpy := *c
+	cpy.q = c.q.Copy()
+	cpy.gacc = c.gacc.Copy()
+	cpy.tacc = c.tacc.Copy()
+
+	return &cpy
+}
+
+// PublicKey returns the aggregate public key, including the tweaks applied so far.
+func (c *KeyAggContext) PublicKey() *secp256k1.PublicKey {
+	pub, _ := secp256k1.NewPublicKey(c.q.Encode()) // cannot fail, since Q is not the identity
+
+	return pub
+}
+
+// XOnlyPublicKey returns the 32-byte x-only encoding of the aggregate public key, for which the aggregate signatures
+// are valid BIP-340 signatures.
+func (c *KeyAggContext) XOnlyPublicKey() [32]byte {
+	return c.q.EncodeXOnly()
+}
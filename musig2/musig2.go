@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package musig2 implements the MuSig2 multi-signature protocol for BIP-340 Schnorr signatures as specified in
+// BIP-327, on top of the secp256k1 package's arithmetic.
+//
+// Signers aggregate their public keys with KeyAgg, optionally tweak the aggregate key, exchange public nonces generated
+// with NonceGen, and aggregate them with NonceAgg. Each signer then creates a Session for the message and produces a
+// partial signature with Session.Sign, which the other signers can check with Session.VerifyPartial, and any party can
+// combine the partial signatures into a BIP-340 signature for the x-only aggregate key with Session.Aggregate.
+package musig2
+
+import (
+	"errors"
+
+	"github.com/bytemare/secp256k1"
+)
+
+var (
+	// ErrNoKeys indicates an empty list of public keys.
+	ErrNoKeys = errors.New("empty list of public keys")
+
+	// ErrInvalidTweak indicates a tweak that is not lower than the group order, or which yields the identity.
+	ErrInvalidTweak = errors.New("invalid tweak")
+
+	// ErrInvalidNonce indicates an invalid public nonce or aggregate nonce encoding.
+	ErrInvalidNonce = errors.New("invalid nonce")
+
+	// ErrInvalidSecretNonce indicates a secret nonce that has already been used, or that belongs to another key.
+	ErrInvalidSecretNonce = errors.New("invalid secret nonce")
+
+	// ErrKeyNotInSession indicates a public key which is not part of the aggregated keys.
+	ErrKeyNotInSession = errors.New("public key is not part of the session")
+
+	// ErrInvalidPartialSignature indicates a partial signature that does not verify.
+	ErrInvalidPartialSignature = errors.New("invalid partial signature")
+)
+
+// Tags of the hash functions used by BIP-327.
+const (
+	tagKeyAggList  = "KeyAgg list"
+	tagKeyAggCoeff = "KeyAgg coefficient"
+	tagAux         = "MuSig/aux"
+	tagNonce       = "MuSig/nonce"
+	tagNonceCoeff  = "MuSig/noncecoef"
+	tagChallenge   = "BIP0340/challenge"
+)
+
+// hashToScalar returns the tagged hash of data reduced modulo the group order.
+func hashToScalar(tag string, data ...[]byte) *secp256k1.Scalar {
+	return secp256k1.NewScalar().SetBytesMod(secp256k1.TaggedHash(tag, data...))
+}
+
+// hasEvenY returns whether the non-identity element p has an even y coordinate.
+func hasEvenY(p *secp256k1.Element) bool {
+	return p.Encode()[0] == 2
+}
+
+// xBytes returns the x-only encoding of p.
+func xBytes(p *secp256k1.Element) []byte {
+	x := p.EncodeXOnly()
+	return x[:]
+}
+
+// parity returns 1 if p has an even y coordinate, and n - 1 otherwise.
+func parity(p *secp256k1.Element) *secp256k1.Scalar {
+	if hasEvenY(p) {
+		return secp256k1.NewScalar().One()
+	}
+
+	return secp256k1.NewScalar().MinusOne()
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package musig2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// NonceLength is the byte size of public and aggregate nonces, i.e. two compressed points.
+const NonceLength = 66
+
+// PublicNonce is a signer's public nonce, i.e. the encodings of the two nonce points.
+type PublicNonce [NonceLength]byte
+
+// AggregateNonce is the aggregation of the signers' public nonces, in which the identity is encoded as 33 zero bytes.
+type AggregateNonce [NonceLength]byte
+
+// SecretNonce holds a signer's two secret nonces and public key. It must only be used for a single signature: it is
+// erased by Session.Sign, and must never be serialized or copied, since signing two different messages with the same
+// nonce reveals the private key.
+type SecretNonce struct {
+	k1, k2 *secp256k1.Scalar
+	pub    []byte
+}
+
+// erase zeroes the secret nonces, so that a subsequent use fails.
+func (n *SecretNonce) erase() {
+	n.k1.Zero()
+	n.k2.Zero()
+}
+
+// NonceOptions holds the optional inputs of NonceGen. Providing them is not required for security, but strengthens
+// it if the random source is flawed.
+type NonceOptions struct {
+	// PrivateKey is the signer's private key.
+	PrivateKey *secp256k1.PrivateKey
+
+	// AggregateKey is the 32-byte x-only aggregate public key, as returned by KeyAggContext.XOnlyPublicKey.
+	AggregateKey []byte
+
+	// Message is the message to be signed. A nil Message means it is not known yet, which differs from an empty one.
+	Message []byte
+
+	// Extra is any additional input.
+	Extra []byte
+}
+
+// NonceGen returns a fresh secret nonce and the corresponding public nonce for the signer's public key, as specified
+// in BIP-327, with 32 random bytes read from rand, e.g. crypto/rand.Reader. opts may be nil. The secret nonce must be
+// kept secret and used for a single signature. It returns an error wrapping secp256k1.ErrNilRandomSource or
+// secp256k1.ErrRandomSource if rand is nil or fails, and one if the private key is not the one of pub.
+func NonceGen(pub *secp256k1.PublicKey, rand io.Reader, opts *NonceOptions) (*SecretNonce, PublicNonce, error) {
+	if pub == nil {
+		return nil, PublicNonce{}, fmt.Errorf("%w: nil public key", secp256k1.ErrInvalidPointEncoding)
+	}
+
+	if rand == nil {
+		return nil, PublicNonce{}, secp256k1.ErrNilRandomSource
+	}
+
+	if opts == nil {
+		opts = &NonceOptions{}
+	}
+
+	rnd := make([]byte, 32)
+	if _, err := io.ReadFull(rand, rnd); err != nil {
+		return nil, PublicNonce{}, fmt.Errorf("%w: %w", secp256k1.ErrRandomSource, err)
+	}
+
+	if opts.PrivateKey != nil {
+		if !opts.PrivateKey.PublicKey().Equal(pub) {
+			return nil, PublicNonce{}, secp256k1.ErrInvalidPrivateKey
+		}
+
+		aux := secp256k1.TaggedHash(tagAux, rnd)
+		for i, b := range opts.PrivateKey.Bytes() {
+			rnd[i] = b ^ aux[i]
+		}
+	}
+
+	pk := pub.Bytes()
+	suffix := lengthPrefixed(nil, 1, pk)
+	suffix = lengthPrefixed(suffix, 1, opts.AggregateKey)
+
+	if opts.Message == nil {
+		suffix = append(suffix, 0)
+	} else {
+		suffix = lengthPrefixed(append(suffix, 1), 8, opts.Message)
+	}
+
+	suffix = lengthPrefixed(suffix, 4, opts.Extra)
+
+	k1 := hashToScalar(tagNonce, rnd, suffix, []byte{0})
+	k2 := hashToScalar(tagNonce, rnd, suffix, []byte{1})
+
+	if k1.IsZero() || k2.IsZero() {
+		// This happens with negligible probability.
+		return nil, PublicNonce{}, fmt.Errorf("%w: zero nonce", ErrInvalidSecretNonce)
+	}
+
+	var pubNonce PublicNonce
+	copy(pubNonce[:], secp256k1.ScalarBaseMult(k1).Encode())
+	copy(pubNonce[NonceLength/2:], secp256k1.ScalarBaseMult(k2).Encode())
+
+	return &SecretNonce{k1: k1, k2: k2, pub: pk}, pubNonce, nil
+}
+
+// lengthPrefixed appends the big-endian size-byte length of data, followed by data, to dst.
+func lengthPrefixed(dst []byte, size int, data []byte) []byte {
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(data)))
+
+	return append(append(dst, l[8-size:]...), data...)
+}
+
+// decodeNonce returns the two points of the nonce, where the identity is only accepted if allowIdentity is set.
+func decodeNonce(nonce []byte, allowIdentity bool) (r1, r2 *secp256k1.Element, err error) {
+	decode := (*secp256k1.Element).Decode
+	if allowIdentity {
+		decode = (*secp256k1.Element).DecodeAllowIdentity
+	}
+
+	r1, r2 = secp256k1.NewElement(), secp256k1.NewElement()
+	if err = decode(r1, nonce[:NonceLength/2]); err != nil {
+		return nil, nil, err
+	}
+
+	if err = decode(r2, nonce[NonceLength/2:]); err != nil {
+		return nil, nil, err
+	}
+
+	return r1, r2, nil
+}
+
+// NonceAgg returns the aggregation of the signers' public nonces, as specified in BIP-327. It returns an error
+// wrapping ErrInvalidNonce and identifying the first invalid nonce if one of them is not a valid encoding.
+func NonceAgg(nonces []PublicNonce) (AggregateNonce, error) {
+	if len(nonces) == 0 {
+		return AggregateNonce{}, fmt.Errorf("%w: empty list of nonces", ErrInvalidNonce)
+	}
+
+	r1, r2 := secp256k1.NewElement(), secp256k1.NewElement()
+
+	for i, n := range nonces {
+		p1, p2, err := decodeNonce(n[:], false)
+		if err != nil {
+			return AggregateNonce{}, fmt.Errorf("%w: nonce of signer %d: %w", ErrInvalidNonce, i, err)
+		}
+
+		r1.Add(p1)
+		r2.Add(p2)
+	}
+
+	var agg AggregateNonce
+	copy(agg[:], r1.Encode())
+	copy(agg[NonceLength/2:], r2.Encode())
+
+	return agg, nil
+}
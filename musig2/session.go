@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package musig2
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// SignatureLength is the byte size of a BIP-340 signature.
+const SignatureLength = 64
+
+// Session holds the values shared by the signers to sign a message, derived from the key aggregation context, the
+// aggregate nonce, and the message. It is the session context of BIP-327.
+type Session struct {
+	keyAgg *KeyAggContext
+	b      *secp256k1.Scalar
+	e      *secp256k1.Scalar
+	r      *secp256k1.Element
+}
+
+// NewSession returns the signing session of the message with the aggregate nonce, for the aggregate key of keyAgg and
+// the tweaks applied to it so far, as specified by GetSessionValues in BIP-327. Tweaks applied to keyAgg afterward do
+// not affect the session. It returns an error wrapping ErrInvalidNonce if the aggregate nonce is not a valid encoding.
+func NewSession(keyAgg *KeyAggContext, aggNonce AggregateNonce, message []byte) (*Session, error) {
+	if keyAgg == nil {
+		return nil, ErrNoKeys
+	}
+
+	r1, r2, err := decodeNonce(aggNonce[:], true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNonce, err)
+	}
+
+	keyAgg = keyAgg.copy()
+	qx := xBytes(keyAgg.q)
+	b := hashToScalar(tagNonceCoeff, aggNonce[:], qx, message)
+
+	r := r1.Add(r2.Multiply(b))
+	if r.IsIdentity() {
+		// The signers could otherwise be forced to produce an invalid signature.
+		r.Base()
+	}
+
+	return &Session{
+		keyAgg: keyAgg,
+		b:      b,
+		e:      hashToScalar(tagChallenge, xBytes(r), qx, message),
+		r:      r,
+	}, nil
+}
+
+// Sign returns the signer's partial signature with the secret nonce and private key, as specified in BIP-327, and
+// erases the secret nonce so that it can't be used again. The partial signature is verified before it is returned.
+// It returns an error wrapping ErrInvalidSecretNonce if the nonce has already been used or was generated for another
+// key, ErrKeyNotInSession if the key is not one of the aggregated keys, or ErrInvalidPartialSignature if the partial
+// signature does not verify.
+func (s *Session) Sign(nonce *SecretNonce, key *secp256k1.PrivateKey) (*secp256k1.Scalar, error) {
+	if nonce == nil || key == nil {
+		return nil, fmt.Errorf("%w: nil secret nonce or private key", ErrInvalidSecretNonce)
+	}
+
+	defer nonce.erase()
+
+	if nonce.k1.IsZero() || nonce.k2.IsZero() {
+		return nil, fmt.Errorf("%w: nonce already used", ErrInvalidSecretNonce)
+	}
+
+	pub := key.PublicKey()
+	if !bytes.Equal(nonce.pub, pub.Bytes()) {
+		return nil, fmt.Errorf("%w: nonce generated for another key", ErrInvalidSecretNonce)
+	}
+
+	a, err := s.keyAgg.Coefficient(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	k1, k2 := nonce.k1.Copy(), nonce.k2.Copy()
+	if !hasEvenY(s.r) {
+		k1 = secp256k1.NewScalar().Subtract(k1)
+		k2 = secp256k1.NewScalar().Subtract(k2)
+	}
+
+	// d = g * gacc * d' and s = k1 + b * k2 + e * a * d.
+	d := key.Scalar()
+	d.Multiply(parity(s.keyAgg.q)).Multiply(s.keyAgg.gacc)
+	sig := k2.Multiply(s.b).Add(k1).Add(d.Multiply(a).Multiply(s.e))
+
+	var pubNonce PublicNonce
+	copy(pubNonce[:], secp256k1.ScalarBaseMult(nonce.k1).Encode())
+	copy(pubNonce[NonceLength/2:], secp256k1.ScalarBaseMult(nonce.k2).Encode())
+
+	if !s.VerifyPartial(sig, pubNonce, pub) {
+		return nil, ErrInvalidPartialSignature
+	}
+
+	return sig, nil
+}
+
+// VerifyPartial returns whether sig is a valid partial signature of the signer with the public nonce and key, as
+// specified by PartialSigVerifyInternal in BIP-327. This allows identifying a signer that produced an invalid partial
+// signature, which aggregation does not.
+func (s *Session) VerifyPartial(sig *secp256k1.Scalar, nonce PublicNonce, pub *secp256k1.PublicKey) bool {
+	if sig == nil {
+		return false
+	}
+
+	a, err := s.keyAgg.Coefficient(pub)
+	if err != nil {
+		return false
+	}
+
+	r1, r2, err := decodeNonce(nonce[:], false)
+	if err != nil {
+		return false
+	}
+
+	// Re = ±(R1 + b * R2), and s * G = Re + e * a * g * gacc * P.
+	re := r1.Add(r2.Multiply(s.b))
+	if !hasEvenY(s.r) {
+		re.Negate()
+	}
+
+	g := parity(s.keyAgg.q).Multiply(s.keyAgg.gacc)
+	p := pub.Element().Multiply(s.e.Copy().Multiply(a).Multiply(g))
+
+	return secp256k1.ScalarBaseMult(sig).Equal(re.Add(p)) == 1
+}
+
+// Aggregate returns the BIP-340 signature of the session's message for the x-only aggregate key, combining the
+// partial signatures of all signers, as specified by PartialSigAgg in BIP-327. The result is only valid if all partial
+// signatures are, which can be checked with VerifyPartial.
+func (s *Session) Aggregate(sigs []*secp256k1.Scalar) ([SignatureLength]byte, error) {
+	var out [SignatureLength]byte
+
+	sum := secp256k1.NewScalar()
+
+	for i, sig := range sigs {
+		if sig == nil {
+			return out, fmt.Errorf("%w: nil partial signature at index %d", ErrInvalidPartialSignature, i)
+		}
+
+		sum.Add(sig)
+	}
+
+	// s = sum + e * g * tacc.
+	sum.Add(s.e.Copy().Multiply(parity(s.keyAgg.q)).Multiply(s.keyAgg.tacc))
+
+	copy(out[:], xBytes(s.r))
+	copy(out[SignatureLength/2:], sum.Encode())
+
+	return out, nil
+}
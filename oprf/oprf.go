@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package oprf implements the 2HashDH oblivious pseudorandom function of RFC 9497 over secp256k1, in its base (OPRF)
+// and verifiable (VOPRF) modes, on top of the secp256k1 package's arithmetic.
+//
+// The client blinds its input with Blind and sends the blinded element to the server, which answers with
+// BlindEvaluate, and the client unblinds the evaluated element into the output with Finalize. The server learns
+// neither the input nor the output, and the client learns nothing about the server's key but the output. In the
+// verifiable mode, the server also sends a proof from ProveEvaluation that it used the key of its public key, which
+// the client checks with VerifyEvaluation before finalizing.
+//
+// RFC 9497 does not define a secp256k1 suite. This package follows the P256-SHA256 suite, substituting the curve and
+// the secp256k1_XMD:SHA-256_SSWU_RO_ hash-to-curve suite of RFC 9380, with the identifier "secp256k1-SHA256".
+package oprf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// Mode is an RFC 9497 protocol variant, which separates the domains of all hashes.
+type Mode byte
+
+const (
+	// OPRF is the base mode, where the client can't verify the server's evaluation.
+	OPRF Mode = 0x00
+
+	// VOPRF is the verifiable mode, where the server proves its evaluation under its public key.
+	VOPRF Mode = 0x01
+
+	// OutputLength is the byte size of the PRF output.
+	OutputLength = sha256.Size
+
+	// identifier is the suite identifier in the context string.
+	identifier = "secp256k1-SHA256"
+
+	maxDeriveKeyPairAttempts = 256
+)
+
+var (
+	// ErrInvalidInput indicates an input that maps to the identity element, or an invalid element.
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrDeriveKeyPair indicates that no key could be derived from the seed and info.
+	ErrDeriveKeyPair = errors.New("failed to derive a key pair")
+
+	// ErrInvalidProof indicates a malformed proof, or a proof that does not verify.
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// contextString returns the RFC 9497 context string of the mode.
+func (m Mode) contextString() string {
+	return "OPRFV1-" + string([]byte{byte(m)}) + "-" + identifier
+}
+
+func (m Mode) dst(prefix string) []byte {
+	return []byte(prefix + m.contextString())
+}
+
+// hashToGroup maps the input to the group, with the HashToGroup DST of the mode.
+func (m Mode) hashToGroup(input []byte) *secp256k1.Element {
+	return secp256k1.HashToGroup(input, m.dst("HashToGroup-"))
+}
+
+// hashToScalar maps the input to a scalar, with the HashToScalar DST of the mode.
+func (m Mode) hashToScalar(input []byte) *secp256k1.Scalar {
+	return secp256k1.HashToScalar(input, m.dst("HashToScalar-"))
+}
+
+// lengthPrefixed returns the concatenation of the data, each preceded by its 2-byte big-endian length.
+func lengthPrefixed(data ...[]byte) []byte {
+	var out []byte
+	for _, d := range data {
+		out = binary.BigEndian.AppendUint16(out, uint16(len(d)))
+		out = append(out, d...)
+	}
+
+	return out
+}
+
+// DeriveKeyPair deterministically derives the server's key from the 32-byte seed and the public info, as specified in
+// RFC 9497. It returns an error wrapping ErrDeriveKeyPair in the negligible event that all attempts yield zero.
+func (m Mode) DeriveKeyPair(seed, info []byte) (*secp256k1.PrivateKey, error) {
+	deriveInput := slices.Concat(seed, lengthPrefixed(info))
+	dst := m.dst("DeriveKeyPair")
+
+	for counter := range maxDeriveKeyPairAttempts {
+		sk := secp256k1.HashToScalar(slices.Concat(deriveInput, []byte{byte(counter)}), dst)
+		if !sk.IsZero() {
+			return secp256k1.NewPrivateKey(sk.Encode())
+		}
+	}
+
+	return nil, ErrDeriveKeyPair
+}
+
+// Blind returns a fresh blinding scalar read from rand, e.g. crypto/rand.Reader, and the blinded element of the input,
+// to send to the server. It returns an error if rand is nil or fails, as for Scalar.RandomFrom, and one wrapping
+// ErrInvalidInput if the input maps to the identity.
+func (m Mode) Blind(input []byte, rand io.Reader) (blind *secp256k1.Scalar, blinded *secp256k1.Element, err error) {
+	blind, err = secp256k1.NewScalar().RandomFrom(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blinded, err = m.BlindWith(input, blind)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blind, blinded, nil
+}
+
+// BlindWith is like Blind, with the given non-zero blinding scalar, e.g. to reproduce test vectors. The blind must be
+// secret, uniformly random, and never reused.
+func (m Mode) BlindWith(input []byte, blind *secp256k1.Scalar) (*secp256k1.Element, error) {
+	p := m.hashToGroup(input)
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("%w: input maps to the identity", ErrInvalidInput)
+	}
+
+	return p.Multiply(blind), nil
+}
+
+// BlindEvaluate returns the server's evaluation of the blinded element with its private key, in either mode. It
+// returns an error wrapping ErrInvalidInput if the blinded element is nil or the identity.
+func BlindEvaluate(key *secp256k1.PrivateKey, blinded *secp256k1.Element) (*secp256k1.Element, error) {
+	if blinded == nil || blinded.IsIdentity() {
+		return nil, fmt.Errorf("%w: invalid blinded element", ErrInvalidInput)
+	}
+
+	sk := key.Scalar()
+	defer sk.Zero()
+
+	return blinded.Copy().Multiply(sk), nil
+}
+
+// finalize returns the PRF output of the input for the unblinded element.
+func finalize(input []byte, unblinded *secp256k1.Element) []byte {
+	h := sha256.New()
+	h.Write(lengthPrefixed(input, unblinded.Encode()))
+	h.Write([]byte("Finalize"))
+
+	return h.Sum(nil)
+}
+
+// Finalize returns the 32-byte PRF output of the input from the server's evaluated element and the blind used for
+// the input. In the verifiable mode, the evaluation must first be checked with VerifyEvaluation. It returns an error
+// wrapping ErrInvalidInput if the evaluated element is nil or the identity.
+func Finalize(input []byte, blind *secp256k1.Scalar, evaluated *secp256k1.Element) ([]byte, error) {
+	if evaluated == nil || evaluated.IsIdentity() {
+		return nil, fmt.Errorf("%w: invalid evaluated element", ErrInvalidInput)
+	}
+
+	inverse := blind.Copy().Invert()
+	defer inverse.Zero()
+
+	return finalize(input, evaluated.Copy().Multiply(inverse)), nil
+}
+
+// Evaluate returns the PRF output of the input for the private key, computed directly by the server, which is the
+// same as the client's output of the blinded protocol.
+func (m Mode) Evaluate(key *secp256k1.PrivateKey, input []byte) ([]byte, error) {
+	p := m.hashToGroup(input)
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("%w: input maps to the identity", ErrInvalidInput)
+	}
+
+	sk := key.Scalar()
+	defer sk.Zero()
+
+	return finalize(input, p.Multiply(sk)), nil
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package oprf
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// ProofLength is the byte size of an encoded proof.
+const ProofLength = 64
+
+// Proof is a batched discrete logarithm equality proof that the evaluated elements are the blinded elements
+// multiplied by the private key of the server's public key.
+type Proof struct {
+	C, S *secp256k1.Scalar
+}
+
+// Encode returns the 64-byte encoding c || s of the proof.
+func (p *Proof) Encode() []byte {
+	return append(p.C.Encode(), p.S.Encode()...)
+}
+
+// Decode sets the receiver to the decoding of the 64-byte encoding, and returns an error wrapping ErrInvalidProof on
+// failure. The receiver is left untouched on error.
+func (p *Proof) Decode(data []byte) error {
+	if len(data) != ProofLength {
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidProof, len(data))
+	}
+
+	c, s := secp256k1.NewScalar(), secp256k1.NewScalar()
+	if err := c.Decode(data[:ProofLength/2]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	if err := s.Decode(data[ProofLength/2:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	p.C, p.S = c, s
+
+	return nil
+}
+
+// composites returns the weights of the batched proof for the public key and the element pairs, as in
+// ComputeComposites, i.e. M and Z are their linear combinations.
+func (m Mode) composites(pub *secp256k1.Element, blinded, evaluated []*secp256k1.Element) []*secp256k1.Scalar {
+	seed := sha256.Sum256(lengthPrefixed(pub.Encode(), m.dst("Seed-")))
+	weights := make([]*secp256k1.Scalar, len(blinded))
+
+	for i := range blinded {
+		transcript := lengthPrefixed(seed[:])
+		transcript = append(transcript, byte(i>>8), byte(i))
+		transcript = append(transcript, lengthPrefixed(blinded[i].Encode(), evaluated[i].Encode())...)
+		weights[i] = m.hashToScalar(append(transcript, "Composite"...))
+	}
+
+	return weights
+}
+
+// challenge returns the proof challenge of the public key, the composites, and the commitments.
+func (m Mode) challenge(pub, mm, z, t2, t3 *secp256k1.Element) *secp256k1.Scalar {
+	transcript := lengthPrefixed(pub.Encode(), mm.Encode(), z.Encode(), t2.Encode(), t3.Encode())
+	return m.hashToScalar(append(transcript, "Challenge"...))
+}
+
+// checkElements returns an error wrapping ErrInvalidInput if the lists have different or zero lengths, or hold nil
+// elements.
+func checkElements(blinded, evaluated []*secp256k1.Element) error {
+	if len(blinded) == 0 || len(blinded) != len(evaluated) {
+		return fmt.Errorf("%w: element lists of different or zero lengths", ErrInvalidInput)
+	}
+
+	for i := range blinded {
+		if blinded[i] == nil || evaluated[i] == nil {
+			return fmt.Errorf("%w: nil element", ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
+
+// ProveEvaluation returns a proof that the evaluated elements are the server's evaluations of the blinded elements,
+// which proves a whole batch at the cost of one. The proof's nonce is read from rand as for Blind.
+func (m Mode) ProveEvaluation(
+	key *secp256k1.PrivateKey,
+	blinded, evaluated []*secp256k1.Element,
+	rand io.Reader,
+) (*Proof, error) {
+	r, err := secp256k1.NewScalar().RandomFrom(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Zero()
+
+	return m.ProveEvaluationWith(key, blinded, evaluated, r)
+}
+
+// ProveEvaluationWith is like ProveEvaluation, with the given non-zero proof nonce r, e.g. to reproduce test vectors.
+// The nonce must be secret, uniformly random, and never reused, otherwise the private key leaks.
+func (m Mode) ProveEvaluationWith(
+	key *secp256k1.PrivateKey,
+	blinded, evaluated []*secp256k1.Element,
+	r *secp256k1.Scalar,
+) (*Proof, error) {
+	if err := checkElements(blinded, evaluated); err != nil {
+		return nil, err
+	}
+
+	sk := key.Scalar()
+	defer sk.Zero()
+
+	pub := key.PublicKey().Element()
+
+	// The server knows the key, so Z is k * M.
+	mm := secp256k1.MultiScalarMultVartime(m.composites(pub, blinded, evaluated), blinded)
+	z := mm.Copy().Multiply(sk)
+	c := m.challenge(pub, mm, z, secp256k1.ScalarBaseMult(r), mm.Copy().Multiply(r))
+	s := r.Copy().Subtract(c.Copy().Multiply(sk))
+
+	return &Proof{C: c, S: s}, nil
+}
+
+// VerifyEvaluation returns whether the proof shows that the evaluated elements are the evaluations of the blinded
+// elements with the private key of the public key.
+func (m Mode) VerifyEvaluation(pub *secp256k1.PublicKey, blinded, evaluated []*secp256k1.Element, proof *Proof) bool {
+	if pub == nil || proof == nil || proof.C == nil || proof.S == nil || checkElements(blinded, evaluated) != nil {
+		return false
+	}
+
+	b := pub.Element()
+	weights := m.composites(b, blinded, evaluated)
+	mm := secp256k1.MultiScalarMultVartime(weights, blinded)
+	z := secp256k1.MultiScalarMultVartime(weights, evaluated)
+	t2 := secp256k1.DoubleScalarBaseMultVartime(proof.S, proof.C, b)
+	t3 := secp256k1.MultiScalarMultVartime([]*secp256k1.Scalar{proof.S, proof.C}, []*secp256k1.Element{mm, z})
+
+	return m.challenge(b, mm, z, t2, t3).Equal(proof.C) == 1
+}
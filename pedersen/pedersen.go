@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package pedersen implements Pedersen commitments over secp256k1, on top of the secp256k1 package's arithmetic. A
+// commitment to the value v with the blinding factor r is C = v * G + r * H, where G is the base point and H the
+// second generator returned by secp256k1.GeneratorH, whose discrete logarithm with respect to G is unknown.
+//
+// Commitments are perfectly hiding, computationally binding, and additively homomorphic: the sum of two commitments
+// commits to the sum of their values with the sum of their blinding factors.
+package pedersen
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// CommitmentLength is the byte size of an encoded commitment.
+const CommitmentLength = 33
+
+// ErrInvalidCommitment indicates a malformed commitment encoding.
+var ErrInvalidCommitment = errors.New("invalid commitment")
+
+// Commitment is a Pedersen commitment.
+type Commitment struct {
+	point secp256k1.Element
+}
+
+// Commit returns the commitment v * G + r * H to the value with the blinding factor. Both must be kept secret until
+// the commitment is opened, and the blinding factor must be uniformly random for the commitment to be hiding.
+func Commit(value, blinding *secp256k1.Scalar) *Commitment {
+	c := &Commitment{}
+	c.point.Set(secp256k1.GeneratorH().Multiply(blinding).Add(secp256k1.ScalarBaseMult(value)))
+
+	return c
+}
+
+// CommitRandom returns the commitment to the value with a fresh blinding factor read from rand, and the blinding
+// factor. rand is e.g. crypto/rand.Reader, and an error is returned if it is nil or fails, as for Scalar.RandomFrom.
+func CommitRandom(value *secp256k1.Scalar, rand io.Reader) (*Commitment, *secp256k1.Scalar, error) {
+	r, err := secp256k1.NewScalar().RandomFrom(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return Commit(value, r), r, nil
+}
+
+// Verify returns whether the commitment opens to the value with the blinding factor.
+func (c *Commitment) Verify(value, blinding *secp256k1.Scalar) bool {
+	return c.Equal(Commit(value, blinding))
+}
+
+// Element returns a copy of the point of the commitment.
+func (c *Commitment) Element() *secp256k1.Element {
+	return c.point.Copy()
+}
+
+// Copy returns a copy of the commitment.
+func (c *Commitment) Copy() *Commitment {
+	d := &Commitment{}
+	d.point.Set(&c.point)
+
+	return d
+}
+
+// Equal returns whether the commitments are equal, in constant time.
+func (c *Commitment) Equal(other *Commitment) bool {
+	if other == nil {
+		return false
+	}
+
+	a, b := c.point.Key(), other.point.Key()
+
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// Add sets the receiver to the sum of the receiver and the other commitment, which commits to the sum of their values
+// with the sum of their blinding factors, and returns it.
+func (c *Commitment) Add(other *Commitment) *Commitment {
+	c.point.Add(&other.point)
+	return c
+}
+
+// Subtract sets the receiver to the difference of the receiver and the other commitment, which commits to the
+// difference of their values with the difference of their blinding factors, and returns it.
+func (c *Commitment) Subtract(other *Commitment) *Commitment {
+	c.point.Subtract(&other.point)
+	return c
+}
+
+// Encode returns the 33-byte compressed encoding of the commitment. The identity, i.e. the commitment to zero with a
+// zero blinding factor, is encoded as 33 zero bytes.
+func (c *Commitment) Encode() []byte {
+	return c.point.Encode()
+}
+
+// Decode sets the receiver to the decoding of the 33-byte encoding, and returns an error wrapping
+// ErrInvalidCommitment on failure. The receiver is left untouched on error.
+func (c *Commitment) Decode(data []byte) error {
+	p := secp256k1.NewElement()
+	if err := p.DecodeAllowIdentity(data); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCommitment, err)
+	}
+
+	c.point.Set(p)
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package pedersen
+
+import (
+	"errors"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// errVectorLength indicates a vector of values longer than the set of generators.
+var errVectorLength = errors.New("more values than generators")
+
+// Generators is a set of independent generators for vector commitments.
+type Generators []*secp256k1.Element
+
+// NewGenerators returns n generators derived from the label with secp256k1.DeriveGenerators. Sets derived from the same
+// label share their prefixes, so a set can be derived once for the largest vectors and used for shorter ones.
+func NewGenerators(label []byte, n int) Generators {
+	return secp256k1.DeriveGenerators(label, n)
+}
+
+// CommitVector returns the commitment sum(values[i] * generators[i]) + r * H to the values with the blinding factor,
+// where H is secp256k1.GeneratorH. It panics if there are more values than generators. With a single value and the base
+// point as generator, this is Commit.
+func CommitVector(generators Generators, values secp256k1.ScalarVector, blinding *secp256k1.Scalar) *Commitment {
+	if len(values) > len(generators) {
+		panic(errVectorLength)
+	}
+
+	p := secp256k1.GeneratorH().Multiply(blinding)
+	for i, v := range values {
+		p.Add(generators[i].Copy().Multiply(v))
+	}
+
+	c := &Commitment{}
+	c.point.Set(p)
+
+	return c
+}
+
+// VerifyVector returns whether the commitment opens to the values with the blinding factor for the generators. It
+// panics if there are more values than generators.
+func (c *Commitment) VerifyVector(
+	generators Generators,
+	values secp256k1.ScalarVector,
+	blinding *secp256k1.Scalar,
+) bool {
+	return c.Equal(CommitVector(generators, values, blinding))
+}
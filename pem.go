@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"encoding/pem"
+	"fmt"
+)
+
+// PEM block types.
+const (
+	pemTypeECPrivateKey = "EC PRIVATE KEY"
+	pemTypePrivateKey   = "PRIVATE KEY"
+	pemTypePublicKey    = "PUBLIC KEY"
+	pemTypeECParameters = "EC PARAMETERS"
+)
+
+// decodePEM returns the first PEM block in data that is not an "EC PARAMETERS" block, as written before the key by
+// "openssl ecparam -genkey".
+func decodePEM(data []byte) (*pem.Block, error) {
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidPEM)
+		}
+
+		if block.Type != pemTypeECParameters {
+			return block, nil
+		}
+
+		data = rest
+	}
+}
+
+// EncodePrivateKeyPEM returns the PEM encoding of the private key in an "EC PRIVATE KEY" block, holding the DER
+// encoding returned by MarshalECPrivateKey, as generated by OpenSSL.
+func EncodePrivateKeyPEM(key *PrivateKey) ([]byte, error) {
+	der, err := MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeECPrivateKey, Bytes: der}), nil
+}
+
+// DecodePrivateKeyPEM parses a private key from the first PEM block of data, which can be an "EC PRIVATE KEY" block
+// or a PKCS #8 "PRIVATE KEY" block, as generated by OpenSSL. A preceding "EC PARAMETERS" block is skipped, and
+// encrypted blocks are not supported. It returns an error wrapping ErrInvalidPEM if no such block is found, and the
+// errors of ParseECPrivateKey and ParsePKCS8PrivateKey otherwise.
+func DecodePrivateKeyPEM(data []byte) (*PrivateKey, error) {
+	block, err := decodePEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case pemTypeECPrivateKey:
+		return ParseECPrivateKey(block.Bytes)
+	case pemTypePrivateKey:
+		return ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: unexpected block type %q", ErrInvalidPEM, block.Type)
+	}
+}
+
+// EncodePublicKeyPEM returns the PEM encoding of the public key in a "PUBLIC KEY" block, holding the DER encoding
+// returned by MarshalPKIXPublicKey.
+func EncodePublicKeyPEM(pub *PublicKey) ([]byte, error) {
+	der, err := MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}), nil
+}
+
+// DecodePublicKeyPEM parses a public key from the first PEM block of data, which must be a "PUBLIC KEY" block. It
+// returns an error wrapping ErrInvalidPEM if no such block is found, and the errors of ParsePKIXPublicKey otherwise.
+func DecodePublicKeyPEM(data []byte) (*PublicKey, error) {
+	block, err := decodePEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type != pemTypePublicKey {
+		return nil, fmt.Errorf("%w: unexpected block type %q", ErrInvalidPEM, block.Type)
+	}
+
+	return ParsePKIXPublicKey(block.Bytes)
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto/hmac"
+	"math/big"
+)
+
+// DeriveScalarRFC6979 returns a deterministic non-zero nonce scalar as specified in RFC 6979 (section 3.2), using
+// HMAC-DRBG instantiated with SHA-256. key is the 32-byte big-endian encoding of the non-zero private key, and message
+// is the hashed message to be signed (its leftmost 256 bits are used, as per bits2octets). extra is optional
+// additional data mixed into the generator as described in section 3.6, e.g. to hedge against fault attacks with
+// fresh randomness. An error is returned if key is not a valid non-zero scalar encoding.
+func DeriveScalarRFC6979(key, message, extra []byte) (*Scalar, error) {
+	x := newScalar()
+	if err := x.Decode(key); err != nil || x.IsZero() {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return deriveRFC6979(x, message, extra), nil
+}
+
+// bits2int implements the bits2int transform of RFC 6979 (section 2.3.2) for qlen = 256.
+func bits2int(in []byte) *big.Int {
+	i := new(big.Int).SetBytes(in)
+	if excess := len(in)*8 - fn.BitLen(); excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+
+	return i
+}
+
+// bits2octets implements the bits2octets transform of RFC 6979 (section 2.3.4).
+func bits2octets(in []byte) []byte {
+	return fn.Mod(bits2int(in)).FillBytes(make([]byte, scalarLength))
+}
+
+func deriveRFC6979(x *Scalar, message, extra []byte) *Scalar {
+	h := hashID.New
+	size := hashID.Size()
+	xb := x.Encode()
+	hb := bits2octets(message)
+
+	v := make([]byte, size)
+	k := make([]byte, size)
+
+	for i := range v {
+		v[i] = 0x01
+	}
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(h, key)
+		for _, d := range data {
+			_, _ = m.Write(d)
+		}
+
+		return m.Sum(nil)
+	}
+
+	k = mac(k, v, []byte{0x00}, xb, hb, extra)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, xb, hb, extra)
+	v = mac(k, v)
+
+	nonce := newScalar()
+
+	for {
+		// qlen = hlen = 256, so a single HMAC output is enough for T.
+		v = mac(k, v)
+
+		candidate := bits2int(v)
+		if candidate.Sign() != 0 && candidate.Cmp(fn.Order()) < 0 {
+			nonce.scalar.Set(candidate)
+			return nonce
+		}
+
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ring
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// LinkableSignature is a linkable SAG ring signature, i.e. the first challenge of the ring, one response per member,
+// and the key image of the signer.
+type LinkableSignature struct {
+	C        *secp256k1.Scalar
+	S        []*secp256k1.Scalar
+	KeyImage *secp256k1.Element
+}
+
+// hashToPoint returns the base of the key image of the public key, whose discrete logarithm is unknown.
+func hashToPoint(pub *secp256k1.PublicKey) *secp256k1.Element {
+	return secp256k1.HashToGroup(pub.Bytes(), []byte(dstKeyImage))
+}
+
+// KeyImage returns the key image x * Hp(P) of the private key, which is the same in all the linkable signatures it
+// produces, whatever the ring and the message.
+func KeyImage(key *secp256k1.PrivateKey) *secp256k1.Element {
+	x := key.Scalar()
+	defer x.Zero()
+
+	return hashToPoint(key.PublicKey()).Multiply(x)
+}
+
+// SignLinkable returns a linkable SAG ring signature of the message by the private key, whose public key must be in
+// the ring, with randomness read from rand as for Sign.
+func SignLinkable(
+	key *secp256k1.PrivateKey,
+	ring []*secp256k1.PublicKey,
+	message []byte,
+	rand io.Reader,
+) (*LinkableSignature, error) {
+	j, err := signerIndex(key, ring)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := randomScalars(rand, len(ring))
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(ring)
+	image := KeyImage(key)
+	prefix := append(transcript(ring, message), image.Encode()...)
+	c := make([]*secp256k1.Scalar, n)
+	c[(j+1)%n] = challenge(dstLinkable, prefix, secp256k1.ScalarBaseMult(s[j]), hashToPoint(ring[j]).Multiply(s[j]))
+
+	for k := 1; k < n; k++ {
+		i := (j + k) % n
+		l := ring[i].Element().Multiply(c[i]).Add(secp256k1.ScalarBaseMult(s[i]))
+		r := image.Copy().Multiply(c[i]).Add(hashToPoint(ring[i]).Multiply(s[i]))
+		c[(i+1)%n] = challenge(dstLinkable, prefix, l, r)
+	}
+
+	x := key.Scalar()
+	defer x.Zero()
+
+	s[j].Subtract(x.Multiply(c[j]))
+
+	return &LinkableSignature{C: c[0], S: s, KeyImage: image}, nil
+}
+
+// VerifyLinkable returns whether the signature is a valid linkable ring signature of the message by a member of the
+// ring.
+func VerifyLinkable(ring []*secp256k1.PublicKey, message []byte, signature *LinkableSignature) bool {
+	if len(ring) == 0 || signature == nil || signature.C == nil || len(signature.S) != len(ring) ||
+		signature.KeyImage == nil || signature.KeyImage.IsIdentity() {
+		return false
+	}
+
+	prefix := append(transcript(ring, message), signature.KeyImage.Encode()...)
+	c := signature.C.Copy()
+
+	for i, p := range ring {
+		if p == nil || signature.S[i] == nil {
+			return false
+		}
+
+		l := secp256k1.DoubleScalarBaseMultVartime(signature.S[i], c, p.Element())
+		r := secp256k1.MultiScalarMultVartime(
+			[]*secp256k1.Scalar{signature.S[i], c},
+			[]*secp256k1.Element{hashToPoint(p), signature.KeyImage},
+		)
+		c = challenge(dstLinkable, prefix, l, r)
+	}
+
+	return c.Equal(signature.C) == 1
+}
+
+// Linked returns whether the two signatures were produced by the same private key, i.e. have the same key image. It
+// is only meaningful for signatures that verify.
+func Linked(a, b *LinkableSignature) bool {
+	return a.KeyImage.Equal(b.KeyImage) == 1
+}
+
+// Encode returns the encoding I || c || s_0 || ... || s_{n-1} of the signature, of 33 + 32 * (n + 1) bytes for a ring
+// of n keys.
+func (s *LinkableSignature) Encode() []byte {
+	return append(s.KeyImage.Encode(), (&Signature{C: s.C, S: s.S}).Encode()...)
+}
+
+// Decode sets the receiver to the decoding of the signature, whose ring size is deduced from its length, and returns
+// an error wrapping ErrInvalidSignature on failure. The receiver is left untouched on error.
+func (s *LinkableSignature) Decode(data []byte) error {
+	if len(data) < elementLength {
+		return fmt.Errorf("%w: invalid length", ErrInvalidSignature)
+	}
+
+	image := secp256k1.NewElement()
+	if err := image.Decode(data[:elementLength]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	sig := new(Signature)
+	if err := sig.Decode(data[elementLength:]); err != nil {
+		return err
+	}
+
+	s.C, s.S, s.KeyImage = sig.C, sig.S, image
+
+	return nil
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ring implements Schnorr ring signatures over secp256k1, on top of the secp256k1 package's arithmetic. A ring
+// signature proves that the message was signed by the private key of one of the public keys of the ring, without
+// revealing which one.
+//
+// Sign produces unlinkable AOS signatures (Abe, Ohkubo, and Suzuki, 2002), of which two signatures by the same signer
+// can't be told apart from two signatures by different members. SignLinkable produces linkable SAG signatures (Liu,
+// Wei, and Wong, 2004), which carry a key image that is the same for all signatures of a signer, e.g. to detect double
+// spending or double voting while keeping the signer anonymous.
+//
+// Signatures grow linearly with the size of the ring, and are only anonymous among keys of the same ring.
+package ring
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/secp256k1"
+)
+
+const (
+	dstChallenge = "github.com/bytemare/secp256k1/ring:challenge"
+	dstLinkable  = "github.com/bytemare/secp256k1/ring:linkable-challenge"
+	dstKeyImage  = "github.com/bytemare/secp256k1/ring:key-image"
+
+	scalarLength  = 32
+	elementLength = 33
+)
+
+var (
+	// ErrKeyNotInRing indicates that the public key of the signer is not in the ring, or that the ring is empty.
+	ErrKeyNotInRing = errors.New("signer's key is not in the ring")
+
+	// ErrInvalidSignature indicates a malformed signature encoding.
+	ErrInvalidSignature = errors.New("invalid ring signature")
+)
+
+// Signature is an unlinkable AOS ring signature, i.e. the first challenge of the ring and one response per member.
+type Signature struct {
+	C *secp256k1.Scalar
+	S []*secp256k1.Scalar
+}
+
+// signerIndex returns the first position of the key's public key in the ring, or an error wrapping ErrKeyNotInRing.
+// The whole ring is scanned, and the position is selected with constant-time comparisons, so that the time it takes
+// does not depend on the position.
+func signerIndex(key *secp256k1.PrivateKey, ring []*secp256k1.PublicKey) (int, error) {
+	pub := key.PublicKey().Bytes()
+	index, found := 0, 0
+
+	for i, p := range ring {
+		if p == nil {
+			continue
+		}
+
+		eq := subtle.ConstantTimeCompare(pub, p.Bytes()) & (found ^ 1)
+		index = subtle.ConstantTimeSelect(eq, i, index)
+		found |= eq
+	}
+
+	if found == 0 {
+		return 0, ErrKeyNotInRing
+	}
+
+	return index, nil
+}
+
+// transcript returns the encoding of the ring and the message, bound to every challenge.
+func transcript(ring []*secp256k1.PublicKey, message []byte) []byte {
+	out := make([]byte, 0, len(ring)*elementLength+len(message))
+	for _, p := range ring {
+		out = append(out, p.Bytes()...)
+	}
+
+	return append(out, message...)
+}
+
+// challenge returns the challenge of the transcript and the commitments.
+func challenge(dst string, prefix []byte, commitments ...*secp256k1.Element) *secp256k1.Scalar {
+	input := prefix
+	for _, c := range commitments {
+		input = append(input[:len(input):len(input)], c.Encode()...)
+	}
+
+	return secp256k1.HashToScalar(input, []byte(dst))
+}
+
+// randomScalars returns n random scalars read from rand, and an error if rand is nil or fails.
+func randomScalars(rand io.Reader, n int) ([]*secp256k1.Scalar, error) {
+	s := make([]*secp256k1.Scalar, n)
+	for i := range s {
+		var err error
+		if s[i], err = secp256k1.NewScalar().RandomFrom(rand); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Sign returns an AOS ring signature of the message by the private key, whose public key must be in the ring, with
+// randomness read from rand, e.g. crypto/rand.Reader. It returns an error if rand is nil or fails, as for
+// Scalar.RandomFrom. The position of the signer is found without branching on it, and the same operations are
+// performed for every member, but the arithmetic is built on math/big, which does not guarantee constant-time
+// execution, so Sign is not hardened against timing side channels.
+func Sign(key *secp256k1.PrivateKey, ring []*secp256k1.PublicKey, message []byte, rand io.Reader) (*Signature, error) {
+	j, err := signerIndex(key, ring)
+	if err != nil {
+		return nil, err
+	}
+
+	// The responses of the other members are random, and the signer's slot holds its nonce until it is closed.
+	s, err := randomScalars(rand, len(ring))
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(ring)
+	prefix := transcript(ring, message)
+	c := make([]*secp256k1.Scalar, n)
+	c[(j+1)%n] = challenge(dstChallenge, prefix, secp256k1.ScalarBaseMult(s[j]))
+
+	for k := 1; k < n; k++ {
+		i := (j + k) % n
+		l := ring[i].Element().Multiply(c[i]).Add(secp256k1.ScalarBaseMult(s[i]))
+		c[(i+1)%n] = challenge(dstChallenge, prefix, l)
+	}
+
+	x := key.Scalar()
+	defer x.Zero()
+
+	s[j].Subtract(x.Multiply(c[j]))
+
+	return &Signature{C: c[0], S: s}, nil
+}
+
+// Verify returns whether the signature is a valid AOS ring signature of the message by a member of the ring.
+func Verify(ring []*secp256k1.PublicKey, message []byte, signature *Signature) bool {
+	if len(ring) == 0 || signature == nil || signature.C == nil || len(signature.S) != len(ring) {
+		return false
+	}
+
+	prefix := transcript(ring, message)
+	c := signature.C.Copy()
+
+	for i, p := range ring {
+		if p == nil || signature.S[i] == nil {
+			return false
+		}
+
+		c = challenge(dstChallenge, prefix, secp256k1.DoubleScalarBaseMultVartime(signature.S[i], c, p.Element()))
+	}
+
+	return c.Equal(signature.C) == 1
+}
+
+// Encode returns the encoding c || s_0 || ... || s_{n-1} of the signature, of 32 * (n + 1) bytes for a ring of n keys.
+func (s *Signature) Encode() []byte {
+	out := make([]byte, 0, scalarLength*(len(s.S)+1))
+	out = append(out, s.C.Encode()...)
+
+	for _, si := range s.S {
+		out = append(out, si.Encode()...)
+	}
+
+	return out
+}
+
+// decodeScalars decodes the concatenation of scalars.
+func decodeScalars(data []byte) ([]*secp256k1.Scalar, error) {
+	if len(data) == 0 || len(data)%scalarLength != 0 {
+		return nil, fmt.Errorf("%w: invalid length", ErrInvalidSignature)
+	}
+
+	out := make([]*secp256k1.Scalar, len(data)/scalarLength)
+	for i := range out {
+		out[i] = secp256k1.NewScalar()
+		if err := out[i].Decode(data[i*scalarLength : (i+1)*scalarLength]); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+		}
+	}
+
+	return out, nil
+}
+
+// Decode sets the receiver to the decoding of the signature, whose ring size is deduced from its length, and returns
+// an error wrapping ErrInvalidSignature on failure. The receiver is left untouched on error.
+func (s *Signature) Decode(data []byte) error {
+	scalars, err := decodeScalars(data)
+	if err != nil {
+		return err
+	}
+
+	if len(scalars) < 2 {
+		return fmt.Errorf("%w: invalid length", ErrInvalidSignature)
+	}
+
+	s.C, s.S = scalars[0], scalars[1:]
+
+	return nil
+}
@@ -10,24 +10,36 @@ package secp256k1
 
 import (
 	"crypto/subtle"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
+	"io"
 	"math/big"
+	"math/bits"
+	"slices"
+
+	"github.com/bytemare/hash2curve"
 )
 
 var (
-	// errParamScalarLength indicates an invalid scalar length.
-	errParamScalarLength = errors.New("invalid scalar length")
-
-	// errParamNilScalar indicates a forbidden nil or empty scalar.
-	errParamNilScalar = errors.New("nil or empty scalar")
-
 	// errParamNegScalar reports an error when the input scalar is negative.
 	// errParamNegScalar = errors.New("negative scalar").
 
-	// errParamScalarTooBig reports an error when the input scalar is too big.
-	errParamScalarTooBig = errors.New("scalar too big")
+	// errParamNAFWindow indicates an unsupported window width for the NAF recoding.
+	errParamNAFWindow = errors.New("invalid NAF window width")
+
+	// errParamWindowWidth indicates an unsupported window width for the digit accessor.
+	errParamWindowWidth = errors.New("invalid window width")
+
+	// errParamShortBuffer indicates a destination buffer too short to hold an encoding.
+	errParamShortBuffer = errors.New("destination buffer too short")
+)
+
+const (
+	nafMinWindow = 2
+	nafMaxWindow = 8
 )
 
 type disallowEqual [0]func()
@@ -71,7 +83,8 @@ func (s *Scalar) MinusOne() *Scalar {
 }
 
 // Random sets the current scalar to a new random scalar and returns it.
-// The random source is crypto/rand, and this functions is guaranteed to return a non-zero scalar.
+// The random source is crypto/rand, and this functions is guaranteed to return a non-zero scalar. Candidates are drawn
+// by rejection sampling (as libsecp256k1 does), so the result is uniform over [1, order-1] without modulo bias.
 func (s *Scalar) Random() *Scalar {
 	for {
 		fn.Random(&s.scalar)
@@ -82,6 +95,34 @@ func (s *Scalar) Random() *Scalar {
 	}
 }
 
+// randomAttempts bounds the number of samples RandomFrom draws before giving up on a source that keeps yielding zero.
+const randomAttempts = 128
+
+// RandomFrom sets the current scalar to a new non-zero random scalar read from r, and returns it. Unlike Random, it
+// does not panic: it returns an error if r is nil, if reading from r fails, or if r repeatedly yields zero or values
+// out of range. 32-byte candidates are read from r and rejected if they are not canonical, so that the result is free
+// of modulo bias. The receiver is left untouched on error.
+func (s *Scalar) RandomFrom(r io.Reader) (*Scalar, error) {
+	if r == nil {
+		return nil, ErrNilRandomSource
+	}
+
+	var tmp big.Int
+
+	for range randomAttempts {
+		if err := fn.RandomFrom(&tmp, r); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRandomSource, err)
+		}
+
+		if tmp.Sign() != 0 {
+			s.scalar.Set(&tmp)
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: repeatedly yields zero", ErrRandomSource)
+}
+
 // Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
 func (s *Scalar) Add(scalar *Scalar) *Scalar {
 	if scalar == nil {
@@ -117,7 +158,11 @@ func (s *Scalar) Multiply(scalar *Scalar) *Scalar {
 
 // Pow sets s to s**scalar modulo the group order, and returns s. If scalar is nil, it returns 1.
 func (s *Scalar) Pow(scalar *Scalar) *Scalar {
-	if scalar == nil || scalar.IsZero() {
+	if scalar == nil {
+		return s.One()
+	}
+
+	if scalar.IsZero() {
 		return s.One()
 	}
 
@@ -130,7 +175,27 @@ func (s *Scalar) Pow(scalar *Scalar) *Scalar {
 	return s
 }
 
-// Invert sets the receiver to its modular inverse ( 1 / s ), and returns it.
+// PowUint64 sets s to s**e modulo the group order, and returns s. It uses left-to-right square-and-multiply, and its
+// execution time depends on the exponent, which must therefore be public. s**0 = 1.
+func (s *Scalar) PowUint64(e uint64) *Scalar {
+	var base big.Int
+	base.Set(&s.scalar)
+	s.scalar.Set(scOne)
+
+	for i := bits.Len64(e) - 1; i >= 0; i-- {
+		fn.Square(&s.scalar, &s.scalar)
+
+		if (e>>i)&1 == 1 {
+			fn.Mul(&s.scalar, &s.scalar, &base)
+		}
+	}
+
+	return s
+}
+
+// Invert sets the receiver to its modular inverse ( 1 / s ), and returns it. Zero has no inverse: by definition,
+// inverting zero yields zero, such that protocols can detect the degenerate case with IsZero() on the result.
+// The inversion is computed as s^(order-2), which naturally maps zero to zero without branching.
 func (s *Scalar) Invert() *Scalar {
 	fn.Inv(&s.scalar, &s.scalar)
 	return s
@@ -145,21 +210,118 @@ func (s *Scalar) Equal(scalar *Scalar) int {
 	return subtle.ConstantTimeCompare(s.scalar.Bytes(), scalar.scalar.Bytes())
 }
 
-// LessOrEqual returns 1 if s <= scalar and 0 otherwise.
+// LessOrEqual returns 1 if s <= scalar and 0 otherwise, comparing the big-endian encodings from the most significant
+// byte without branching on the values.
 func (s *Scalar) LessOrEqual(scalar *Scalar) int {
-	ienc := s.Encode()
-	jenc := scalar.Encode()
-	var res bool
+	return 1 - ctGreaterThan(s.Encode(), scalar.Encode())
+}
+
+// ctGreaterThan returns 1 if a > b and 0 otherwise, in constant time. a and b must be big-endian encodings of the same
+// length.
+func ctGreaterThan(a, b []byte) int {
+	gt, eq := 0, 1
+
+	for i := range a {
+		gt |= eq & subtle.ConstantTimeLessOrEq(int(b[i])+1, int(a[i]))
+		eq &= subtle.ConstantTimeByteEq(a[i], b[i])
+	}
+
+	return gt
+}
+
+func (s *Scalar) isHigh() int {
+	return ctGreaterThan(s.Encode(), scHalfOrder)
+}
+
+// IsHigh returns whether the scalar is strictly greater than (order-1)/2, i.e. whether it is in the upper half of the
+// range of scalars. This is used to enforce low-S signatures.
+func (s *Scalar) IsHigh() bool {
+	return s.isHigh() == 1
+}
+
+// NegateIfHigh sets the scalar to its negation (order - s) if it is strictly greater than (order-1)/2, in constant
+// time, and returns it. After this call, IsHigh() always returns false.
+func (s *Scalar) NegateIfHigh() *Scalar {
+	fn.CondNeg(&s.scalar, &s.scalar, s.isHigh())
+	return s
+}
+
+// leByte returns the i-th byte of the little-endian encoding enc, or 0 if i is out of range.
+func leByte(enc []byte, i int) byte {
+	if i < 0 || i >= len(enc) {
+		return 0
+	}
+
+	return enc[len(enc)-1-i]
+}
 
-	for i := range ienc {
-		res = res || (ienc[i] > jenc[i])
+// Bit returns the value of the i-th bit of the scalar, i.e. (s >> i) & 1, and 0 if i is out of [0, 256). Its
+// execution time does not depend on the value of the scalar, only on the public index i.
+func (s *Scalar) Bit(i int) uint8 {
+	if i < 0 {
+		return 0
 	}
 
-	if res {
+	return (leByte(s.Encode(), i/8) >> (i % 8)) & 1
+}
+
+// Window returns the unsigned value of the width bits of the scalar starting at bit offset, i.e.
+// (s >> offset) & (2^width - 1), with width in [1, 8]. Bits beyond the scalar's length are 0. Its execution time does
+// not depend on the value of the scalar, only on the public offset and width. It panics if width is out of range.
+func (s *Scalar) Window(offset, width int) uint8 {
+	if width < 1 || width > 8 {
+		panic(errParamWindowWidth)
+	}
+
+	if offset < 0 {
 		return 0
 	}
 
-	return 1
+	enc := s.Encode()
+	pos := offset / 8
+	w := uint16(leByte(enc, pos)) | uint16(leByte(enc, pos+1))<<8
+
+	return uint8((w >> (offset % 8)) & (1<<width - 1))
+}
+
+// NAF returns the width-w non-adjacent form of the scalar, with w in [2, 8]. The output holds scalarLength*8+1
+// signed digits in little-endian order (i.e. s = sum(naf[i] * 2^i)), each digit being either 0 or odd and in
+// ]-2^(w-1), 2^(w-1)[, and among any w consecutive digits at most one is non-zero. w = 2 yields the classic NAF.
+// This recoding branches on the value of the scalar, and must only be used on public scalars. It panics if w is out
+// of range.
+func (s *Scalar) NAF(w int) []int8 {
+	if w < nafMinWindow || w > nafMaxWindow {
+		panic(errParamNAFWindow)
+	}
+
+	var (
+		k, d  big.Int
+		naf   = make([]int8, scalarLength*8+1)
+		width = big.Word(1) << w
+		mask  = width - 1
+		half  = width >> 1
+	)
+
+	k.Set(&s.scalar)
+
+	for i := 0; k.Sign() != 0; i++ {
+		if k.Bit(0) == 1 {
+			digit := k.Bits()[0] & mask
+			if digit >= half {
+				naf[i] = int8(int(digit) - int(width))
+				d.SetUint64(uint64(width - digit))
+				k.Add(&k, &d)
+			} else {
+				naf[i] = int8(digit)
+				d.SetUint64(uint64(digit))
+				k.Sub(&k, &d)
+			}
+		}
+
+		k.Rsh(&k, 1)
+	}
+
+	return naf
 }
 
 // IsZero returns whether the scalar is 0.
@@ -186,6 +348,32 @@ func (s *Scalar) SetUInt64(i uint64) *Scalar {
 	return s
 }
 
+// SetBytesMod sets s to the big-endian unsigned integer in, of any length, reduced modulo the group order, and returns
+// it. To produce uniformly distributed scalars from random or hashed input, in should be at least 48 bytes long.
+func (s *Scalar) SetBytesMod(in []byte) *Scalar {
+	s.scalar.SetBytes(in)
+	fn.Mod(&s.scalar)
+
+	return s
+}
+
+// FromHash sets s to the digest of h, interpreted as a big-endian integer and reduced modulo the group order, and
+// returns s. The state of h is not modified, so more data can be written to it afterwards. Digests of at least 48
+// bytes (e.g. SHA-384, SHA-512) yield uniformly distributed scalars. Since the group order is close to 2^256, the
+// bias of a 32-byte digest (e.g. SHA-256) is below 2^-127, while shorter digests are not suitable.
+func (s *Scalar) FromHash(h hash.Hash) *Scalar {
+	var buf [64]byte
+
+	return s.SetBytesMod(h.Sum(buf[:0]))
+}
+
+// Hash sets s to HashToScalar(input, dst), and returns it. This avoids allocating a new Scalar on each call in loops.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (s *Scalar) Hash(input, dst []byte) *Scalar {
+	s.scalar.Set(hash2curve.HashToFieldXMD(hashID, input, dst, 1, 1, secLength, fn.Order())[0])
+	return s
+}
+
 // Copy returns a copy of the receiver.
 func (s *Scalar) Copy() *Scalar {
 	cpy := newScalar()
@@ -200,15 +388,42 @@ func (s *Scalar) Encode() []byte {
 	return s.scalar.FillBytes(scalar)
 }
 
+// EncodeTo writes the fixed-size big-endian encoding of the scalar into the first 32 bytes of dst, and returns that
+// sub-slice. It does not allocate, and panics if dst is shorter than 32 bytes.
+func (s *Scalar) EncodeTo(dst []byte) []byte {
+	if len(dst) < scalarLength {
+		panic(errParamShortBuffer)
+	}
+
+	return s.scalar.FillBytes(dst[:scalarLength])
+}
+
+// Bytes32 returns the fixed-size big-endian encoding of the scalar as an array, without heap allocation.
+func (s *Scalar) Bytes32() [scalarLength]byte {
+	var out [scalarLength]byte
+	s.scalar.FillBytes(out[:])
+
+	return out
+}
+
+// appendEncoding appends the byte encoding of the scalar to dst, and only allocates if dst has insufficient capacity.
+func (s *Scalar) appendEncoding(dst []byte) []byte {
+	dst = slices.Grow(dst, scalarLength)
+	end := len(dst) + scalarLength
+	s.scalar.FillBytes(dst[len(dst):end])
+
+	return dst[:end]
+}
+
 // Decode sets the receiver to a decoding of the input data, and returns an error on failure.
 func (s *Scalar) Decode(in []byte) error {
 	switch len(in) {
 	case 0:
-		return errParamNilScalar
+		return ErrEmptyScalar
 	case scalarLength:
 		break
 	default:
-		return errParamScalarLength
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidScalarLength, scalarLength, len(in))
 	}
 
 	// warning - SetBytes interprets the input as a non-signed integer, so this will always be false
@@ -218,7 +433,7 @@ func (s *Scalar) Decode(in []byte) error {
 	tmp := new(big.Int).SetBytes(in)
 
 	if fn.Order().Cmp(tmp) <= 0 {
-		return errParamScalarTooBig
+		return ErrScalarTooBig
 	}
 
 	s.scalar.Set(tmp)
@@ -226,6 +441,47 @@ func (s *Scalar) Decode(in []byte) error {
 	return nil
 }
 
+// EncodeLE returns the fixed-size little-endian byte encoding of the scalar.
+func (s *Scalar) EncodeLE() []byte {
+	out := s.Encode()
+	slices.Reverse(out)
+
+	return out
+}
+
+// DecodeLE sets the receiver to the decoding of the little-endian encoded input, and returns an error on failure.
+// The same validation rules as for Decode apply.
+func (s *Scalar) DecodeLE(in []byte) error {
+	be := slices.Clone(in)
+	slices.Reverse(be)
+
+	return s.Decode(be)
+}
+
+// Limbs returns the canonical value of the scalar as four saturated 64-bit limbs in little-endian order, i.e.
+// s = limbs[0] + limbs[1]*2^64 + limbs[2]*2^128 + limbs[3]*2^192. This is a plain, non-Montgomery representation.
+func (s *Scalar) Limbs() [4]uint64 {
+	var limbs [4]uint64
+
+	enc := s.Encode()
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(enc[scalarLength-8*(i+1):])
+	}
+
+	return limbs
+}
+
+// SetLimbs sets s to the value represented by the four saturated 64-bit limbs in little-endian order, as returned by
+// Limbs. It returns an error if the value is not canonical, i.e. not strictly lower than the group order.
+func (s *Scalar) SetLimbs(limbs [4]uint64) error {
+	var enc [scalarLength]byte
+	for i, limb := range limbs {
+		binary.BigEndian.PutUint64(enc[scalarLength-8*(i+1):], limb)
+	}
+
+	return s.Decode(enc[:])
+}
+
 // Hex returns the fixed-sized hexadecimal encoding of s.
 func (s *Scalar) Hex() string {
 	return hex.EncodeToString(s.Encode())
@@ -246,7 +502,45 @@ func (s *Scalar) MarshalBinary() ([]byte, error) {
 	return s.Encode(), nil
 }
 
+// AppendBinary appends the compressed byte encoding of the scalar to dst and returns the extended buffer. It
+// implements encoding.BinaryAppender, and does not allocate if dst has enough spare capacity.
+func (s *Scalar) AppendBinary(dst []byte) ([]byte, error) {
+	return s.appendEncoding(dst), nil
+}
+
 // UnmarshalBinary sets e to the decoding of the byte encoded scalar.
 func (s *Scalar) UnmarshalBinary(data []byte) error {
 	return s.Decode(data)
 }
+
+// MarshalText returns the fixed-sized hexadecimal encoding of the scalar.
+func (s *Scalar) MarshalText() ([]byte, error) {
+	return []byte(s.Hex()), nil
+}
+
+// UnmarshalText sets s to the decoding of the hex encoded scalar.
+func (s *Scalar) UnmarshalText(text []byte) error {
+	return s.DecodeHex(string(text))
+}
+
+// scalarRedactedString is what String() returns for a scalar.
+const scalarRedactedString = "Scalar(REDACTED)"
+
+// String implements fmt.Stringer. It returns a redacted placeholder, which prevents secret scalars from accidentally
+// leaking into logs. Print the scalar with the %x or %X verb, or use Hex, for its hexadecimal encoding, e.g. for
+// debugging or for public scalars.
+func (s *Scalar) String() string {
+	return scalarRedactedString
+}
+
+// Format implements fmt.Formatter. The %x and %X verbs print the hexadecimal encoding of the scalar, honoring flags
+// such as %#x, so that printing the value is an explicit choice at each call site. All other verbs, including %v, %s
+// and %#v, print the redacted placeholder of String.
+func (s *Scalar) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'x', 'X':
+		_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), s.Encode())
+	default:
+		_, _ = io.WriteString(f, scalarRedactedString)
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"errors"
+	"math/big"
+)
+
+// errVectorLength indicates that two vectors of different lengths are combined.
+var errVectorLength = errors.New("vectors have different lengths")
+
+// ScalarVector is a vector of scalars supporting element-wise and batch arithmetic. Operations are applied in place on
+// the receiver, which is returned to allow chaining. Combining vectors of different lengths panics.
+type ScalarVector []*Scalar
+
+// NewScalarVector returns a new vector of n scalars set to 0.
+func NewScalarVector(n int) ScalarVector {
+	v := make(ScalarVector, n)
+	for i := range v {
+		v[i] = newScalar()
+	}
+
+	return v
+}
+
+// ScalarPowers returns the vector (1, x, x^2, ..., x^(n-1)).
+func ScalarPowers(x *Scalar, n int) ScalarVector {
+	v := NewScalarVector(n)
+	if n == 0 {
+		return v
+	}
+
+	v[0].One()
+
+	for i := 1; i < n; i++ {
+		fn.Mul(&v[i].scalar, &v[i-1].scalar, &x.scalar)
+	}
+
+	return v
+}
+
+func (v ScalarVector) checkLength(w ScalarVector) {
+	if len(v) != len(w) {
+		panic(errVectorLength)
+	}
+}
+
+// Copy returns a deep copy of the vector.
+func (v ScalarVector) Copy() ScalarVector {
+	cpy := make(ScalarVector, len(v))
+	for i, s := range v {
+		cpy[i] = s.Copy()
+	}
+
+	return cpy
+}
+
+// Add sets v[i] = v[i] + w[i] for all i, and returns v.
+func (v ScalarVector) Add(w ScalarVector) ScalarVector {
+	v.checkLength(w)
+
+	for i, s := range v {
+		s.Add(w[i])
+	}
+
+	return v
+}
+
+// Subtract sets v[i] = v[i] - w[i] for all i, and returns v.
+func (v ScalarVector) Subtract(w ScalarVector) ScalarVector {
+	v.checkLength(w)
+
+	for i, s := range v {
+		s.Subtract(w[i])
+	}
+
+	return v
+}
+
+// Multiply sets v[i] = v[i] * w[i] for all i (the Hadamard product), and returns v.
+func (v ScalarVector) Multiply(w ScalarVector) ScalarVector {
+	v.checkLength(w)
+
+	for i, s := range v {
+		s.Multiply(w[i])
+	}
+
+	return v
+}
+
+// AddScalar sets v[i] = v[i] + scalar for all i, and returns v.
+func (v ScalarVector) AddScalar(scalar *Scalar) ScalarVector {
+	for _, s := range v {
+		s.Add(scalar)
+	}
+
+	return v
+}
+
+// MultiplyScalar sets v[i] = v[i] * scalar for all i, and returns v.
+func (v ScalarVector) MultiplyScalar(scalar *Scalar) ScalarVector {
+	for _, s := range v {
+		s.Multiply(scalar)
+	}
+
+	return v
+}
+
+// Negate sets v[i] = -v[i] for all i, and returns v.
+func (v ScalarVector) Negate() ScalarVector {
+	for _, s := range v {
+		fn.Neg(&s.scalar, &s.scalar)
+	}
+
+	return v
+}
+
+// Sum returns the sum of all the scalars in the vector. The reduction modulo the group order is only done once.
+func (v ScalarVector) Sum() *Scalar {
+	res := newScalar()
+
+	for _, s := range v {
+		res.scalar.Add(&res.scalar, &s.scalar)
+	}
+
+	fn.Mod(&res.scalar)
+
+	return res
+}
+
+// InnerProduct returns the inner product of v and w, i.e. the sum of v[i] * w[i]. Products are accumulated
+// unreduced, and the reduction modulo the group order is only done once.
+func (v ScalarVector) InnerProduct(w ScalarVector) *Scalar {
+	v.checkLength(w)
+
+	var product big.Int
+	res := newScalar()
+
+	for i, s := range v {
+		product.Mul(&s.scalar, &w[i].scalar)
+		res.scalar.Add(&res.scalar, &product)
+	}
+
+	fn.Mod(&res.scalar)
+
+	return res
+}
+
+// Equal returns 1 if both vectors have the same length and hold the same scalars, and 0 otherwise.
+func (v ScalarVector) Equal(w ScalarVector) int {
+	if len(v) != len(w) {
+		return 0
+	}
+
+	res := 1
+	for i, s := range v {
+		res &= s.Equal(w[i])
+	}
+
+	return res
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+const (
+	// dstMaxLength is the maximum length of a DST for expand_message_xmd, longer ones are hashed.
+	dstMaxLength = 255
+
+	// dstLongPrefix prefixes oversized DSTs before hashing them, as per RFC 9380.
+	dstLongPrefix = "H2C-OVERSIZE-DST-"
+)
+
+// errParamZeroLenDST indicates an empty DST.
+var errParamZeroLenDST = errors.New("zero-length DST")
+
+// HashToGroupWriter computes HashToGroup over a message written in chunks, so that large inputs like files or
+// transcripts don't need to be buffered in memory. It implements io.Writer, and Sum returns the same element as
+// HashToGroup over the concatenation of everything written so far.
+type HashToGroupWriter struct {
+	h        hash.Hash
+	dstPrime []byte
+}
+
+// NewHashToGroupWriter returns a HashToGroupWriter for the given DST, with the same requirements as for HashToGroup:
+// the DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func NewHashToGroupWriter(dst []byte) *HashToGroupWriter {
+	if len(dst) == 0 {
+		panic(errParamZeroLenDST)
+	}
+
+	if len(dst) > dstMaxLength {
+		h := sha256.New()
+		_, _ = h.Write([]byte(dstLongPrefix))
+		_, _ = h.Write(dst)
+		dst = h.Sum(nil)
+	}
+
+	w := &HashToGroupWriter{
+		h:        sha256.New(),
+		dstPrime: append(append(make([]byte, 0, len(dst)+1), dst...), byte(len(dst))),
+	}
+	w.Reset()
+
+	return w
+}
+
+// Write absorbs more of the message. It never returns an error.
+func (w *HashToGroupWriter) Write(p []byte) (int, error) {
+	return w.h.Write(p)
+}
+
+// Reset discards everything written so far, keeping the DST.
+func (w *HashToGroupWriter) Reset() {
+	w.h.Reset()
+	_, _ = w.h.Write(make([]byte, w.h.BlockSize())) // Z_pad
+}
+
+// Sum returns the element HashToGroup would return for the message written so far. It does not change the state of
+// the writer, so more data can be written afterwards.
+func (w *HashToGroupWriter) Sum() *Element {
+	state, err := w.h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	h := sha256.New()
+	if err = h.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		panic(err)
+	}
+
+	return w.sum(h)
+}
+
+// sum finalizes the hash-to-curve computation on h, which holds the message state and is consumed.
+func (w *HashToGroupWriter) sum(h hash.Hash) *Element {
+	uniform := w.expand(h, 2*secLength)
+	u := []*big.Int{
+		fp.Mod(new(big.Int).SetBytes(uniform[:secLength])),
+		fp.Mod(new(big.Int).SetBytes(uniform[secLength:])),
+	}
+
+	return mapToCurveRO(u)
+}
+
+// expand finalizes expand_message_xmd on h, which holds the message state and is consumed, and returns length uniform
+// bytes.
+func (w *HashToGroupWriter) expand(h hash.Hash, length int) []byte {
+	// b_0 = H(Z_pad || msg || I2OSP(len_in_bytes, 2) || I2OSP(0, 1) || DST_prime)
+	_, _ = h.Write([]byte{byte(length >> 8), byte(length), 0})
+	_, _ = h.Write(w.dstPrime)
+	b0 := h.Sum(nil)
+
+	// b_i = H(strxor(b_0, b_(i-1)) || I2OSP(i, 1) || DST_prime), with b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	out := make([]byte, 0, length+h.Size())
+	bi := make([]byte, h.Size())
+
+	for i := 1; len(out) < length; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+
+		h.Reset()
+		_, _ = h.Write(bi)
+		_, _ = h.Write([]byte{byte(i)})
+		_, _ = h.Write(w.dstPrime)
+		bi = h.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+
+	return out[:length]
+}
+
+// hashToCurveBatch maps each input to the group as HashToGroup does, vetting the DST and allocating the hash state
+// only once.
+func hashToCurveBatch(inputs [][]byte, dst []byte) []*Element {
+	w := NewHashToGroupWriter(dst)
+	res := make([]*Element, len(inputs))
+
+	for i, input := range inputs {
+		w.Reset()
+		_, _ = w.h.Write(input)
+		res[i] = w.sum(w.h)
+	}
+
+	w.Reset()
+
+	return res
+}
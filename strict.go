@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import "fmt"
+
+// The methods that take a *Scalar or *Element operand treat nil as zero or the identity point, e.g. Add(nil) is a
+// no-op and Multiply(nil) yields zero or the identity. The Strict variants below return an error wrapping
+// ErrNilOperand on a nil operand instead, and leave the receiver untouched, which surfaces bugs in calling code that
+// would otherwise go unnoticed.
+
+func nilOperand(method string) error {
+	return fmt.Errorf("%w: %s", ErrNilOperand, method)
+}
+
+// AddStrict is like Add, but returns an error wrapping ErrNilOperand if scalar is nil.
+func (s *Scalar) AddStrict(scalar *Scalar) error {
+	if scalar == nil {
+		return nilOperand("Add")
+	}
+
+	s.Add(scalar)
+
+	return nil
+}
+
+// SubtractStrict is like Subtract, but returns an error wrapping ErrNilOperand if scalar is nil.
+func (s *Scalar) SubtractStrict(scalar *Scalar) error {
+	if scalar == nil {
+		return nilOperand("Subtract")
+	}
+
+	s.Subtract(scalar)
+
+	return nil
+}
+
+// MultiplyStrict is like Multiply, but returns an error wrapping ErrNilOperand if scalar is nil.
+func (s *Scalar) MultiplyStrict(scalar *Scalar) error {
+	if scalar == nil {
+		return nilOperand("Multiply")
+	}
+
+	s.Multiply(scalar)
+
+	return nil
+}
+
+// PowStrict is like Pow, but returns an error wrapping ErrNilOperand if scalar is nil.
+func (s *Scalar) PowStrict(scalar *Scalar) error {
+	if scalar == nil {
+		return nilOperand("Pow")
+	}
+
+	s.Pow(scalar)
+
+	return nil
+}
+
+// SetStrict is like Set, but returns an error wrapping ErrNilOperand if scalar is nil.
+func (s *Scalar) SetStrict(scalar *Scalar) error {
+	if scalar == nil {
+		return nilOperand("Set")
+	}
+
+	s.Set(scalar)
+
+	return nil
+}
+
+// AddStrict is like Add, but returns an error wrapping ErrNilOperand if element is nil.
+func (e *Element) AddStrict(element *Element) error {
+	if element == nil {
+		return nilOperand("Add")
+	}
+
+	e.Add(element)
+
+	return nil
+}
+
+// SubtractStrict is like Subtract, but returns an error wrapping ErrNilOperand if element is nil.
+func (e *Element) SubtractStrict(element *Element) error {
+	if element == nil {
+		return nilOperand("Subtract")
+	}
+
+	e.Subtract(element)
+
+	return nil
+}
+
+// MultiplyStrict is like Multiply, but returns an error wrapping ErrNilOperand if scalar is nil.
+func (e *Element) MultiplyStrict(scalar *Scalar) error {
+	if scalar == nil {
+		return nilOperand("Multiply")
+	}
+
+	e.Multiply(scalar)
+
+	return nil
+}
+
+// SetStrict is like Set, but returns an error wrapping ErrNilOperand if element is nil.
+func (e *Element) SetStrict(element *Element) error {
+	if element == nil {
+		return nilOperand("Set")
+	}
+
+	e.Set(element)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/bytemare/hash"
+)
+
+// BuildDST returns a domain separation tag following the format recommended by RFC 9380, section 3.1, e.g.
+// "MYAPP-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_" for BuildDST("MYAPP", 1, 2, H2CSECP256K1). The application
+// name should identify the protocol, and the version and ciphersuite numbers allow updating it without reusing tags.
+// The result may be longer than 255 bytes, in which case it must go through Suite.ReduceDST before being used with
+// other implementations that don't handle oversize DSTs.
+func BuildDST(application string, version, ciphersuite uint8, suiteID string) []byte {
+	return fmt.Appendf(nil, "%s-V%02d-CS%02d-with-%s", application, version, ciphersuite, suiteID)
+}
+
+// Suite ties a hash-to-curve or encode-to-curve suite identifier to the function implementing it.
+type Suite struct {
+	// Map maps the input to an Element with the suite, e.g. HashToGroup for H2CSECP256K1.
+	Map func(input, dst []byte) *Element
+
+	// ID is the suite identifier, e.g. H2CSECP256K1.
+	ID string
+
+	// RandomOracle is true for the uniform hash-to-curve suites, and false for the encode-to-curve suites.
+	RandomOracle bool
+
+	xmd crypto.Hash // zero for the SHAKE256 suites.
+}
+
+// ReduceDST returns dst if it is at most 255 bytes long, and otherwise the short DST derived from it with the hash
+// function of the suite, as specified in RFC 9380, section 5.3.3. The suite functions already apply this reduction,
+// so this is only needed to interoperate with implementations that don't.
+func (s Suite) ReduceDST(dst []byte) []byte {
+	if len(dst) <= dstMaxLength {
+		return dst
+	}
+
+	if s.xmd == 0 {
+		// As in hash2curve, k is the security level of SHAKE256, making the reduced DST ceil(2 * k / 8) = 64 bytes long.
+		return hash.SHAKE256.GetXOF().Hash(uint(2*hash.SHAKE256.SecurityLevel()/8), []byte(dstLongPrefix), dst)
+	}
+
+	h := s.xmd.New()
+	_, _ = h.Write([]byte(dstLongPrefix))
+	_, _ = h.Write(dst)
+
+	return h.Sum(nil)
+}
+
+// suiteHashes are the hash functions considered for expand_message_xmd suites, which are registered only if they are
+// linked into the binary.
+var suiteHashes = []crypto.Hash{
+	crypto.SHA256, crypto.SHA384, crypto.SHA512, crypto.SHA512_256,
+	crypto.SHA3_256, crypto.SHA3_384, crypto.SHA3_512,
+	crypto.BLAKE2s_256, crypto.BLAKE2b_256, crypto.BLAKE2b_384, crypto.BLAKE2b_512,
+}
+
+// Suites returns the hash-to-curve and encode-to-curve suites supported by the package. The expand_message_xmd suites
+// are only listed for the hash functions linked into the binary, SHA-256 always being available.
+func Suites() []Suite {
+	suites := []Suite{
+		{ID: H2CSECP256K1XOF, Map: hashToCurveXOF, RandomOracle: true},
+		{ID: E2CSECP256K1XOF, Map: encodeToCurveXOF},
+	}
+
+	for _, id := range suiteHashes {
+		if !id.Available() {
+			continue
+		}
+
+		suites = append(suites,
+			Suite{
+				ID:           H2CSuiteXMD(id),
+				Map:          func(input, dst []byte) *Element { return hashToCurveXMD(id, input, dst) },
+				RandomOracle: true,
+				xmd:          id,
+			},
+			Suite{
+				ID:  E2CSuiteXMD(id),
+				Map: func(input, dst []byte) *Element { return encodeToCurveXMD(id, input, dst) },
+				xmd: id,
+			},
+		)
+	}
+
+	return suites
+}
+
+// LookupSuite returns the suite with the given identifier, e.g. H2CSECP256K1 or "secp256k1_XMD:SHA-512_SSWU_NU_", or
+// an error wrapping ErrUnknownSuite if it is not supported or its hash function is not linked into the binary.
+func LookupSuite(id string) (Suite, error) {
+	for _, s := range Suites() {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+
+	return Suite{}, fmt.Errorf("%w: %q", ErrUnknownSuite, id)
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+func TestBackend(t *testing.T) {
+	expected := "generic"
+
+	switch {
+	case purego:
+	case runtime.GOARCH == "amd64" || runtime.GOARCH == "arm64":
+		expected = "limbs64"
+	case runtime.GOARCH == "386" || runtime.GOARCH == "arm" || runtime.GOARCH == "mips" ||
+		runtime.GOARCH == "mipsle" || runtime.GOARCH == "wasm":
+		expected = "limbs32"
+	}
+
+	if secp256k1.Backend() != expected {
+		t.Fatalf("expected backend %q, got %q", expected, secp256k1.Backend())
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+func bitcoinTestKey(t *testing.T) *secp256k1.PrivateKey {
+	t.Helper()
+
+	one := make([]byte, 32)
+	one[31] = 1
+
+	k, err := secp256k1.NewPrivateKey(one)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return k
+}
+
+func TestBitcoinAddresses(t *testing.T) {
+	pub := bitcoinTestKey(t).PublicKey().Element()
+
+	tests := []struct {
+		name, expected, got string
+	}{
+		{
+			"P2PKH", "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+			ecdsa.BitcoinP2PKHAddress(pub, true, ecdsa.BitcoinMainnet),
+		},
+		{
+			"P2PKH uncompressed", "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm",
+			ecdsa.BitcoinP2PKHAddress(pub, false, ecdsa.BitcoinMainnet),
+		},
+		{
+			"P2WPKH", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+			ecdsa.BitcoinP2WPKHAddress(pub, ecdsa.BitcoinMainnet),
+		},
+		{
+			"P2WPKH testnet", "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+			ecdsa.BitcoinP2WPKHAddress(pub, ecdsa.BitcoinTestnet),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.got != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, test.got)
+			}
+		})
+	}
+
+	if ecdsa.BitcoinP2PKHAddress(secp256k1.NewElement(), true, ecdsa.BitcoinMainnet) != "" ||
+		ecdsa.BitcoinP2WPKHAddress(secp256k1.NewElement(), ecdsa.BitcoinMainnet) != "" {
+		t.Fatal("expected no address for the identity")
+	}
+}
+
+func TestBitcoinMessage_Verify(t *testing.T) {
+	k := newTestKey(t)
+	pub := k.PublicKey().Element()
+	message := []byte("Hello, Bitcoin!")
+	p2pkh := ecdsa.BitcoinP2PKHAddress(pub, true, ecdsa.BitcoinMainnet)
+	p2wpkh := ecdsa.BitcoinP2WPKHAddress(pub, ecdsa.BitcoinMainnet)
+	uncompressed := ecdsa.BitcoinP2PKHAddress(pub, false, ecdsa.BitcoinMainnet)
+
+	for _, addressType := range []ecdsa.BitcoinAddressType{ecdsa.BitcoinP2PKH, ecdsa.BitcoinP2WPKH} {
+		sig, err := ecdsa.SignBitcoinMessage(k, message, addressType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recovered, recoveredType, err := ecdsa.RecoverBitcoinMessage(sig, message)
+		if err != nil || recoveredType != addressType || !recovered.Equal(k.PublicKey()) {
+			t.Fatalf("unexpected recovery for address type %d", addressType)
+		}
+
+		// Compressed keys are accepted for both P2PKH and P2WPKH addresses.
+		if !ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, p2pkh, sig, message) ||
+			!ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, p2wpkh, sig, message) {
+			t.Fatalf("expected valid signature for address type %d", addressType)
+		}
+
+		if ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, uncompressed, sig, message) {
+			t.Fatal("expected the uncompressed address to be rejected")
+		}
+
+		if ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinTestnet, p2wpkh, sig, message) {
+			t.Fatal("expected the wrong network to be rejected")
+		}
+
+		if ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, p2pkh, sig, []byte("Hello, Bitcoin?")) {
+			t.Fatal("expected the wrong message to be rejected")
+		}
+	}
+
+	sig, err := ecdsa.SignBitcoinMessage(k, message, ecdsa.BitcoinP2PKH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, _ := base64.StdEncoding.DecodeString(sig)
+
+	for _, header := range []byte{0, 26, 35, 43} {
+		decoded[0] = header
+		if ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, p2pkh, base64.StdEncoding.EncodeToString(decoded), message) {
+			t.Fatalf("expected header %d to be rejected", header)
+		}
+	}
+
+	if ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, p2pkh, "not base64!", message) ||
+		ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, p2pkh, sig[:40], message) {
+		t.Fatal("expected invalid encodings to be rejected")
+	}
+
+	if _, err = ecdsa.SignBitcoinMessage(k, message, 35); err == nil {
+		t.Fatal("expected an error for an unsupported address type")
+	}
+}
+
+func TestBitcoinMessageHash_LongMessage(t *testing.T) {
+	// Messages of 253 bytes and more have a multi-byte length prefix.
+	message := bytes.Repeat([]byte{'a'}, 300)
+
+	sig, err := ecdsa.SignBitcoinMessage(bitcoinTestKey(t), message, ecdsa.BitcoinP2PKH)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := ecdsa.BitcoinP2PKHAddress(bitcoinTestKey(t).PublicKey().Element(), true, ecdsa.BitcoinMainnet)
+	if !ecdsa.VerifyBitcoinMessage(ecdsa.BitcoinMainnet, address, sig, message) {
+		t.Fatal("expected valid signature")
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/blindschnorr"
+)
+
+func TestBlindSchnorr(t *testing.T) {
+	msg := []byte("message")
+
+	// Both parities of the signer's public key.
+	for range 8 {
+		k := newTestKey(t)
+		pub := k.PublicKey().Element().EncodeXOnly()
+
+		nonce, r, err := blindschnorr.Commit(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		blinding, c, err := blindschnorr.Blind(k.PublicKey(), r, msg, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := nonce.Sign(k, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = nonce.Sign(k, c); !errors.Is(err, blindschnorr.ErrNonceUsed) {
+			t.Fatalf("expected %v, got %v", blindschnorr.ErrNonceUsed, err)
+		}
+
+		sig, err := blinding.Unblind(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !blindschnorr.Verify(pub, msg, sig) || !verifyBIP340(t, pub, msg, sig) {
+			t.Fatal("expected valid signature")
+		}
+
+		// The signature does not reveal the signer's nonce point or the blinded challenge.
+		rx := r.EncodeXOnly()
+		if bytes.Equal(sig[:32], rx[:]) || bytes.Equal(sig[32:], s.Encode()) {
+			t.Fatal("expected a blinded signature")
+		}
+
+		if blindschnorr.Verify(pub, []byte("other"), sig) {
+			t.Fatal("expected invalid signature")
+		}
+
+		tampered := sig
+		tampered[63] ^= 1
+
+		if blindschnorr.Verify(pub, msg, tampered) {
+			t.Fatal("expected invalid signature")
+		}
+
+		// A wrong blind signature is detected before unblinding.
+		if _, err = blinding.Unblind(s.Add(secp256k1.NewScalar().One())); !errors.Is(
+			err, blindschnorr.ErrInvalidSignature) {
+			t.Fatalf("expected %v, got %v", blindschnorr.ErrInvalidSignature, err)
+		}
+	}
+}
+
+func TestBlindSchnorr_Errors(t *testing.T) {
+	k := newTestKey(t)
+
+	if _, _, err := blindschnorr.Commit(bytes.NewReader(nil)); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+
+	if _, _, err := blindschnorr.Commit(nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	nonce, r, err := blindschnorr.Commit(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		pub   *secp256k1.PublicKey
+		nonce *secp256k1.Element
+	}{
+		{nonce: r},
+		{pub: k.PublicKey()},
+		{pub: k.PublicKey(), nonce: secp256k1.NewElement()},
+	} {
+		if _, _, err = blindschnorr.Blind(test.pub, test.nonce, nil, rand.Reader); !errors.Is(
+			err, blindschnorr.ErrInvalidSignature) {
+			t.Fatalf("expected %v, got %v", blindschnorr.ErrInvalidSignature, err)
+		}
+	}
+
+	if _, err = nonce.Sign(k, nil); !errors.Is(err, blindschnorr.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", blindschnorr.ErrInvalidSignature, err)
+	}
+
+	if _, _, err = blindschnorr.Blind(k.PublicKey(), r, nil, nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	// A signature by another key does not unblind.
+	blinding, c, err := blindschnorr.Blind(k.PublicKey(), r, nil, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := nonce.Sign(newTestKey(t), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = blinding.Unblind(s); !errors.Is(err, blindschnorr.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", blindschnorr.ErrInvalidSignature, err)
+	}
+
+	var pub [32]byte
+	if blindschnorr.Verify(pub, nil, [64]byte{}) {
+		t.Fatal("expected invalid signature")
+	}
+}
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// Generated with OpenSSL: "openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:secp256k1", converted with
+// "openssl pkcs8 -topk8 -nocrypt" and "openssl pkey -pubout".
+const (
+	opensslSecret = "aa2f4f8fe961cf6b3f459cb09c0aee69d8cd6a5b84843c68fef2098f824a500a"
+	opensslPKCS8  = "308184020100301006072a8648ce3d020106052b8104000a046d306b0201010420aa2f4f8fe961cf6b3f459cb09c0aee69" +
+		"d8cd6a5b84843c68fef2098f824a500aa14403420004c03b801f18d1f7c90ff61d17b6e96fe48fe375dbcc125e995c9e6236891d8866" +
+		"0dda4a440c3aec07233d465c7a00e7ac255cd66f28f354e93e4306801ba21b54"
+	opensslPKIX = "3056301006072a8648ce3d020106052b8104000a03420004c03b801f18d1f7c90ff61d17b6e96fe48fe375dbcc125e995c9e6" +
+		"236891d88660dda4a440c3aec07233d465c7a00e7ac255cd66f28f354e93e4306801ba21b54"
+
+	// A P-256 public key, and a secp256k1 public key with an RSA algorithm identifier.
+	p256PKIX = "3059301306072a8648ce3d020106082a8648ce3d030107034200046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0" +
+		"f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5"
+	rsaPKIX = "3053300d06092a864886f70d010101050003420004c03b801f18d1f7c90ff61d17b6e96fe48fe375dbcc125e995c9e6236891d8" +
+		"8660dda4a440c3aec07233d465c7a00e7ac255cd66f28f354e93e4306801ba21b54"
+)
+
+func mustDecodeHex(t *testing.T, h string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestPKIX_OpenSSL(t *testing.T) {
+	k, err := secp256k1.NewPrivateKey(mustDecodeHex(t, opensslSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := secp256k1.ParsePKIXPublicKey(mustDecodeHex(t, opensslPKIX))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pub.Equal(k.PublicKey()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	der, err := secp256k1.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hex.EncodeToString(der) != opensslPKIX {
+		t.Fatalf("unexpected encoding %x", der)
+	}
+
+	priv, err := secp256k1.ParsePKCS8PrivateKey(mustDecodeHex(t, opensslPKCS8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !priv.Equal(k) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if der, err = secp256k1.MarshalPKCS8PrivateKey(priv); err != nil {
+		t.Fatal(err)
+	}
+
+	if hex.EncodeToString(der) != opensslPKCS8 {
+		t.Fatalf("unexpected encoding %x", der)
+	}
+}
+
+func TestPKIX_RoundTrip(t *testing.T) {
+	k, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := secp256k1.MarshalPKCS8PrivateKey(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d, err := secp256k1.ParsePKCS8PrivateKey(der); err != nil || !d.Equal(k) {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+
+	if der, err = secp256k1.MarshalPKIXPublicKey(k.PublicKey()); err != nil {
+		t.Fatal(err)
+	}
+
+	if p, err := secp256k1.ParsePKIXPublicKey(der); err != nil || !p.Equal(k.PublicKey()) {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+}
+
+func TestPKIX_Invalid(t *testing.T) {
+	pkix := mustDecodeHex(t, opensslPKIX)
+	pkcs8 := mustDecodeHex(t, opensslPKCS8)
+
+	// A compressed point is accepted.
+	compressed := append([]byte{0x30, 0x36}, pkix[2:20]...)
+	compressed = append(compressed, 0x03, 0x22, 0x00)
+	compressed = append(compressed, secp256k1.Base().Encode()...)
+
+	if p, err := secp256k1.ParsePKIXPublicKey(compressed); err != nil || p.Element().Equal(secp256k1.Base()) != 1 {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+
+	notOnCurve := bytes.Clone(pkix)
+	notOnCurve[len(notOnCurve)-1] ^= 1
+
+	// A bit string whose length is not a multiple of 8, with the unused bits count right after its header.
+	unaligned := bytes.Clone(pkix)
+	unaligned[22] = 2
+
+	for _, test := range []struct {
+		err error
+		der []byte
+	}{
+		{secp256k1.ErrInvalidDER, nil},
+		{secp256k1.ErrInvalidDER, pkix[:len(pkix)-1]},
+		{secp256k1.ErrInvalidDER, append(bytes.Clone(pkix), 0)},
+		{secp256k1.ErrInvalidDER, pkcs8},
+		{secp256k1.ErrUnsupportedCurve, mustDecodeHex(t, p256PKIX)},
+		{secp256k1.ErrUnsupportedCurve, mustDecodeHex(t, rsaPKIX)},
+		{secp256k1.ErrInvalidDER, unaligned},
+		{secp256k1.ErrInvalidPointEncoding, notOnCurve},
+	} {
+		if _, err := secp256k1.ParsePKIXPublicKey(test.der); !errors.Is(err, test.err) {
+			t.Fatalf("expected %v, got %v", test.err, err)
+		}
+	}
+
+	// A zero secret scalar, in the ECPrivateKey structure at offset 33.
+	zero := bytes.Clone(pkcs8)
+	clear(zero[33 : 33+32])
+
+	// A wrong version of the PKCS #8 structure.
+	version := bytes.Clone(pkcs8)
+	version[5] = 1
+
+	// The embedded public key, in the ECPrivateKey structure at offset 70 and with its unused bits count at offset 69:
+	// another key, a point not on the curve, and a bit string that is not octet-aligned.
+	other := bytes.Clone(pkcs8)
+	copy(other[70:], secp256k1.Base().EncodeUncompressed())
+
+	embeddedNotOnCurve := bytes.Clone(pkcs8)
+	embeddedNotOnCurve[len(embeddedNotOnCurve)-1] ^= 1
+
+	embeddedUnaligned := bytes.Clone(pkcs8)
+	embeddedUnaligned[69] = 2
+
+	for _, test := range []struct {
+		err error
+		der []byte
+	}{
+		{secp256k1.ErrInvalidDER, nil},
+		{secp256k1.ErrInvalidDER, pkcs8[:len(pkcs8)-1]},
+		{secp256k1.ErrInvalidDER, append(bytes.Clone(pkcs8), 0)},
+		{secp256k1.ErrInvalidDER, pkix},
+		{secp256k1.ErrInvalidDER, version},
+		{secp256k1.ErrInvalidPrivateKey, zero},
+		{secp256k1.ErrInvalidDER, other},
+		{secp256k1.ErrInvalidPointEncoding, embeddedNotOnCurve},
+		{secp256k1.ErrInvalidDER, embeddedUnaligned},
+	} {
+		if _, err := secp256k1.ParsePKCS8PrivateKey(test.der); !errors.Is(err, test.err) {
+			t.Fatalf("expected %v, got %v", test.err, err)
+		}
+	}
+}
+
+func TestPKIX_Nil(t *testing.T) {
+	if _, err := secp256k1.MarshalPKIXPublicKey(nil); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPointEncoding, err)
+	}
+
+	if _, err := secp256k1.MarshalPKCS8PrivateKey(nil); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+
+	if _, err := secp256k1.MarshalECPrivateKey(nil); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+func TestECDH(t *testing.T) {
+	alice, bob := newTestKey(t), newTestKey(t)
+
+	a, err := alice.ECDH(bob.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := bob.ECDH(alice.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(a, b) || len(a) != 32 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// libsecp256k1's default hashes the compressed shared point, and the raw variant returns its x coordinate.
+	p, err := alice.SharedPoint(bob.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := sha256.Sum256(p.Encode())
+	if !bytes.Equal(a, expected[:]) {
+		t.Fatal("unexpected shared secret")
+	}
+
+	raw, err := alice.ECDHRawX(bob.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(raw, p.XCoordinate()) {
+		t.Fatal("unexpected raw shared secret")
+	}
+
+	if p.Equal(bob.PublicKey().Element().Multiply(alice.Scalar())) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestECDH_Generator(t *testing.T) {
+	// With the private key 1 and the generator as public key, the secret is the hash of the compressed generator.
+	k := bitcoinTestKey(t)
+	expected := sha256.Sum256(secp256k1.Base().Encode())
+
+	secret, err := k.ECDH(k.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(secret, expected[:]) {
+		t.Fatal("unexpected shared secret")
+	}
+}
+
+func TestECDH_InvalidPublicKey(t *testing.T) {
+	k := newTestKey(t)
+
+	if _, err := k.ECDH(nil); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrIdentity, err)
+	}
+
+	if _, err := k.ECDHRawX(&secp256k1.PublicKey{}); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrIdentity, err)
+	}
+}
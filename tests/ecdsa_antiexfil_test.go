@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+func TestECDSA_AntiExfil(t *testing.T) {
+	k := newTestKey(t)
+	digest := sha256.Sum256([]byte("message"))
+
+	hostData := make([]byte, 32)
+	if _, err := rand.Read(hostData); err != nil {
+		t.Fatal(err)
+	}
+
+	// Protocol run.
+	commitment := ecdsa.AntiExfilHostCommit(hostData)
+
+	opening, err := ecdsa.AntiExfilSignerCommit(k, digest[:], commitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := ecdsa.AntiExfilSign(k, digest[:], hostData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ecdsa.AntiExfilHostVerify(k.PublicKey(), digest[:], sig, hostData, opening) {
+		t.Fatal("expected valid anti-exfil signature")
+	}
+
+	if !ecdsa.Verify(k.PublicKey(), digest[:], sig) || sig.IsHighS() {
+		t.Fatal("expected a regular low-S signature")
+	}
+
+	// The signer's commitment is deterministic.
+	again, err := ecdsa.AntiExfilSignerCommit(k, digest[:], commitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again.Equal(opening) != 1 {
+		t.Fatal("expected the same nonce commitment")
+	}
+
+	otherData := bytes.Repeat([]byte{1}, 32)
+
+	otherSig, err := ecdsa.AntiExfilSign(k, digest[:], otherData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain, err := ecdsa.Sign(k, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		opening  *secp256k1.Element
+		sig      *ecdsa.Signature
+		name     string
+		hostData []byte
+	}{
+		{name: "host data", opening: opening, sig: sig, hostData: otherData},
+		{name: "opening", opening: secp256k1.Base(), sig: sig, hostData: hostData},
+		{name: "identity opening", opening: secp256k1.NewElement(), sig: sig, hostData: hostData},
+		{name: "nil opening", sig: sig, hostData: hostData},
+		{name: "ignored host data", opening: opening, sig: otherSig, hostData: hostData},
+		{name: "plain signature", opening: opening, sig: plain, hostData: hostData},
+	} {
+		if ecdsa.AntiExfilHostVerify(k.PublicKey(), digest[:], test.sig, test.hostData, test.opening) {
+			t.Fatalf("%s: expected invalid anti-exfil signature", test.name)
+		}
+	}
+
+	if ecdsa.AntiExfilHostVerify(newTestKey(t).PublicKey(), digest[:], sig, hostData, opening) {
+		t.Fatal("expected invalid signature for another key")
+	}
+
+	if _, err = ecdsa.AntiExfilSign(k, digest[:], hostData[:31]); !errors.Is(err, ecdsa.ErrInvalidHostData) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidHostData, err)
+	}
+
+	if _, err = ecdsa.AntiExfilSignerCommit(nil, digest[:], commitment); !errors.Is(err, ecdsa.ErrNilKey) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrNilKey, err)
+	}
+
+	if _, err = ecdsa.AntiExfilSign(nil, digest[:], hostData); !errors.Is(err, ecdsa.ErrNilKey) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrNilKey, err)
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+func ecdsaBatch(t *testing.T, n int) []ecdsa.BatchEntry {
+	t.Helper()
+
+	entries := make([]ecdsa.BatchEntry, n)
+
+	for i := range entries {
+		k := newTestKey(t)
+		digest := sha256.Sum256([]byte(fmt.Sprintf("message %d", i)))
+
+		sig, recoveryID, err := ecdsa.SignRecoverable(k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entries[i] = ecdsa.BatchEntry{
+			PublicKey:  k.PublicKey(),
+			Signature:  sig,
+			Digest:     digest[:],
+			RecoveryID: recoveryID,
+		}
+	}
+
+	return entries
+}
+
+func TestECDSA_SignRecoverable(t *testing.T) {
+	for _, entry := range ecdsaBatch(t, 32) {
+		if entry.RecoveryID > 1 {
+			t.Fatalf("unexpected recovery ID %d", entry.RecoveryID)
+		}
+
+		if !ecdsa.Verify(entry.PublicKey, entry.Digest, entry.Signature) {
+			t.Fatal("expected valid signature")
+		}
+
+		// Only the nonce point with the right parity verifies.
+		if !ecdsa.VerifyBatch([]ecdsa.BatchEntry{entry}) {
+			t.Fatal("expected valid batch")
+		}
+
+		entry.RecoveryID ^= 1
+		if ecdsa.VerifyBatch([]ecdsa.BatchEntry{entry}) {
+			t.Fatal("expected invalid batch with the wrong recovery ID")
+		}
+	}
+}
+
+func TestECDSA_VerifyBatch(t *testing.T) {
+	if !ecdsa.VerifyBatch(nil) {
+		t.Fatal("expected an empty batch to be valid")
+	}
+
+	for _, n := range []int{1, 2, 16} {
+		entries := ecdsaBatch(t, n)
+		if !ecdsa.VerifyBatch(entries) {
+			t.Fatalf("expected valid batch of %d signatures", n)
+		}
+
+		last := n - 1
+		valid := entries[last]
+
+		for _, invalid := range []ecdsa.BatchEntry{
+			{PublicKey: valid.PublicKey, Signature: valid.Signature, Digest: []byte("other"), RecoveryID: valid.RecoveryID},
+			{PublicKey: newTestKey(t).PublicKey(), Signature: valid.Signature, Digest: valid.Digest},
+			{PublicKey: valid.PublicKey, Digest: valid.Digest, RecoveryID: valid.RecoveryID},
+			{Signature: valid.Signature, Digest: valid.Digest, RecoveryID: valid.RecoveryID},
+			{PublicKey: valid.PublicKey, Signature: valid.Signature, Digest: valid.Digest, RecoveryID: 4},
+			{PublicKey: valid.PublicKey, Signature: valid.Signature, Digest: valid.Digest, RecoveryID: valid.RecoveryID | 2},
+			{
+				PublicKey:  valid.PublicKey,
+				Signature:  &ecdsa.Signature{R: valid.Signature.R, S: secp256k1.NewScalar()},
+				Digest:     valid.Digest,
+				RecoveryID: valid.RecoveryID,
+			},
+			{
+				PublicKey:  valid.PublicKey,
+				Signature:  &ecdsa.Signature{R: valid.Signature.R, S: valid.Signature.S.Copy().Add(secp256k1.NewScalar().One())},
+				Digest:     valid.Digest,
+				RecoveryID: valid.RecoveryID,
+			},
+		} {
+			entries[last] = invalid
+			if ecdsa.VerifyBatch(entries) {
+				t.Fatalf("expected invalid batch of %d signatures", n)
+			}
+		}
+
+		// Swapping the signatures of two entries is detected.
+		if n > 1 {
+			entries[last] = valid
+			entries[0].Signature, entries[1].Signature = entries[1].Signature, entries[0].Signature
+			entries[0].RecoveryID, entries[1].RecoveryID = entries[1].RecoveryID, entries[0].RecoveryID
+
+			if ecdsa.VerifyBatch(entries) {
+				t.Fatal("expected invalid batch")
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+func TestECDSA_DER_Vector(t *testing.T) {
+	v := ecdsaVectors[0]
+	expected := "3045022100" + v.r + "0220" + v.s
+
+	r, s := secp256k1.NewScalar(), secp256k1.NewScalar()
+	if err := r.DecodeHex(v.r); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DecodeHex(v.s); err != nil {
+		t.Fatal(err)
+	}
+
+	sig := &ecdsa.Signature{R: r, S: s}
+	if !bytes.Equal(sig.EncodeDER(), mustDecodeHex(t, expected)) {
+		t.Fatalf("unexpected encoding %x", sig.EncodeDER())
+	}
+
+	// Small values are encoded on fewer bytes.
+	sig = &ecdsa.Signature{R: secp256k1.NewScalar().SetUInt64(1), S: secp256k1.NewScalar().SetUInt64(0x80)}
+	if !bytes.Equal(sig.EncodeDER(), mustDecodeHex(t, "3007020101020200"+"80")) {
+		t.Fatalf("unexpected encoding %x", sig.EncodeDER())
+	}
+
+	dec := new(ecdsa.Signature)
+	if err := dec.DecodeDER(sig.EncodeDER()); err != nil {
+		t.Fatal(err)
+	}
+
+	if dec.R.Equal(sig.R) != 1 || dec.S.Equal(sig.S) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestECDSA_DER_Invalid(t *testing.T) {
+	order := hex.EncodeToString(secp256k1.Order())
+
+	for _, test := range []struct {
+		name, encoding string
+	}{
+		{name: "empty", encoding: ""},
+		{name: "too short", encoding: "30050201010201"},
+		{name: "too long", encoding: "3046022100" + order + "022100" + order + "0000"},
+		{name: "sequence tag", encoding: "3106020101020101"},
+		{name: "sequence length", encoding: "3007020101020101"},
+		{name: "trailing data", encoding: "300602010102010100"},
+		{name: "r tag", encoding: "3006030101020101"},
+		{name: "r length", encoding: "3006020401020101"},
+		{name: "s tag", encoding: "3006020101030101"},
+		{name: "s length", encoding: "3006020101020201"},
+		{name: "empty r", encoding: "30060200020201" + "01"},
+		{name: "empty s", encoding: "3006020201010200"},
+		{name: "negative r", encoding: "3006020181020101"},
+		{name: "negative s", encoding: "3006020101020181"},
+		{name: "padded r", encoding: "300702020001020101"},
+		{name: "padded s", encoding: "300702010102020001"},
+		{name: "zero r", encoding: "3006020100020101"},
+		{name: "zero s", encoding: "3006020101020100"},
+		{name: "r equals n", encoding: "3026022100" + order + "020101"},
+		{name: "s too large", encoding: "302702010102220100" + order},
+	} {
+		sig := new(ecdsa.Signature)
+		if err := sig.DecodeDER(mustDecodeHex(t, test.encoding)); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+			t.Fatalf("%s: expected %v, got %v", test.name, ecdsa.ErrInvalidSignature, err)
+		}
+
+		if sig.R != nil || sig.S != nil {
+			t.Fatalf("%s: receiver modified on error", test.name)
+		}
+	}
+}
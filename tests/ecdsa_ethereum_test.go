@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+
+	return h.Sum(nil)
+}
+
+func TestECDSA_Ethereum_Vector(t *testing.T) {
+	// From go-ethereum's crypto tests.
+	const (
+		key     = "289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032"
+		address = "970e8128ab834e8eac17ab8e3812f010678cf791"
+	)
+
+	k, err := secp256k1.NewPrivateKey(mustDecodeHex(t, key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := keccak256([]byte("foo"))
+
+	sig, err := ecdsa.SignEthereum(k, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sig) != ecdsa.EthereumSignatureLength || sig[64] > 1 {
+		t.Fatalf("unexpected signature layout %x", sig)
+	}
+
+	pub, err := ecdsa.Ecrecover(digest, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(pub, k.PublicKey().BytesUncompressed()) {
+		t.Fatal("unexpected recovered public key")
+	}
+
+	recovered, err := ecdsa.RecoverEthereum(digest, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := ecdsa.EthereumAddress(recovered.Element())
+	if hex.EncodeToString(addr[:]) != address {
+		t.Fatalf("unexpected address %x", addr)
+	}
+
+	for _, p := range [][]byte{k.PublicKey().Bytes(), k.PublicKey().BytesUncompressed()} {
+		if !ecdsa.VerifyEthereum(p, digest, sig[:64]) {
+			t.Fatal("expected valid signature")
+		}
+	}
+}
+
+func TestECDSA_RecoverPublicKey(t *testing.T) {
+	for range 16 {
+		k := newTestKey(t)
+		digest := sha256.Sum256(k.Bytes())
+
+		sig, recoveryID, err := ecdsa.SignRecoverable(k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pub, err := ecdsa.RecoverPublicKey(digest[:], sig, recoveryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !pub.Equal(k.PublicKey()) {
+			t.Fatal("unexpected recovered public key")
+		}
+
+		// The other parity recovers another key, and the high-S signature the same key.
+		if pub, err = ecdsa.RecoverPublicKey(digest[:], sig, recoveryID^1); err != nil || pub.Equal(k.PublicKey()) {
+			t.Fatal("expected another public key")
+		}
+
+		high := &ecdsa.Signature{R: sig.R, S: secp256k1.NewScalar().Subtract(sig.S)}
+		if pub, err = ecdsa.RecoverPublicKey(digest[:], high, recoveryID^1); err != nil || !pub.Equal(k.PublicKey()) {
+			t.Fatal("expected the same public key")
+		}
+	}
+}
+
+func TestECDSA_Ethereum_Errors(t *testing.T) {
+	k := newTestKey(t)
+	digest := keccak256([]byte("message"))
+
+	sig, err := ecdsa.SignEthereum(k, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = ecdsa.SignEthereum(k, digest[:31]); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+	}
+
+	for _, test := range []struct {
+		name        string
+		digest, sig []byte
+	}{
+		{name: "digest length", digest: digest[:31], sig: sig},
+		{name: "signature length", digest: digest, sig: sig[:64]},
+		{name: "recovery ID", digest: digest, sig: append(bytes.Clone(sig[:64]), 4)},
+		{name: "zero r", digest: digest, sig: append(make([]byte, 32), sig[32:]...)},
+	} {
+		if _, err = ecdsa.Ecrecover(test.digest, test.sig); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+			t.Fatalf("%s: expected %v, got %v", test.name, ecdsa.ErrInvalidSignature, err)
+		}
+	}
+
+	if _, err = ecdsa.RecoverPublicKey(digest, nil, 0); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+	}
+
+	// High-S signatures are rejected by VerifyEthereum, but still recover the key with the flipped recovery ID.
+	s := new(ecdsa.Signature)
+	if err = s.Decode(sig[:64]); err != nil {
+		t.Fatal(err)
+	}
+
+	high := append((&ecdsa.Signature{R: s.R, S: secp256k1.NewScalar().Subtract(s.S)}).Encode(), sig[64]^1)
+	if ecdsa.VerifyEthereum(k.PublicKey().Bytes(), digest, high[:64]) {
+		t.Fatal("expected high-S signature to be rejected")
+	}
+
+	if pub, err := ecdsa.RecoverEthereum(digest, high); err != nil || !pub.Equal(k.PublicKey()) {
+		t.Fatal("expected the same public key")
+	}
+
+	if ecdsa.VerifyEthereum(k.PublicKey().Bytes(), digest[:31], sig[:64]) ||
+		ecdsa.VerifyEthereum(k.PublicKey().Bytes()[:32], digest, sig[:64]) ||
+		ecdsa.VerifyEthereum(k.PublicKey().Bytes(), digest, sig) {
+		t.Fatal("expected invalid signature")
+	}
+}
+
+func TestECDSA_EthereumV(t *testing.T) {
+	for _, test := range []struct {
+		chainID    *big.Int
+		v          int64
+		recoveryID byte
+	}{
+		{recoveryID: 0, v: 27},
+		{recoveryID: 1, v: 28},
+		{recoveryID: 0, chainID: big.NewInt(1), v: 37},
+		{recoveryID: 1, chainID: big.NewInt(1), v: 38},
+		{recoveryID: 1, chainID: big.NewInt(0), v: 36},
+		{recoveryID: 0, chainID: big.NewInt(11155111), v: 22310257},
+	} {
+		v := ecdsa.EthereumV(test.recoveryID, test.chainID)
+		if v.Int64() != test.v {
+			t.Fatalf("expected v = %d, got %v", test.v, v)
+		}
+
+		recoveryID, chainID, err := ecdsa.EthereumRecoveryID(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if recoveryID != test.recoveryID || (chainID == nil) != (test.chainID == nil) ||
+			chainID != nil && chainID.Cmp(test.chainID) != 0 {
+			t.Fatalf("unexpected decoding of v = %v", v)
+		}
+	}
+
+	// Large chain IDs.
+	chainID := new(big.Int).Lsh(big.NewInt(1), 70)
+
+	recoveryID, decoded, err := ecdsa.EthereumRecoveryID(ecdsa.EthereumV(1, chainID))
+	if err != nil || recoveryID != 1 || decoded.Cmp(chainID) != 0 {
+		t.Fatal("unexpected decoding of a large chain ID")
+	}
+
+	for _, v := range []*big.Int{nil, big.NewInt(-1), big.NewInt(0), big.NewInt(29), big.NewInt(34)} {
+		if _, _, err = ecdsa.EthereumRecoveryID(v); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+			t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+// additiveShares returns n random scalars that sum to s.
+func additiveShares(s *secp256k1.Scalar, n int) []*secp256k1.Scalar {
+	shares := make([]*secp256k1.Scalar, n)
+	last := s.Copy()
+
+	for i := range n - 1 {
+		shares[i] = secp256k1.NewScalar().Random()
+		last.Subtract(shares[i])
+	}
+
+	shares[n-1] = last
+
+	return shares
+}
+
+// presignatureDealer simulates the presigning phase for n signers with a trusted dealer.
+func presignatureDealer(t *testing.T, x *secp256k1.Scalar, n int) (*secp256k1.Element, []*ecdsa.Presignature) {
+	t.Helper()
+
+	k := secp256k1.NewScalar().Random()
+	gammas := make([]*secp256k1.Element, n)
+	gamma := secp256k1.NewScalar()
+
+	for i := range gammas {
+		g := secp256k1.NewScalar().Random()
+		gamma.Add(g)
+		gammas[i] = secp256k1.ScalarBaseMult(g)
+	}
+
+	r, err := ecdsa.ReconstructNoncePoint(gammas, additiveShares(k.Copy().Multiply(gamma), n))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Equal(secp256k1.ScalarBaseMult(k.Copy().Invert())) != 1 {
+		t.Fatal("unexpected nonce point")
+	}
+
+	ks, chis := additiveShares(k, n), additiveShares(k.Copy().Multiply(x), n)
+	presignatures := make([]*ecdsa.Presignature, n)
+
+	for i := range presignatures {
+		if presignatures[i], err = ecdsa.NewPresignature(r, ks[i], chis[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return r, presignatures
+}
+
+func TestThresholdECDSA_Presignature(t *testing.T) {
+	key := newTestKey(t)
+	digest := sha256.Sum256([]byte("threshold"))
+
+	for _, n := range []int{1, 2, 5} {
+		r, presignatures := presignatureDealer(t, key.Scalar(), n)
+		commitments := make([]*ecdsa.PresignatureCommitment, n)
+		shares := make([]*secp256k1.Scalar, n)
+
+		for i, p := range presignatures {
+			if p.NoncePoint().Equal(r) != 1 {
+				t.Fatal(errExpectedEquality)
+			}
+
+			commitments[i] = p.Commitment()
+		}
+
+		if !ecdsa.VerifyPresignature(r, key.PublicKey(), commitments) {
+			t.Fatal("expected valid presignature")
+		}
+
+		for i, p := range presignatures {
+			var err error
+			if shares[i], err = p.Sign(digest[:]); err != nil {
+				t.Fatal(err)
+			}
+
+			if !ecdsa.VerifySignatureShare(r, commitments[i], digest[:], shares[i]) {
+				t.Fatalf("expected valid signature share %d", i)
+			}
+		}
+
+		sig, recoveryID, err := ecdsa.CombineSignatureShares(r, key.PublicKey(), digest[:], shares)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa.VerifyWithFlags(key.PublicKey(), digest[:], sig, ecdsa.VerifyLowS) {
+			t.Fatal("expected valid low-S signature")
+		}
+
+		pub, err := ecdsa.RecoverPublicKey(digest[:], sig, recoveryID)
+		if err != nil || !pub.Equal(key.PublicKey()) {
+			t.Fatal("unexpected recovered public key")
+		}
+	}
+}
+
+func TestThresholdECDSA_Errors(t *testing.T) {
+	key := newTestKey(t)
+	digest := sha256.Sum256([]byte("threshold"))
+	r, presignatures := presignatureDealer(t, key.Scalar(), 3)
+	commitments := []*ecdsa.PresignatureCommitment{
+		presignatures[0].Commitment(), presignatures[1].Commitment(), presignatures[2].Commitment(),
+	}
+
+	// Commitments for another key, or missing commitments, are inconsistent.
+	if ecdsa.VerifyPresignature(r, newTestKey(t).PublicKey(), commitments) ||
+		ecdsa.VerifyPresignature(r, key.PublicKey(), commitments[:2]) ||
+		ecdsa.VerifyPresignature(nil, key.PublicKey(), commitments) {
+		t.Fatal("expected inconsistent presignature")
+	}
+
+	shares := make([]*secp256k1.Scalar, 3)
+	for i, p := range presignatures {
+		shares[i], _ = p.Sign(digest[:])
+	}
+
+	// Presignatures can't be reused.
+	if _, err := presignatures[0].Sign(digest[:]); !errors.Is(err, ecdsa.ErrInvalidShare) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+	}
+
+	// A cheating signer is identified.
+	shares[1].Add(secp256k1.NewScalar().One())
+
+	if ecdsa.VerifySignatureShare(r, commitments[1], digest[:], shares[1]) ||
+		!ecdsa.VerifySignatureShare(r, commitments[2], digest[:], shares[2]) {
+		t.Fatal("expected only the cheating signer's share to be invalid")
+	}
+
+	_, _, err := ecdsa.CombineSignatureShares(r, key.PublicKey(), digest[:], shares)
+	if !errors.Is(err, ecdsa.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+	}
+
+	_, _, err = ecdsa.CombineSignatureShares(r, key.PublicKey(), digest[:], []*secp256k1.Scalar{nil})
+	if !errors.Is(err, ecdsa.ErrInvalidShare) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+	}
+
+	// Invalid reconstruction inputs.
+	g := secp256k1.Base()
+	for _, test := range []struct {
+		gammas []*secp256k1.Element
+		deltas []*secp256k1.Scalar
+	}{
+		{nil, nil},
+		{[]*secp256k1.Element{g}, nil},
+		{[]*secp256k1.Element{nil}, []*secp256k1.Scalar{secp256k1.NewScalar().One()}},
+		{[]*secp256k1.Element{g}, []*secp256k1.Scalar{secp256k1.NewScalar()}},
+		{[]*secp256k1.Element{g, g.Copy().Negate()}, additiveShares(secp256k1.NewScalar().One(), 2)},
+	} {
+		if _, err := ecdsa.ReconstructNoncePoint(test.gammas, test.deltas); !errors.Is(err, ecdsa.ErrInvalidShare) {
+			t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+		}
+	}
+
+	_, err = ecdsa.NewPresignature(secp256k1.NewElement(), shares[0], shares[0])
+	if !errors.Is(err, ecdsa.ErrInvalidShare) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+	}
+}
@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+// Deterministic RFC 6979 signatures with SHA-256 digests, normalized to low-S.
+var ecdsaVectors = []struct {
+	key, message, r, s string
+}{
+	{
+		key:     "0000000000000000000000000000000000000000000000000000000000000001",
+		message: "Satoshi Nakamoto",
+		r:       "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+		s:       "2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+	},
+	{
+		key:     "0000000000000000000000000000000000000000000000000000000000000001",
+		message: "All those moments will be lost in time, like tears in rain. Time to die...",
+		r:       "8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b",
+		s:       "547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+	},
+}
+
+func newTestKey(t *testing.T) *secp256k1.PrivateKey {
+	t.Helper()
+
+	k, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return k
+}
+
+func TestECDSA_Vectors(t *testing.T) {
+	for _, v := range ecdsaVectors {
+		k, err := secp256k1.NewPrivateKey(mustDecodeHex(t, v.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		digest := sha256.Sum256([]byte(v.message))
+
+		sig, err := ecdsa.Sign(k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sig.R.Hex() != v.r || sig.S.Hex() != v.s {
+			t.Fatalf("unexpected signature for %q: %s %s", v.message, sig.R.Hex(), sig.S.Hex())
+		}
+
+		if !ecdsa.Verify(k.PublicKey(), digest[:], sig) {
+			t.Fatal("expected valid signature")
+		}
+	}
+}
+
+func TestECDSA_Verify_Invalid(t *testing.T) {
+	k := newTestKey(t)
+	digest := sha256.Sum256([]byte("message"))
+
+	sig, err := ecdsa.Sign(k, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signing is deterministic.
+	sig2, _ := ecdsa.Sign(k, digest[:])
+	if !bytes.Equal(sig.Encode(), sig2.Encode()) {
+		t.Fatal("expected deterministic signatures")
+	}
+
+	other := sha256.Sum256([]byte("other"))
+	zero := secp256k1.NewScalar()
+
+	for _, test := range []struct {
+		pub    *secp256k1.PublicKey
+		sig    *ecdsa.Signature
+		name   string
+		digest []byte
+	}{
+		{name: "digest", pub: k.PublicKey(), digest: other[:], sig: sig},
+		{name: "key", pub: newTestKey(t).PublicKey(), digest: digest[:], sig: sig},
+		{name: "swapped", pub: k.PublicKey(), digest: digest[:], sig: &ecdsa.Signature{R: sig.S, S: sig.R}},
+		{name: "zero r", pub: k.PublicKey(), digest: digest[:], sig: &ecdsa.Signature{R: zero, S: sig.S}},
+		{name: "zero s", pub: k.PublicKey(), digest: digest[:], sig: &ecdsa.Signature{R: sig.R, S: zero}},
+		{name: "nil r", pub: k.PublicKey(), digest: digest[:], sig: &ecdsa.Signature{S: sig.S}},
+		{name: "nil signature", pub: k.PublicKey(), digest: digest[:]},
+		{name: "nil key", digest: digest[:], sig: sig},
+	} {
+		if ecdsa.Verify(test.pub, test.digest, test.sig) {
+			t.Fatalf("%s: expected invalid signature", test.name)
+		}
+	}
+
+	if _, err = ecdsa.Sign(nil, digest[:]); !errors.Is(err, ecdsa.ErrNilKey) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrNilKey, err)
+	}
+}
+
+func TestECDSA_Encoding(t *testing.T) {
+	k := newTestKey(t)
+	digest := sha256.Sum256([]byte("message"))
+
+	sig, err := ecdsa.Sign(k, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc := sig.Encode()
+	dec := new(ecdsa.Signature)
+
+	if err = dec.Decode(enc); err != nil {
+		t.Fatal(err)
+	}
+
+	if dec.R.Equal(sig.R) != 1 || dec.S.Equal(sig.S) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	zeroR := bytes.Clone(enc)
+	clear(zeroR[:32])
+
+	highS := bytes.Clone(enc)
+	copy(highS[32:], secp256k1.Order())
+
+	for _, in := range [][]byte{nil, enc[:63], append(bytes.Clone(enc), 0), zeroR, highS} {
+		if err = dec.Decode(in); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+			t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+		}
+	}
+}
+
+func TestECDSA_SignWithEntropy(t *testing.T) {
+	k := newTestKey(t)
+	digest := sha256.Sum256([]byte("message"))
+
+	sig, err := ecdsa.Sign(k, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Empty additional data yields the plain RFC 6979 signature.
+	for _, extra := range [][]byte{nil, {}} {
+		s, err := ecdsa.SignWithEntropy(k, digest[:], extra)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(s.Encode(), sig.Encode()) {
+			t.Fatal("expected the deterministic signature")
+		}
+	}
+
+	extra := bytes.Repeat([]byte{1}, 32)
+
+	s1, err := ecdsa.SignWithEntropy(k, digest[:], extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s2, err := ecdsa.SignWithEntropy(k, digest[:], extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(s1.Encode(), s2.Encode()) {
+		t.Fatal("expected the same signature for the same additional data")
+	}
+
+	if bytes.Equal(s1.Encode(), sig.Encode()) {
+		t.Fatal("expected additional data to change the nonce")
+	}
+
+	// The nonce matches RFC 6979 with additional data.
+	k1, err := secp256k1.DeriveScalarRFC6979(k.Bytes(), digest[:], extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := secp256k1.ScalarBaseMult(k1).EncodeXOnly()
+	if s1.R.Equal(secp256k1.NewScalar().SetBytesMod(x[:])) != 1 {
+		t.Fatal("unexpected nonce")
+	}
+
+	if !ecdsa.Verify(k.PublicKey(), digest[:], s1) {
+		t.Fatal("expected valid signature")
+	}
+}
+
+func TestECDSA_SignHedged(t *testing.T) {
+	k := newTestKey(t)
+	digest := sha256.Sum256([]byte("message"))
+
+	s1, err := ecdsa.SignHedged(k, digest[:], rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s2, err := ecdsa.SignHedged(k, digest[:], rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s1.R.Equal(s2.R) == 1 {
+		t.Fatal("expected different nonces")
+	}
+
+	if !ecdsa.Verify(k.PublicKey(), digest[:], s1) || !ecdsa.Verify(k.PublicKey(), digest[:], s2) {
+		t.Fatal("expected valid signatures")
+	}
+
+	// The randomness only acts as additional data.
+	extra := bytes.Repeat([]byte{7}, 32)
+
+	s3, err := ecdsa.SignHedged(k, digest[:], bytes.NewReader(extra))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s4, _ := ecdsa.SignWithEntropy(k, digest[:], extra)
+	if !bytes.Equal(s3.Encode(), s4.Encode()) {
+		t.Fatal("expected the same signature")
+	}
+
+	if _, err = ecdsa.SignHedged(k, digest[:], nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	if _, err = ecdsa.SignHedged(k, digest[:], bytes.NewReader(extra[:31])); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+
+	if _, err = ecdsa.SignHedged(nil, digest[:], rand.Reader); !errors.Is(err, ecdsa.ErrNilKey) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrNilKey, err)
+	}
+}
+
+func TestECDSA_LowS(t *testing.T) {
+	digest := sha256.Sum256([]byte("message"))
+
+	for range 16 {
+		k := newTestKey(t)
+
+		sig, err := ecdsa.Sign(k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sig.IsHighS() {
+			t.Fatal("expected a low-S signature")
+		}
+
+		if !ecdsa.VerifyWithFlags(k.PublicKey(), digest[:], sig, ecdsa.VerifyLowS) {
+			t.Fatal("expected valid signature")
+		}
+
+		// The malleated signature is valid under SEC 1, but not with the low-S policy.
+		high := &ecdsa.Signature{R: sig.R.Copy(), S: secp256k1.NewScalar().Subtract(sig.S)}
+		if !high.IsHighS() {
+			t.Fatal("expected a high-S signature")
+		}
+
+		if !ecdsa.Verify(k.PublicKey(), digest[:], high) ||
+			!ecdsa.VerifyWithFlags(k.PublicKey(), digest[:], high, 0) {
+			t.Fatal("expected valid signature")
+		}
+
+		if ecdsa.VerifyWithFlags(k.PublicKey(), digest[:], high, ecdsa.VerifyLowS) {
+			t.Fatal("expected high-S signature to be rejected")
+		}
+
+		if high.Normalize().S.Equal(sig.S) != 1 || high.IsHighS() {
+			t.Fatal("expected normalization to the low-S signature")
+		}
+
+		if sig.Normalize().S.Equal(high.S) != 1 {
+			t.Fatal("expected normalization of a low-S signature to be a no-op")
+		}
+	}
+}
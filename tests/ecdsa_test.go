@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// secp256k1Params only carries the curve parameters, as an external secp256k1 elliptic.Curve implementation would.
+func secp256k1Params() *elliptic.CurveParams {
+	hexInt := func(h string) *big.Int {
+		i, _ := new(big.Int).SetString(h, 0)
+		return i
+	}
+
+	return &elliptic.CurveParams{
+		P:       hexInt(fieldOrder),
+		N:       new(big.Int).SetBytes(secp256k1.Order()),
+		B:       big.NewInt(7),
+		Gx:      hexInt("0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		Gy:      hexInt("0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"),
+		BitSize: 256,
+		Name:    "secp256k1",
+	}
+}
+
+func TestECDSA_PublicKey(t *testing.T) {
+	curve := secp256k1Params()
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random()).Negate()
+
+	pub, err := point.ToECDSAPublicKey(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pub.Curve != curve || pub.X.Sign() < 0 || pub.Y.Sign() < 0 {
+		t.Fatal("unexpected public key")
+	}
+
+	res := secp256k1.NewElement()
+	if err = res.FromECDSAPublicKey(pub); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(point) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Errors.
+	if _, err = point.ToECDSAPublicKey(elliptic.P256()); !errors.Is(err, secp256k1.ErrUnsupportedCurve) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrUnsupportedCurve, err)
+	}
+
+	if _, err = point.ToECDSAPublicKey(nil); !errors.Is(err, secp256k1.ErrUnsupportedCurve) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrUnsupportedCurve, err)
+	}
+
+	if _, err = secp256k1.NewElement().ToECDSAPublicKey(curve); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrIdentity, err)
+	}
+
+	for name, key := range map[string]*ecdsa.PublicKey{
+		"nil":          nil,
+		"nil x":        {Curve: curve, Y: pub.Y},
+		"off curve":    {Curve: curve, X: pub.X, Y: new(big.Int).Add(pub.Y, big.NewInt(1))},
+		"x too big":    {Curve: curve, X: new(big.Int).Add(pub.X, curve.P), Y: pub.Y},
+		"wrong curve":  {Curve: elliptic.P256(), X: pub.X, Y: pub.Y},
+		"negative y":   {Curve: curve, X: pub.X, Y: new(big.Int).Neg(pub.Y)},
+		"point at inf": {Curve: curve, X: big.NewInt(0), Y: big.NewInt(0)},
+	} {
+		if err = res.FromECDSAPublicKey(key); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+
+	if res.Equal(point) != 1 {
+		t.Fatal("expected the receiver to be untouched on error")
+	}
+}
+
+func TestECDSA_PrivateKey(t *testing.T) {
+	curve := secp256k1Params()
+	scalar := secp256k1.NewScalar().Random()
+
+	priv, err := scalar.ToECDSAPrivateKey(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub := secp256k1.NewElement()
+	if err = pub.FromECDSAPublicKey(&priv.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if pub.Equal(secp256k1.Base().Multiply(scalar)) != 1 {
+		t.Fatal("unexpected public key")
+	}
+
+	res := secp256k1.NewScalar()
+	if err = res.FromECDSAPrivateKey(priv); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(scalar) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Errors.
+	if _, err = secp256k1.NewScalar().ToECDSAPrivateKey(curve); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+
+	if _, err = scalar.ToECDSAPrivateKey(elliptic.P256()); !errors.Is(err, secp256k1.ErrUnsupportedCurve) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrUnsupportedCurve, err)
+	}
+
+	for name, key := range map[string]*ecdsa.PrivateKey{
+		"nil":         nil,
+		"nil d":       {PublicKey: priv.PublicKey},
+		"zero":        {PublicKey: priv.PublicKey, D: big.NewInt(0)},
+		"order":       {PublicKey: priv.PublicKey, D: curve.N},
+		"wrong curve": {PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()}, D: priv.D},
+	} {
+		if err = res.FromECDSAPrivateKey(key); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+
+	if res.Equal(scalar) != 1 {
+		t.Fatal("expected the receiver to be untouched on error")
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+func newTwoPartyShares(t *testing.T) (x1 *secp256k1.Scalar, p1, p2 *ecdsa.TwoPartyShare) {
+	t.Helper()
+
+	x1, x2 := secp256k1.NewScalar().Random(), secp256k1.NewScalar().Random()
+
+	var err error
+	if p1, err = ecdsa.NewTwoPartyShare(x1, secp256k1.ScalarBaseMult(x2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if p2, err = ecdsa.NewTwoPartyShare(x2, p1.PublicShare()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !p1.PublicKey().Equal(p2.PublicKey()) {
+		t.Fatal("expected the same joint public key")
+	}
+
+	if p1.PublicKey().Element().Equal(secp256k1.ScalarBaseMult(x1.Copy().Multiply(x2))) != 1 {
+		t.Fatal("unexpected joint public key")
+	}
+
+	return x1, p1, p2
+}
+
+func newTwoPartyNonces(t *testing.T) (n1, n2 *ecdsa.TwoPartyNonce) {
+	t.Helper()
+
+	var err error
+	if n1, err = ecdsa.NewTwoPartyNonce(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+
+	if n2, err = ecdsa.NewTwoPartyNonce(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+
+	return n1, n2
+}
+
+func TestTwoPartyECDSA(t *testing.T) {
+	x1, p1, p2 := newTwoPartyShares(t)
+	digest := sha256.Sum256([]byte("two-party"))
+
+	for range 8 {
+		n1, n2 := newTwoPartyNonces(t)
+		r1, r2 := n1.Point(), n2.Point()
+
+		a, b, err := p2.Coefficients(n2, r1, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The homomorphic evaluation of Enc(a + b * x1) is simulated in the clear.
+		partial := b.Multiply(x1).Add(a)
+
+		sig, recoveryID, err := p1.Complete(n1, r2, digest[:], partial)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa.VerifyWithFlags(p1.PublicKey(), digest[:], sig, ecdsa.VerifyLowS) {
+			t.Fatal("expected valid low-S signature")
+		}
+
+		pub, err := ecdsa.RecoverPublicKey(digest[:], sig, recoveryID)
+		if err != nil || !pub.Equal(p1.PublicKey()) {
+			t.Fatal("unexpected recovered public key")
+		}
+	}
+}
+
+func TestTwoPartyECDSA_Errors(t *testing.T) {
+	x1, p1, p2 := newTwoPartyShares(t)
+	digest := sha256.Sum256([]byte("two-party"))
+	n1, n2 := newTwoPartyNonces(t)
+	r1, r2 := n1.Point(), n2.Point()
+
+	a, b, err := p2.Coefficients(n2, r1, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nonces can't be reused.
+	if _, _, err = p2.Coefficients(n2, r1, digest[:]); !errors.Is(err, ecdsa.ErrInvalidShare) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+	}
+
+	// A wrong partial signature is detected.
+	if _, _, err = p1.Complete(n1, r2, digest[:], a.Copy().Add(b)); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+	}
+
+	// A nil partial signature or an empty digest is rejected without consuming the nonce.
+	n1, n2 = newTwoPartyNonces(t)
+	r1, r2 = n1.Point(), n2.Point()
+
+	if a, b, err = p2.Coefficients(n2, r1, digest[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	partial := b.Multiply(x1).Add(a)
+
+	if _, _, err = p1.Complete(n1, r2, digest[:], nil); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+	}
+
+	if _, _, err = p1.Complete(n1, r2, nil, partial); !errors.Is(err, ecdsa.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidSignature, err)
+	}
+
+	if _, _, err = p1.Complete(n1, r2, digest[:], partial); err != nil {
+		t.Fatal(err)
+	}
+
+	// Invalid shares and peer points.
+	if _, err = ecdsa.NewTwoPartyShare(secp256k1.NewScalar(), p2.PublicShare()); !errors.Is(err, ecdsa.ErrInvalidShare) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+	}
+
+	if _, err = ecdsa.NewTwoPartyShare(x1, secp256k1.NewElement()); !errors.Is(err, ecdsa.ErrInvalidShare) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+	}
+
+	n1, _ = newTwoPartyNonces(t)
+	if _, _, err = p2.Coefficients(n1, nil, digest[:]); !errors.Is(err, ecdsa.ErrInvalidShare) {
+		t.Fatalf("expected %v, got %v", ecdsa.ErrInvalidShare, err)
+	}
+
+	// The random source is required.
+	if _, err = ecdsa.NewTwoPartyNonce(nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+}
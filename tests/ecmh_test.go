@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecmh"
+)
+
+func TestECMH(t *testing.T) {
+	items := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+
+	m1 := ecmh.New()
+	for _, item := range items {
+		m1.Add(item)
+	}
+
+	// The hash doesn't depend on the order of insertion.
+	m2 := new(ecmh.MultisetHash).Add(items[2]).Add(items[0]).Add(items[1])
+	if !m1.Equal(m2) || !bytes.Equal(m1.Digest(), m2.Digest()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The digest is the hash of the sum of the mapped points.
+	dst := []byte("github.com/bytemare/secp256k1/ecmh:" + secp256k1.E2CSECP256K1)
+	sum := secp256k1.NewElement()
+
+	for _, item := range items {
+		sum.Add(secp256k1.EncodeToGroup(item, dst))
+	}
+
+	if expected := sha256.Sum256(sum.Encode()); !bytes.Equal(m1.Digest(), expected[:]) {
+		t.Fatal("unexpected digest")
+	}
+
+	// Removing an item yields the hash of the smaller multiset.
+	m3 := new(ecmh.MultisetHash).Add(items[0]).Add(items[2])
+	if !m2.Remove(items[1]).Equal(m3) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Multiplicities count.
+	if m3.Copy().Add(items[0]).Equal(m3) || !m3.Copy().Add(items[0]).Remove(items[0]).Equal(m3) {
+		t.Fatal("unexpected multiplicity handling")
+	}
+
+	// Union and difference.
+	other := new(ecmh.MultisetHash).Add(items[1])
+	if !m3.Copy().Union(other).Equal(m1) || !m1.Copy().Difference(other).Equal(m3) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if m1.Equal(nil) {
+		t.Fatal("expected nil to be different")
+	}
+}
+
+func TestECMH_Empty(t *testing.T) {
+	m := ecmh.New()
+	if !m.IsEmpty() || !bytes.Equal(m.Digest(), make([]byte, ecmh.DigestLength)) {
+		t.Fatal("expected the empty digest")
+	}
+
+	m.Add([]byte("a"))
+	if m.IsEmpty() {
+		t.Fatal("expected a non-empty multiset")
+	}
+
+	if !m.Remove([]byte("a")).IsEmpty() {
+		t.Fatal("expected the empty multiset")
+	}
+}
+
+func TestECMH_Encoding(t *testing.T) {
+	m := ecmh.New().Add([]byte("a")).Add([]byte("b"))
+
+	resumed := new(ecmh.MultisetHash)
+	if err := resumed.Decode(m.Encode()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !resumed.Add([]byte("c")).Equal(m.Add([]byte("c"))) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if err := resumed.Decode(ecmh.New().Encode()); err != nil || !resumed.IsEmpty() {
+		t.Fatal("expected the empty multiset to be decoded")
+	}
+
+	if err := resumed.Decode([]byte{1, 2, 3}); !errors.Is(err, ecmh.ErrInvalidEncoding) {
+		t.Fatalf("expected %v, got %v", ecmh.ErrInvalidEncoding, err)
+	}
+}
@@ -9,13 +9,16 @@
 package secp256k1_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"log"
 	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/internal/reference"
 )
 
 const (
@@ -177,7 +180,7 @@ func TestElement_EncodedLength(t *testing.T) {
 }
 
 func TestElement_Decode_OutOfBounds(t *testing.T) {
-	expected := errors.New("invalid point encoding")
+	expected := secp256k1.ErrInvalidPointEncoding
 
 	// Set x and y to zero
 	x := big.NewInt(0)
@@ -187,7 +190,7 @@ func TestElement_Decode_OutOfBounds(t *testing.T) {
 	encoded[0] = byte(2 | y.Bit(0)&1)
 	x.FillBytes(encoded[1:])
 
-	if err := secp256k1.NewElement().Decode(encoded[:]); err == nil || err.Error() != expected.Error() {
+	if err := secp256k1.NewElement().Decode(encoded[:]); !errors.Is(err, expected) {
 		t.Errorf("expected error %q, got %v", expected, err)
 	}
 
@@ -203,7 +206,7 @@ func TestElement_Decode_OutOfBounds(t *testing.T) {
 	encoded[0] = byte(2 | y.Bit(0)&1)
 	x.FillBytes(encoded[1:])
 
-	if err := secp256k1.NewElement().Decode(encoded[:]); err == nil || err.Error() != expected.Error() {
+	if err := secp256k1.NewElement().Decode(encoded[:]); !errors.Is(err, expected) {
 		t.Errorf("expected error %q, got %v", expected, err)
 	}
 }
@@ -385,10 +388,30 @@ func TestElement_Identity(t *testing.T) {
 	}
 
 	e := secp256k1.NewElement()
-	if err := e.Decode(b); err == nil || err.Error() != "invalid point encoding" {
+	if err := e.Decode(b); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
 		t.Fatalf("expected specific error on decoding identity, got %q", err)
 	}
 
+	if err := e.Decode(b); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected error %q on decoding identity, got %q", secp256k1.ErrIdentity, err)
+	}
+
+	// The identity can be explicitly allowed.
+	e.Base()
+	if err := e.DecodeAllowIdentity(b); err != nil || !e.IsIdentity() {
+		t.Fatalf("expected the identity to be decoded, got %v", err)
+	}
+
+	if err := e.DecodeAllowIdentity(basePointBytes(t)); err != nil || e.Equal(secp256k1.Base()) != 1 {
+		t.Fatalf("expected the base point to be decoded, got %v", err)
+	}
+
+	for _, invalid := range [][]byte{nil, b[1:], append([]byte{4}, b[1:]...)} {
+		if err := e.DecodeAllowIdentity(invalid); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+			t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+		}
+	}
+
 	// Test operation
 	base := secp256k1.Base()
 	if id.Equal(base.Subtract(base)) != 1 {
@@ -418,3 +441,586 @@ func TestElement_Identity(t *testing.T) {
 		t.Fatal(errExpectedIdentity)
 	}
 }
+
+func basePointBytes(t *testing.T) []byte {
+	b, err := hex.DecodeString(basePoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestElement_StrictNilOperands(t *testing.T) {
+	base := secp256k1.Base()
+
+	// Nil operands are absorbed by default.
+	if !base.Copy().Set(nil).IsIdentity() {
+		t.Fatal("expected Set(nil) to yield the identity")
+	}
+
+	// The Strict variants reject them, and leave the receiver untouched.
+	e := base.Copy()
+
+	for name, err := range map[string]error{
+		"Add":      e.AddStrict(nil),
+		"Subtract": e.SubtractStrict(nil),
+		"Multiply": e.MultiplyStrict(nil),
+		"Set":      e.SetStrict(nil),
+	} {
+		if !errors.Is(err, secp256k1.ErrNilOperand) {
+			t.Fatalf("%s: expected error %q, got %v", name, secp256k1.ErrNilOperand, err)
+		}
+	}
+
+	if e.Equal(base) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// They behave like their lenient counterparts on non-nil operands.
+	s := secp256k1.NewScalar().Random()
+	if e.AddStrict(base) != nil || e.SubtractStrict(base) != nil || e.MultiplyStrict(s) != nil ||
+		e.Equal(base.Copy().Multiply(s)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if e.SetStrict(base) != nil || e.Equal(base) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestElement_MultiplyVartime(t *testing.T) {
+	scalars := []*secp256k1.Scalar{
+		secp256k1.NewScalar().One(),
+		secp256k1.NewScalar().SetUInt64(2),
+		secp256k1.NewScalar().SetUInt64(31),
+		secp256k1.NewScalar().MinusOne(),
+	}
+
+	for range 10 {
+		scalars = append(scalars, secp256k1.NewScalar().Random())
+	}
+
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+
+	for _, s := range scalars {
+		for _, p := range []*secp256k1.Element{secp256k1.Base(), point} {
+			if p.Copy().MultiplyVartime(s).Equal(p.Copy().Multiply(s)) != 1 {
+				t.Fatalf("unexpected result for scalar %s", s.Hex())
+			}
+		}
+	}
+
+	if !point.Copy().MultiplyVartime(secp256k1.NewScalar()).IsIdentity() {
+		t.Fatal(errExpectedIdentity)
+	}
+
+	if !point.Copy().MultiplyVartime(nil).IsIdentity() {
+		t.Fatal(errExpectedIdentity)
+	}
+
+	if !secp256k1.NewElement().MultiplyVartime(scalars[4]).IsIdentity() {
+		t.Fatal(errExpectedIdentity)
+	}
+}
+
+func TestElement_Multiply_Endomorphism(t *testing.T) {
+	// λ * P = (β * x, y).
+	lambda := decodeHexScalar(t, "5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72")
+	beta, _ := new(big.Int).SetString("7ae96a2b657c07106e64479eac3434e99cf0497512f58995c1396c28719501ee", 16)
+	p, _ := new(big.Int).SetString(fieldOrder, 0)
+
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	x := new(big.Int).SetBytes(point.XCoordinate())
+	x.Mul(x, beta).Mod(x, p)
+
+	res := point.Copy().Multiply(lambda)
+	if new(big.Int).SetBytes(res.XCoordinate()).Cmp(x) != 0 || res.Encode()[0] != point.Encode()[0] {
+		t.Fatal("unexpected endomorphism")
+	}
+
+	// Scalars around the decomposition boundaries, compared to repeated additions.
+	acc := secp256k1.NewElement()
+	for i := range uint64(40) {
+		if point.Copy().Multiply(secp256k1.NewScalar().SetUInt64(i)).Equal(acc) != 1 {
+			t.Fatalf("unexpected result for %d", i)
+		}
+
+		acc.Add(point)
+	}
+
+	// (n - k) * P = -(k * P).
+	for range 10 {
+		k := secp256k1.NewScalar().Random()
+		neg := secp256k1.NewScalar().Subtract(k)
+
+		if point.Copy().Multiply(neg).Equal(point.Copy().Multiply(k).Negate()) != 1 {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestElement_Multiply_ZeroWindows(t *testing.T) {
+	// Scalars with zero windows make the multiplication add the identity, which must go through the complete formulas
+	// and not through a special case: the results are compared to the reference, and the identity must come out as
+	// (0 : Y : 0) with Y != 0, which only the formulas produce from the (0 : 0 : 0) of the zero value.
+	n := new(big.Int).Set(reference.N)
+	ints := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(16),
+		new(big.Int).Lsh(big.NewInt(1), 128),
+		new(big.Int).Lsh(big.NewInt(1), 129),
+		new(big.Int).Lsh(big.NewInt(1), 255),
+		new(big.Int).Lsh(big.NewInt(0xf), 252),
+		new(big.Int).Rsh(n, 1),
+		new(big.Int).Sub(n, big.NewInt(1)),
+		new(big.Int).Sub(n, new(big.Int).Lsh(big.NewInt(1), 128)),
+	}
+
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	rp, _ := reference.Decode(point.Encode())
+
+	for _, k := range ints {
+		s := secp256k1.NewScalar().SetBytesMod(k.FillBytes(make([]byte, 32)))
+
+		if !bytes.Equal(point.Copy().Multiply(s).Encode(), reference.Encode(reference.ScalarMult(k, rp))) ||
+			!bytes.Equal(secp256k1.Base().Multiply(s).Encode(), reference.Encode(reference.ScalarBaseMult(k))) {
+			t.Fatalf("unexpected multiplication by %x", k)
+		}
+	}
+
+	for _, e := range []*secp256k1.Element{
+		point.Copy().Multiply(secp256k1.NewScalar()),
+		secp256k1.Base().Multiply(secp256k1.NewScalar()),
+		point.Copy().Subtract(point),
+		new(secp256k1.Element).Add(new(secp256k1.Element)),
+	} {
+		if x, y, z := e.ProjectiveCoordinates(); x != [32]byte{} || y == [32]byte{} || z != [32]byte{} {
+			t.Fatalf("unexpected identity coordinates (%x : %x : %x)", x, y, z)
+		}
+	}
+
+	// The zero value of Element is the identity as operand of the complete formulas.
+	if new(secp256k1.Element).Add(point).Equal(point) != 1 || point.Copy().Add(new(secp256k1.Element)).Equal(point) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestElement_IsOnCurve(t *testing.T) {
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+
+	for _, e := range []*secp256k1.Element{
+		secp256k1.NewElement(),
+		secp256k1.Base(),
+		point,
+		point.Copy().Negate(),
+		point.Copy().Add(secp256k1.Base()),
+	} {
+		if !e.IsOnCurve() {
+			t.Fatal("expected the element to be on the curve")
+		}
+	}
+
+	// Raw coordinates.
+	gx, _ := hex.DecodeString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	gy, _ := hex.DecodeString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+
+	if !secp256k1.IsOnCurve(gx, gy) {
+		t.Fatal("expected the base point to be on the curve")
+	}
+
+	p, _ := new(big.Int).SetString(fieldOrder, 0)
+	badY := bytes.Clone(gy)
+	badY[31] ^= 1
+
+	for _, test := range []struct {
+		name string
+		x, y []byte
+	}{
+		{"wrong y", gx, badY},
+		{"short x", gx[1:], gy},
+		{"long y", gx, append(bytes.Clone(gy), 0)},
+		{"zero", make([]byte, 32), make([]byte, 32)},
+		{"non-canonical y", gx, p.Bytes()},
+	} {
+		if secp256k1.IsOnCurve(test.x, test.y) {
+			t.Fatalf("%s: expected coordinates to be rejected", test.name)
+		}
+	}
+}
+
+func TestElement_LiftX(t *testing.T) {
+	for range 10 {
+		point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+		encoded := point.Encode()
+
+		var x [32]byte
+		copy(x[:], encoded[1:])
+
+		lifted := secp256k1.NewElement()
+		if err := lifted.LiftX(x); err != nil {
+			t.Fatal(err)
+		}
+
+		if encoded[0] == 3 {
+			point.Negate()
+		}
+
+		if lifted.Equal(point) != 1 || lifted.Encode()[0] != 2 {
+			t.Fatal("expected the point with even y")
+		}
+	}
+
+	// x coordinates that are not on the curve or not canonical.
+	var x [32]byte
+	if err := secp256k1.NewElement().LiftX(x); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+	}
+
+	p, _ := new(big.Int).SetString(fieldOrder, 0)
+	p.FillBytes(x[:])
+
+	if err := secp256k1.NewElement().LiftX(x); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+	}
+}
+
+func TestElement_CSelect(t *testing.T) {
+	p1 := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	p2 := secp256k1.Base().Multiply(secp256k1.NewScalar().Random()).Negate()
+	id := secp256k1.NewElement()
+
+	for _, test := range []struct {
+		e1, e2 *secp256k1.Element
+	}{
+		{p1, p2},
+		{p2, p1},
+		{p1, id},
+		{id, p2},
+	} {
+		if secp256k1.NewElement().CSelect(1, test.e1, test.e2).Equal(test.e1) != 1 {
+			t.Fatal("expected e1 to be selected")
+		}
+
+		if secp256k1.NewElement().CSelect(0, test.e1, test.e2).Equal(test.e2) != 1 {
+			t.Fatal("expected e2 to be selected")
+		}
+	}
+
+	// The receiver may be an operand.
+	res := p1.Copy()
+	if res.CSelect(0, p2, res).Equal(p1) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if res.CMove(0, p2).Equal(p1) != 1 {
+		t.Fatal("expected the receiver to be unchanged")
+	}
+
+	if res.CMove(1, p2).Equal(p2) != 1 {
+		t.Fatal("expected the receiver to be set")
+	}
+
+	// The selected element remains usable.
+	if !res.Add(p2.Copy().Negate()).IsIdentity() {
+		t.Fatal(errExpectedIdentity)
+	}
+}
+
+func TestElement_CSwap(t *testing.T) {
+	p1 := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	p2 := secp256k1.NewElement()
+	a, b := p1.Copy(), p2.Copy()
+
+	a.CSwap(0, b)
+	if a.Equal(p1) != 1 || b.Equal(p2) != 1 {
+		t.Fatal("expected no swap")
+	}
+
+	a.CSwap(1, b)
+	if a.Equal(p2) != 1 || b.Equal(p1) != 1 {
+		t.Fatal("expected a swap")
+	}
+}
+
+func TestElement_EqualVartime(t *testing.T) {
+	p := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	id := secp256k1.NewElement()
+
+	// Same point with different projective representations.
+	p2 := p.Copy().Double().Subtract(p)
+
+	for _, test := range []struct {
+		e1, e2   *secp256k1.Element
+		expected int
+	}{
+		{p, p.Copy(), 1},
+		{p, p2, 1},
+		{p, p.Copy().Negate(), 0},
+		{p, secp256k1.Base(), 0},
+		{p, id, 0},
+		{id, p, 0},
+		{id, secp256k1.Base().Subtract(secp256k1.Base()), 1},
+	} {
+		if res := test.e1.EqualVartime(test.e2); res != test.expected || res != test.e1.Equal(test.e2) {
+			t.Fatalf("expected %d, got %d", test.expected, res)
+		}
+	}
+}
+
+func TestElement_Precompute(t *testing.T) {
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	table := point.Precompute()
+
+	// The table is independent of the element it was computed from.
+	original := point.Copy()
+	point.Double()
+
+	scalars := []*secp256k1.Scalar{
+		secp256k1.NewScalar(),
+		secp256k1.NewScalar().One(),
+		secp256k1.NewScalar().MinusOne(),
+	}
+
+	for range 5 {
+		scalars = append(scalars, secp256k1.NewScalar().Random())
+	}
+
+	for _, s := range scalars {
+		if table.Multiply(s).Equal(original.Copy().Multiply(s)) != 1 {
+			t.Fatalf("unexpected result for scalar %s", s.Hex())
+		}
+	}
+
+	if !table.Multiply(nil).IsIdentity() {
+		t.Fatal(errExpectedIdentity)
+	}
+
+	if !secp256k1.NewElement().Precompute().Multiply(scalars[4]).IsIdentity() {
+		t.Fatal(errExpectedIdentity)
+	}
+}
+
+func TestElement_DecodeX(t *testing.T) {
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	x := point.EncodeXOnly()
+	parity := point.Encode()[0] & 1
+
+	res := secp256k1.NewElement()
+	if err := res.DecodeX(x, parity); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(point) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if err := res.DecodeX(x, parity^1); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(point.Copy().Negate()) != 1 {
+		t.Fatal("expected the negated point for the other parity")
+	}
+
+	even, odd, err := secp256k1.DecodeXCandidates(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if even.Encode()[0] != 2 || odd.Encode()[0] != 3 || even.EncodeXOnly() != x || odd.EncodeXOnly() != x {
+		t.Fatal("unexpected candidates")
+	}
+
+	if !even.Add(odd).IsIdentity() {
+		t.Fatal("expected the candidates to be each other's negation")
+	}
+
+	// Errors.
+	if err = res.DecodeX(x, 2); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+	}
+
+	if _, _, err = secp256k1.DecodeXCandidates([32]byte{}); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+	}
+}
+
+func TestElement_SetAffine(t *testing.T) {
+	p, _ := new(big.Int).SetString(fieldOrder, 0)
+	gx, _ := new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	gy, _ := new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+
+	e := secp256k1.NewElement()
+	if err := e.SetAffine(gx, gy); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Equal(secp256k1.Base()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The coordinates are copied.
+	gx.SetInt64(1)
+	if e.Equal(secp256k1.Base()) != 1 {
+		t.Fatal("expected the element not to alias its input")
+	}
+
+	gx.SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+
+	for name, c := range map[string][2]*big.Int{
+		"nil x":     {nil, gy},
+		"nil y":     {gx, nil},
+		"negative":  {gx, new(big.Int).Neg(gy)},
+		"too big":   {new(big.Int).Add(gx, p), gy},
+		"off curve": {gx, new(big.Int).Add(gy, big.NewInt(1))},
+		"zero":      {big.NewInt(0), big.NewInt(0)},
+	} {
+		if err := e.SetAffine(c[0], c[1]); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+			t.Fatalf("%s: expected error %q, got %v", name, secp256k1.ErrInvalidPointEncoding, err)
+		}
+	}
+
+	if e.Equal(secp256k1.Base()) != 1 {
+		t.Fatal("expected the receiver to be untouched on error")
+	}
+}
+
+func TestElement_Cmp(t *testing.T) {
+	points := []*secp256k1.Element{secp256k1.NewElement(), secp256k1.Base(), secp256k1.Base().Negate()}
+	for range 5 {
+		points = append(points, secp256k1.Base().Multiply(secp256k1.NewScalar().Random()))
+	}
+
+	for _, p1 := range points {
+		for _, p2 := range points {
+			expected := bytes.Compare(p1.Encode(), p2.Encode())
+
+			if res := p1.Cmp(p2); res != expected {
+				t.Fatalf("expected %d, got %d", expected, res)
+			}
+
+			if res := p1.CmpConstantTime(p2); res != expected {
+				t.Fatalf("expected %d, got %d in constant time", expected, res)
+			}
+		}
+	}
+
+	// Equal elements in different representations compare equal.
+	if p := points[3]; p.Cmp(p.Copy().Double().Subtract(p)) != 0 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Sorting.
+	slices.SortFunc(points, (*secp256k1.Element).Cmp)
+	if !points[0].IsIdentity() {
+		t.Fatal("expected the identity to sort first")
+	}
+
+	for i := 1; i < len(points); i++ {
+		if bytes.Compare(points[i-1].Encode(), points[i].Encode()) > 0 {
+			t.Fatal("expected sorted elements")
+		}
+	}
+}
+
+func TestElement_ProjectiveCoordinates(t *testing.T) {
+	p, _ := new(big.Int).SetString(fieldOrder, 0)
+
+	// Same point with a non-trivial Z.
+	p0 := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	point := p0.Copy().Double().Subtract(p0)
+
+	x, y, z := point.ProjectiveCoordinates()
+	zInv := new(big.Int).ModInverse(new(big.Int).SetBytes(z[:]), p)
+	ax := new(big.Int).Mul(new(big.Int).SetBytes(x[:]), zInv)
+	ay := new(big.Int).Mul(new(big.Int).SetBytes(y[:]), zInv)
+	ax.Mod(ax, p)
+	ay.Mod(ay, p)
+
+	e := secp256k1.NewElement()
+	if err := e.SetAffine(ax, ay); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Equal(point) != 1 {
+		t.Fatal("unexpected projective coordinates")
+	}
+
+	if _, _, z = secp256k1.NewElement().ProjectiveCoordinates(); z != [32]byte{} {
+		t.Fatal("expected Z = 0 for the identity")
+	}
+}
+
+func TestElement_Subtract_InPlace(t *testing.T) {
+	p := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	q := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+
+	if p.Copy().Subtract(q).Equal(p.Copy().Add(q.Copy().Negate())) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The operand is not modified, and may be the receiver.
+	cpy := q.Copy()
+	p.Copy().Subtract(q)
+
+	if q.Equal(cpy) != 1 {
+		t.Fatal("expected the operand to be untouched")
+	}
+
+	if !q.Subtract(q).IsIdentity() {
+		t.Fatal(errExpectedIdentity)
+	}
+
+	if secp256k1.NewElement().Subtract(p).Equal(p.Copy().Negate()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The generic backend reduces products with math/big divisions, which allocate.
+	if secp256k1.Backend() == "generic" {
+		t.Skip("the generic backend allocates")
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() {
+		p.Subtract(q)
+		p.Add(q)
+	}); allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestElement_Key(t *testing.T) {
+	p := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	same := p.Copy().Double().Subtract(p)
+
+	if k := p.Key(); !bytes.Equal(k[:], p.Encode()) {
+		t.Fatal("expected the key to be the compressed encoding")
+	}
+
+	seen := map[[33]byte]bool{p.Key(): true}
+	if !seen[same.Key()] {
+		t.Fatal("expected equal elements to have the same key")
+	}
+
+	if seen[p.Copy().Negate().Key()] || seen[secp256k1.NewElement().Key()] {
+		t.Fatal("expected different elements to have different keys")
+	}
+}
+
+func TestElement_Hash(t *testing.T) {
+	input := []byte("input data")
+	dst := []byte("domain separation tag")
+	ref := secp256k1.HashToGroup(input, dst)
+
+	e := secp256k1.Base()
+	if e.Hash(input, dst) != e || e.Equal(ref) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if hasPanic, _ := expectPanic(nil, func() {
+		_ = secp256k1.NewElement().Hash(input, nil)
+	}); !hasPanic {
+		t.Fatal("expected panic on empty DST")
+	}
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/elgamal"
+)
+
+func TestElGamal_Decrypt(t *testing.T) {
+	k := newTestKey(t)
+	m := secp256k1.NewScalar().Random()
+
+	c, err := elgamal.Encrypt(k.PublicKey(), m, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := elgamal.Decrypt(k, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Equal(secp256k1.ScalarBaseMult(m)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Another key doesn't decrypt.
+	if p, _ = elgamal.Decrypt(newTestKey(t), c); p.Equal(secp256k1.ScalarBaseMult(m)) == 1 {
+		t.Fatal("unexpected decryption with another key")
+	}
+}
+
+func TestElGamal_Homomorphism(t *testing.T) {
+	k := newTestKey(t)
+	table := elgamal.NewTable(1000)
+
+	sum, err := elgamal.EncryptUint64(k.PublicKey(), 0, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []uint64{3, 141, 59, 26, 535} {
+		c, err := elgamal.EncryptUint64(k.PublicKey(), v, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum.Add(c)
+	}
+
+	if m, err := elgamal.DecryptUint64(k, sum, table); err != nil || m != 764 {
+		t.Fatalf("expected 764, got %d (%v)", m, err)
+	}
+
+	c, err := elgamal.EncryptUint64(k.PublicKey(), 64, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m, err := elgamal.DecryptUint64(k, sum.Copy().Subtract(c), table); err != nil || m != 700 {
+		t.Fatalf("expected 700, got %d (%v)", m, err)
+	}
+
+	if m, err := elgamal.DecryptUint64(k, c.Multiply(secp256k1.NewScalar().SetUInt64(3)), table); err != nil || m != 192 {
+		t.Fatalf("expected 192, got %d (%v)", m, err)
+	}
+}
+
+func TestElGamal_Rerandomize(t *testing.T) {
+	k := newTestKey(t)
+
+	c, err := elgamal.EncryptUint64(k.PublicKey(), 42, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.Copy()
+	if err = r.Rerandomize(k.PublicKey(), rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(r.Encode(), c.Encode()) {
+		t.Fatal("expected a different ciphertext")
+	}
+
+	if m, err := elgamal.DecryptUint64(k, r, elgamal.NewTable(100)); err != nil || m != 42 {
+		t.Fatalf("expected 42, got %d (%v)", m, err)
+	}
+
+	if err = r.Rerandomize(k.PublicKey(), bytes.NewReader(nil)); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+
+	if err = r.Rerandomize(k.PublicKey(), nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+}
+
+func TestElGamal_Table(t *testing.T) {
+	for _, maximum := range []uint64{0, 1, 2, 15, 16, 17, 255} {
+		table := elgamal.NewTable(maximum)
+		if table.Max() != maximum {
+			t.Fatalf("expected max %d, got %d", maximum, table.Max())
+		}
+
+		for m := range maximum + 1 {
+			if v, err := table.Log(secp256k1.ScalarBaseMult(secp256k1.NewScalar().SetUInt64(m))); err != nil || v != m {
+				t.Fatalf("expected %d, got %d (%v)", m, v, err)
+			}
+		}
+
+		out := secp256k1.ScalarBaseMult(secp256k1.NewScalar().SetUInt64(maximum + 1))
+		if _, err := table.Log(out); !errors.Is(err, elgamal.ErrOutOfRange) {
+			t.Fatalf("expected %v for %d, got %v", elgamal.ErrOutOfRange, maximum+1, err)
+		}
+	}
+
+	// Negative values are out of range.
+	minusOne := secp256k1.ScalarBaseMult(secp256k1.NewScalar().MinusOne())
+	if _, err := elgamal.NewTable(1 << 16).Log(minusOne); !errors.Is(err, elgamal.ErrOutOfRange) {
+		t.Fatalf("expected %v, got %v", elgamal.ErrOutOfRange, err)
+	}
+}
+
+func TestElGamal_Encoding(t *testing.T) {
+	k := newTestKey(t)
+
+	c, err := elgamal.EncryptUint64(k.PublicKey(), 7, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := c.Encode()
+	if len(encoded) != elgamal.CiphertextLength {
+		t.Fatalf("unexpected length %d", len(encoded))
+	}
+
+	decoded := new(elgamal.Ciphertext)
+	if err = decoded.Decode(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.C1.Equal(c.C1) != 1 || decoded.C2.Equal(c.C2) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if err = decoded.Decode(encoded[1:]); !errors.Is(err, elgamal.ErrInvalidCiphertext) {
+		t.Fatalf("expected %v, got %v", elgamal.ErrInvalidCiphertext, err)
+	}
+
+	encoded[0] = 5
+	if err = decoded.Decode(encoded); !errors.Is(err, elgamal.ErrInvalidCiphertext) {
+		t.Fatalf("expected %v, got %v", elgamal.ErrInvalidCiphertext, err)
+	}
+
+	if _, err = elgamal.Decrypt(k, &elgamal.Ciphertext{}); !errors.Is(err, elgamal.ErrInvalidCiphertext) {
+		t.Fatalf("expected %v, got %v", elgamal.ErrInvalidCiphertext, err)
+	}
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ellswift"
+	"github.com/bytemare/secp256k1/field"
+)
+
+// A selection of the XSwiftEC test vectors of BIP-324, as u, t, and the decoded x coordinate.
+var ellswiftVectors = []struct {
+	u, t, x string
+}{
+	{
+		u: "0000000000000000000000000000000000000000000000000000000000000000",
+		t: "0000000000000000000000000000000000000000000000000000000000000000",
+		x: "edd1fd3e327ce90cc7a3542614289aee9682003e9cf7dcc9cf2ca9743be5aa0c",
+	},
+	{
+		u: "0000000000000000000000000000000000000000000000000000000000000000",
+		t: "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		x: "edd1fd3e327ce90cc7a3542614289aee9682003e9cf7dcc9cf2ca9743be5aa0c",
+	},
+	{
+		u: "0a2d2ba93507f1df233770c2a797962cc61f6d15da14ecd47d8d27ae1cd5f853",
+		t: "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		x: "532167c11200b08c0e84a354e74dcc40f8b25f4fe686e30869526366278a0688",
+	},
+	{
+		u: "1f67edf779a8a649d6def60035f2fa22d022dd359079a1a144073d84f19b92d5",
+		t: "0000000000000000000000000000000000000000000000000000000000000000",
+		x: "025661f9aba9d15c3118456bbe980e3e1b8ba2e047c737a4eb48a040bb566f6c",
+	},
+	{
+		u: "5eb9696a2336fe2c3c666b02c755db4c0cfd62825c7b589a7b7bb442e141c1d6",
+		t: "93413f0052d49e64abec6d5831d66c43612830a17df1fe4383db896468100221",
+		x: "ef6e1da6d6c7627e80f7a7234cb08a022c1ee1cf29e4d0f9642ae924cef9eb38",
+	},
+	{
+		u: "a0f18492183e61e8063e573606591421b06bc3513631578a73a39c1c3306239f",
+		t: "2f32904f0d2a33ecca8a5451705bb537d3bf44e071226025cdbfd249fe0f7ad6",
+		x: "97a09cf1a2eae7c494df3c6f8a9445bfb8c09d60832f9b0b9d5eabe25fbd14b9",
+	},
+	{
+		u: "c894ce48bfec433014b931a6ad4226d7dbd8eaa7b6e3faa8d0ef94052bcf8cff",
+		t: "336eeb3919e2b4efb746c7f71bbca7e9383230fbbc48ffafe77e8bcc69542471",
+		x: "f1c91acdc2525330f9b53158434a4d43a1c547cff29f15506f5da4eb4fe8fa5a",
+	},
+	{
+		u: "f292e46825f9225ad23dc057c1d91c4f57fcb1386f29ef10481cb1d22518593f",
+		t: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffff7011c989",
+		x: "3cea2c53b8b0170166ac7da67194694adacc84d56389225e330134dab85a4d55",
+	},
+	{
+		u: "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		t: "d19c182d2759cd99824228d94799f8c6557c38a1c0d6779b9d4b729c6f1ccc42",
+		x: "70720db7e238d04121f5b1afd8cc5ad9d18944c6bdc94881f502b7a3af3aecff",
+	},
+	{
+		u: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffff13cea4a7",
+		t: "0000000000000000000000000000000000000000000000000000000000000000",
+		x: "649984435b62b4a25d40c6133e8d9ab8c53d4b059ee8a154a3be0fcf4e892edb",
+	},
+	{
+		u: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffff3a08cc1e",
+		t: "fffffffffffffffffffffffffffffffffffffffffffffffffffffffff760e9f0",
+		x: "38e2a5ce6a93e795e16d2c398bc99f0369202ce21e8f09d56777b40fc512bccc",
+	},
+	{
+		u: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffff9b77b7f2",
+		t: "c74d99efceaa550f1ad1c0f43f46e7ff1ee3bd0162b7bf55f2965da9c3450646",
+		x: "8b7dd5c3edba9ee97b70eff438f22dca9849c8254a2f3345a0a572ffeaae0928",
+	},
+	{
+		u: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffe7bc1f8d",
+		t: "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		x: "16c2ccb54352ff4bd794f6efd613c72197ab7082da5b563bdf9cb3edaafe74c2",
+	},
+}
+
+func TestElligatorSwiftVectors(t *testing.T) {
+	for i, test := range ellswiftVectors {
+		u, _ := hex.DecodeString(test.u)
+		tt, _ := hex.DecodeString(test.t)
+		x, _ := hex.DecodeString(test.x)
+
+		var enc [ellswift.EncodingLength]byte
+		copy(enc[:32], u)
+		copy(enc[32:], tt)
+
+		// The y coordinate has the parity of t reduced modulo p, which is that of its last byte unless t >= p.
+		p := ellswift.Decode(enc)
+		if got := p.EncodeXOnly(); !bytes.Equal(got[:], x) {
+			t.Fatalf("%d: unexpected x coordinate %x", i, got)
+		}
+
+		if !p.IsOnCurve() || p.IsIdentity() {
+			t.Fatalf("%d: invalid decoded element", i)
+		}
+	}
+}
+
+func TestElligatorSwiftRoundTrip(t *testing.T) {
+	seen := make(map[[ellswift.EncodingLength]byte]bool)
+
+	for i := range 64 {
+		p := secp256k1.ScalarBaseMult(secp256k1.NewScalar().Random())
+		if i%2 == 1 {
+			p.Negate() // cover both y parities
+		}
+
+		enc, err := ellswift.Encode(p, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if seen[enc] {
+			t.Fatal("repeated encoding")
+		}
+
+		seen[enc] = true
+
+		if ellswift.Decode(enc).Equal(p) != 1 {
+			t.Fatalf("%d: %v", i, errExpectedEquality)
+		}
+	}
+
+	// The same element has many encodings.
+	g := secp256k1.Base()
+
+	e1, err := ellswift.Encode(g, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e2, err := ellswift.Encode(g, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e1 == e2 {
+		t.Fatal("expected different encodings")
+	}
+
+	if ellswift.Decode(e1).Equal(g) != 1 || ellswift.Decode(e2).Equal(g) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestElligatorSwiftDecodeAny(t *testing.T) {
+	// Every string decodes to a point of the curve, including those with u or t out of range.
+	var enc [ellswift.EncodingLength]byte
+	for i := range enc {
+		enc[i] = 0xff
+	}
+
+	for i := range 32 {
+		enc[i] ^= byte(i)
+		enc[63-i] ^= byte(3 * i)
+
+		if p := ellswift.Decode(enc); !p.IsOnCurve() || p.IsIdentity() {
+			t.Fatalf("%d: invalid decoded element", i)
+		}
+	}
+}
+
+func TestElligatorSwiftErrors(t *testing.T) {
+	if _, err := ellswift.Encode(nil, rand.Reader); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrIdentity, err)
+	}
+
+	if _, err := ellswift.Encode(secp256k1.NewElement(), rand.Reader); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrIdentity, err)
+	}
+
+	if _, err := ellswift.Encode(secp256k1.Base(), nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	if _, err := ellswift.Encode(secp256k1.Base(), bytes.NewReader(nil)); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrRandomSource, err)
+	}
+}
+
+func TestElligatorSwiftInverseVectors(t *testing.T) {
+	// XSwiftECInv test vectors of BIP-324, as u, x, the case, and t. Encode draws u and then the case from rand, and
+	// returns t or -t depending on the y parity.
+	tests := []struct {
+		u, x, t string
+		c       byte
+	}{
+		{
+			u: "05ff6bdad900fc3261bc7fe34e2fb0f569f06e091ae437d3a52e9da0cbfb9590",
+			x: "80cdf63774ec7022c89a5a8558e373a279170285e0ab27412dbce510bdfe23fc",
+			c: 2,
+			t: "45654798ece071ba79286d04f7f3eb1c3f1d17dd883610f2ad2efd82a287466b",
+		},
+		{
+			u: "05ff6bdad900fc3261bc7fe34e2fb0f569f06e091ae437d3a52e9da0cbfb9590",
+			x: "80cdf63774ec7022c89a5a8558e373a279170285e0ab27412dbce510bdfe23fc",
+			c: 7,
+			t: "f51557790948938ea7badbe7340afcc523a8b816164a2c4dcfc24695c9ad76d8",
+		},
+		{
+			u: "1737a85f4c8d146cec96e3ffdca76d9903dcf3bd53061868d478c78c63c2aa9e",
+			x: "39e48dd150d2f429be088dfd5b61882e7e8407483702ae9a5ab35927b15f85ea",
+			c: 0,
+			t: "1be8cc0b04be0c681d0c6a68f733f82c6c896e0c8a262fcd392918e303a7abf4",
+		},
+		{
+			u: "f58cd4d9830bad322699035e8246007d4be27e19b6f53621317b4f309b3daa9d",
+			x: "78ec2b3dc0948de560148bbc7c6dc9633ad5df70a5a5750cbed721804f082a3b",
+			c: 5,
+			t: "6bdcecaa18c7a3a0da35bc9559be6eb8e515bc6c291795485ca01d4f5350ff22",
+		},
+	}
+
+	order := new(big.Int).SetBytes(field.Order())
+
+	for i, test := range tests {
+		u, _ := hex.DecodeString(test.u)
+		x, _ := hex.DecodeString(test.x)
+		expected, _ := hex.DecodeString(test.t)
+
+		p := secp256k1.NewElement()
+		if err := p.LiftX([32]byte(x)); err != nil {
+			t.Fatal(err)
+		}
+
+		enc, err := ellswift.Encode(p, bytes.NewReader(append(u, test.c)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		negated := new(big.Int).Sub(order, new(big.Int).SetBytes(expected)).FillBytes(make([]byte, 32))
+		if !bytes.Equal(enc[:32], u) || (!bytes.Equal(enc[32:], expected) && !bytes.Equal(enc[32:], negated)) {
+			t.Fatalf("%d: unexpected encoding %x", i, enc)
+		}
+
+		if ellswift.Decode(enc).Equal(p) != 1 {
+			t.Fatalf("%d: %v", i, errExpectedEquality)
+		}
+	}
+}
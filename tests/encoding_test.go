@@ -12,6 +12,10 @@ import (
 	"bytes"
 	"encoding"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/bytemare/secp256k1"
@@ -86,6 +90,210 @@ func TestElement_Encoding(t *testing.T) {
 	testEncoding(t, element, secp256k1.NewElement())
 }
 
+func TestScalar_LittleEndian(t *testing.T) {
+	// 1 is encoded with its first byte set.
+	le := secp256k1.NewScalar().One().EncodeLE()
+	if len(le) != scalarLength || le[0] != 1 || !bytes.Equal(le[1:], make([]byte, scalarLength-1)) {
+		t.Fatalf("unexpected little-endian encoding of 1: %v", le)
+	}
+
+	scalar := secp256k1.NewScalar().Random()
+	le = scalar.EncodeLE()
+	be := scalar.Encode()
+
+	for i := range le {
+		if le[i] != be[scalarLength-1-i] {
+			t.Fatal("expected the little-endian encoding to be the reverse of the big-endian one")
+		}
+	}
+
+	res := secp256k1.NewScalar()
+	if err := res.DecodeLE(le); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(scalar) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The input must not be modified.
+	if !bytes.Equal(le, scalar.EncodeLE()) {
+		t.Fatal("DecodeLE modified its input")
+	}
+
+	// Same validation as Decode.
+	if err := res.DecodeLE(nil); err == nil {
+		t.Fatal("expected error on nil input")
+	}
+
+	order := secp256k1.Order()
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+
+	if err := res.DecodeLE(order); !errors.Is(err, secp256k1.ErrScalarTooBig) {
+		t.Fatalf("expected error on order, got %v", err)
+	}
+}
+
+func TestScalar_Limbs(t *testing.T) {
+	limbs := secp256k1.NewScalar().SetUInt64(42).Limbs()
+	if limbs != [4]uint64{42, 0, 0, 0} {
+		t.Fatalf("unexpected limbs %v", limbs)
+	}
+
+	// n-1
+	expected := [4]uint64{0xbfd25e8cd0364140, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0xffffffffffffffff}
+	if limbs = secp256k1.NewScalar().MinusOne().Limbs(); limbs != expected {
+		t.Fatalf("unexpected limbs %x", limbs)
+	}
+
+	scalar := secp256k1.NewScalar().Random()
+	res := secp256k1.NewScalar()
+
+	if err := res.SetLimbs(scalar.Limbs()); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(scalar) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Non-canonical values are rejected.
+	expected[0]++
+	if err := res.SetLimbs(expected); !errors.Is(err, secp256k1.ErrScalarTooBig) {
+		t.Fatalf("expected error on the order, got %v", err)
+	}
+
+	if err := res.SetLimbs([4]uint64{1<<64 - 1, 1<<64 - 1, 1<<64 - 1, 1<<64 - 1}); err == nil {
+		t.Fatal("expected error on 2^256-1")
+	}
+
+	if res.Equal(scalar) != 1 {
+		t.Fatal("expected the receiver to be untouched on error")
+	}
+}
+
+func TestScalar_AppendBinary(t *testing.T) {
+	scalar := secp256k1.NewScalar().Random()
+	prefix := []byte("prefix")
+
+	out, err := scalar.AppendBinary(bytes.Clone(prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(out, append(bytes.Clone(prefix), scalar.Encode()...)) {
+		t.Fatal("unexpected AppendBinary output")
+	}
+
+	// Small values are left-padded.
+	out, _ = secp256k1.NewScalar().One().AppendBinary(nil)
+	if !bytes.Equal(out, secp256k1.NewScalar().One().Encode()) {
+		t.Fatal("unexpected AppendBinary output for small scalar")
+	}
+
+	buf := make([]byte, 0, 10*scalarLength)
+	if allocs := testing.AllocsPerRun(10, func() {
+		_, _ = scalar.AppendBinary(buf[:0])
+	}); allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestScalar_EncodeTo(t *testing.T) {
+	scalar := secp256k1.NewScalar().Random()
+	buf := make([]byte, scalarLength+8)
+
+	out := scalar.EncodeTo(buf)
+	if !bytes.Equal(out, scalar.Encode()) || &out[0] != &buf[0] {
+		t.Fatal("unexpected EncodeTo output")
+	}
+
+	if b := scalar.Bytes32(); !bytes.Equal(b[:], scalar.Encode()) {
+		t.Fatal("unexpected Bytes32 output")
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() {
+		_ = scalar.EncodeTo(buf)
+		_ = scalar.Bytes32()
+	}); allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+
+	if hasPanic, _ := expectPanic(nil, func() {
+		_ = scalar.EncodeTo(make([]byte, scalarLength-1))
+	}); !hasPanic {
+		t.Fatal("expected panic on short buffer")
+	}
+}
+
+func TestScalar_TextEncoding(t *testing.T) {
+	scalar := secp256k1.NewScalar().Random()
+
+	text, err := scalar.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(text) != scalar.Hex() {
+		t.Fatalf("unexpected text encoding, want %q, got %q", scalar.Hex(), text)
+	}
+
+	res := secp256k1.NewScalar()
+	if err = res.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(scalar) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if err = res.UnmarshalText([]byte("not hex")); err == nil {
+		t.Fatal("expected error on invalid text encoding")
+	}
+
+	// Works as a JSON map key and value.
+	type wrapper struct {
+		S *secp256k1.Scalar `json:"s"`
+	}
+
+	j, err := json.Marshal(wrapper{S: scalar})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := wrapper{S: secp256k1.NewScalar()}
+	if err = json.Unmarshal(j, &w); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.S.Equal(scalar) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestScalar_String(t *testing.T) {
+	scalar := secp256k1.NewScalar().Random()
+
+	if s := fmt.Sprint(scalar); s != "Scalar(REDACTED)" || strings.Contains(s, scalar.Hex()) {
+		t.Fatalf("expected redacted string, got %q", s)
+	}
+
+	if s := fmt.Sprintf("%v %s %#v %+v %d", scalar, scalar, scalar, scalar, scalar); strings.Contains(s, scalar.Hex()) {
+		t.Fatalf("expected redacted string, got %q", s)
+	}
+
+	// The hexadecimal encoding is opted into with the %x and %X verbs.
+	if s := fmt.Sprintf("%x", scalar); s != scalar.Hex() {
+		t.Fatalf("expected %q, got %q", scalar.Hex(), s)
+	}
+
+	if s := fmt.Sprintf("%#X", scalar); s != "0X"+strings.ToUpper(scalar.Hex()) {
+		t.Fatalf("expected upper case hexadecimal, got %q", s)
+	}
+}
+
 func TestScalar_DecodeHex_Fails(t *testing.T) {
 	scalar := secp256k1.NewScalar().Random()
 	testEncoding(t, scalar, secp256k1.NewScalar())
@@ -118,3 +326,282 @@ func TestElement_DecodeHex_Fails(t *testing.T) {
 		t.Fatal(errExpectedEquality)
 	}
 }
+
+func TestElement_XOnly(t *testing.T) {
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	xOnly := point.EncodeXOnly()
+
+	if !bytes.Equal(xOnly[:], point.XCoordinate()) {
+		t.Fatal("expected the x-only encoding to be the x coordinate")
+	}
+
+	res := secp256k1.NewElement()
+	if err := res.DecodeXOnly(xOnly); err != nil {
+		t.Fatal(err)
+	}
+
+	// The decoded point has even y, and is either the point or its negation.
+	if res.Encode()[0] != 2 || (res.Equal(point) != 1 && res.Equal(point.Copy().Negate()) != 1) {
+		t.Fatal("unexpected x-only decoding")
+	}
+
+	if x := res.EncodeXOnly(); x != xOnly {
+		t.Fatal("expected the x-only encoding to round-trip")
+	}
+
+	if x := secp256k1.NewElement().EncodeXOnly(); x != [32]byte{} {
+		t.Fatal("expected the identity to be encoded as zero bytes")
+	}
+
+	if err := res.DecodeXOnly([32]byte{}); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+	}
+}
+
+func TestElement_EncodeTo(t *testing.T) {
+	points := []*secp256k1.Element{
+		secp256k1.NewElement(),
+		secp256k1.Base(),
+		secp256k1.Base().Negate(),
+		secp256k1.Base().Multiply(secp256k1.NewScalar().Random()),
+	}
+
+	// Encode many points into one buffer.
+	buf := make([]byte, len(points)*elementLength)
+	for i, p := range points {
+		p.EncodeTo(buf[i*elementLength:])
+	}
+
+	for i, p := range points {
+		if !bytes.Equal(buf[i*elementLength:(i+1)*elementLength], p.Encode()) {
+			t.Fatalf("unexpected EncodeTo output for point %d", i)
+		}
+	}
+
+	// Elements in affine form, like the base point, are encoded without any allocation.
+	if allocs := testing.AllocsPerRun(10, func() {
+		_ = points[1].EncodeTo(buf)
+	}); allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+
+	if hasPanic, _ := expectPanic(nil, func() {
+		_ = points[1].EncodeTo(make([]byte, elementLength-1))
+	}); !hasPanic {
+		t.Fatal("expected panic on short buffer")
+	}
+}
+
+func TestElement_EncodeUncompressed(t *testing.T) {
+	g := secp256k1.Base()
+
+	expected, _ := hex.DecodeString("04" +
+		"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	if !bytes.Equal(g.EncodeUncompressed(), expected) {
+		t.Fatal("unexpected uncompressed encoding of the base point")
+	}
+
+	// The negation of G has the same x and the opposite y.
+	neg := g.Copy().Negate()
+	if !bytes.Equal(neg.EncodeUncompressed()[:33], expected[:33]) || neg.Encode()[0] != 3 {
+		t.Fatal("unexpected encoding of -G")
+	}
+
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	buf := make([]byte, 100)
+
+	out := point.EncodeUncompressedTo(buf)
+	if len(out) != 65 || !bytes.Equal(out, point.EncodeUncompressed()) || !bytes.Equal(out[1:33], point.XCoordinate()) {
+		t.Fatal("unexpected EncodeUncompressedTo output")
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() {
+		_ = g.EncodeUncompressedTo(buf)
+	}); allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+
+	if !bytes.Equal(secp256k1.NewElement().EncodeUncompressed(), make([]byte, 65)) {
+		t.Fatal("expected the identity to be encoded as zero bytes")
+	}
+
+	if hasPanic, _ := expectPanic(nil, func() {
+		_ = g.EncodeUncompressedTo(make([]byte, 64))
+	}); !hasPanic {
+		t.Fatal("expected panic on short buffer")
+	}
+}
+
+func TestElement_AppendBinary(t *testing.T) {
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	prefix := []byte("prefix")
+
+	out, err := point.AppendBinary(bytes.Clone(prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(out, append(bytes.Clone(prefix), point.Encode()...)) {
+		t.Fatal("unexpected AppendBinary output")
+	}
+
+	// Serialize many points into one buffer.
+	var buf []byte
+	for range 3 {
+		buf, _ = point.AppendBinary(buf)
+	}
+
+	if !bytes.Equal(buf, bytes.Repeat(point.Encode(), 3)) {
+		t.Fatal("unexpected AppendBinary output")
+	}
+
+	g := secp256k1.Base()
+	buf = make([]byte, 0, 10*elementLength)
+
+	if allocs := testing.AllocsPerRun(10, func() {
+		_, _ = g.AppendBinary(buf[:0])
+	}); allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestElement_TextEncoding(t *testing.T) {
+	point := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+
+	text, err := point.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(text) != point.Hex() {
+		t.Fatalf("unexpected text encoding, want %q, got %q", point.Hex(), text)
+	}
+
+	res := secp256k1.NewElement()
+	if err = res.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Equal(point) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if err = res.UnmarshalText([]byte("not hex")); err == nil {
+		t.Fatal("expected error on invalid text encoding")
+	}
+
+	// The identity round trips as zeros.
+	identity := secp256k1.NewElement()
+
+	if text, err = identity.MarshalText(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(text) != strings.Repeat("00", secp256k1.ElementLength()) {
+		t.Fatalf("unexpected identity text encoding %q", text)
+	}
+
+	if err = res.UnmarshalText(text); err != nil || !res.IsIdentity() {
+		t.Fatalf("expected the identity, got %v", err)
+	}
+
+	if err = res.UnmarshalText([]byte(strings.Repeat("00", secp256k1.ElementLength()-1))); err == nil {
+		t.Fatal("expected error on a short identity encoding")
+	}
+
+	// Works as a JSON value and map key.
+	type wrapper struct {
+		E *secp256k1.Element         `json:"e"`
+		M map[*secp256k1.Element]int `json:"m"`
+	}
+
+	j, err := json.Marshal(wrapper{E: point, M: map[*secp256k1.Element]int{point: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var w wrapper
+	if err = json.Unmarshal(j, &w); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.E.Equal(point) != 1 || len(w.M) != 1 {
+		t.Fatal("unexpected JSON decoding")
+	}
+
+	for k, v := range w.M {
+		if k.Equal(point) != 1 || v != 1 {
+			t.Fatal("unexpected JSON map key decoding")
+		}
+	}
+}
+
+func TestDecodeCompressedBatch(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 300} {
+		points := make([]*secp256k1.Element, n)
+		encoded := make([][]byte, n)
+
+		for i := range points {
+			points[i] = secp256k1.Base().Multiply(secp256k1.NewScalar().SetUInt64(uint64(i + 1)))
+			encoded[i] = points[i].Encode()
+		}
+
+		decoded, err := secp256k1.DecodeCompressedBatch(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(decoded) != n {
+			t.Fatalf("expected %d elements, got %d", n, len(decoded))
+		}
+
+		for i := range decoded {
+			if decoded[i].Equal(points[i]) != 1 {
+				t.Fatalf("unexpected element at index %d", i)
+			}
+		}
+
+		if n < 10 {
+			continue
+		}
+
+		// The first invalid encoding is reported.
+		encoded[n-1] = nil
+		encoded[n/2] = make([]byte, elementLength)
+
+		decoded, err = secp256k1.DecodeCompressedBatch(encoded)
+		if !errors.Is(err, secp256k1.ErrInvalidPointEncoding) || decoded != nil {
+			t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPointEncoding, err)
+		}
+
+		if !strings.Contains(err.Error(), fmt.Sprintf("element %d:", n/2)) {
+			t.Fatalf("expected the error to report index %d, got %q", n/2, err)
+		}
+	}
+}
+
+func BenchmarkDecodeCompressedBatch(b *testing.B) {
+	encoded := make([][]byte, 1024)
+	for i := range encoded {
+		encoded[i] = secp256k1.Base().Multiply(secp256k1.NewScalar().Random()).Encode()
+	}
+
+	b.Run("Decode", func(b *testing.B) {
+		for range b.N {
+			for _, enc := range encoded {
+				if err := secp256k1.NewElement().Decode(enc); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("DecodeCompressedBatch", func(b *testing.B) {
+		for range b.N {
+			if _, err := secp256k1.DecodeCompressedBatch(encoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ecdsa"
+)
+
+func TestEthereumAddress(t *testing.T) {
+	// From the web3.js documentation of accounts.privateKeyToAccount.
+	k, err := secp256k1.NewPrivateKey(
+		mustDecodeHex(t, "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "2c7536e3605d9c16a7a3d7b1898e529396a65c23"
+
+	addr := ecdsa.EthereumAddress(k.PublicKey().Element())
+	if hex.EncodeToString(addr[:]) != expected {
+		t.Fatalf("unexpected address %x", addr)
+	}
+
+	// A caller-supplied hash function, which is reset before use.
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write([]byte("garbage"))
+
+	addr = ecdsa.EthereumAddressWithHash(k.PublicKey().Element(), h)
+	if hex.EncodeToString(addr[:]) != expected {
+		t.Fatalf("unexpected address %x", addr)
+	}
+
+	if ecdsa.EthereumAddress(secp256k1.NewElement()) != [20]byte{} ||
+		ecdsa.EthereumAddress(nil) != [20]byte{} {
+		t.Fatal("expected the zero address for the identity")
+	}
+}
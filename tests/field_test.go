@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/field"
+)
+
+func TestField_Order(t *testing.T) {
+	p, _ := new(big.Int).SetString(fieldOrder, 10)
+	if !bytes.Equal(field.Order(), p.Bytes()) {
+		t.Fatal("unexpected field order")
+	}
+}
+
+func TestField_Arithmetic(t *testing.T) {
+	p, _ := new(big.Int).SetString(fieldOrder, 10)
+	a := new(field.Element).SetBigInt(new(big.Int).Sub(p, big.NewInt(5)))
+	b := new(field.Element).SetUint64(7)
+
+	// Results are checked against big.Int arithmetic.
+	for _, test := range []struct {
+		res  *field.Element
+		name string
+		ref  *big.Int
+	}{
+		{name: "add", res: new(field.Element).Add(a, b), ref: big.NewInt(2)},
+		{name: "sub", res: new(field.Element).Sub(b, a), ref: big.NewInt(12)},
+		{name: "negate", res: new(field.Element).Negate(b), ref: new(big.Int).Sub(p, big.NewInt(7))},
+		{name: "mul", res: new(field.Element).Mul(a, b), ref: new(big.Int).Sub(p, big.NewInt(35))},
+		{name: "square", res: new(field.Element).Square(a), ref: big.NewInt(25)},
+		{name: "invert", res: new(field.Element).Invert(b), ref: new(big.Int).ModInverse(big.NewInt(7), p)},
+		{name: "invert zero", res: new(field.Element).Invert(field.NewElement()), ref: big.NewInt(0)},
+	} {
+		if test.res.BigInt().Cmp(test.ref) != 0 {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.ref, test.res.BigInt())
+		}
+	}
+
+	// Aliasing.
+	c := b.Copy()
+	if c.Mul(c, c).Equal(new(field.Element).SetUint64(49)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if new(field.Element).Mul(a, new(field.Element).Invert(a)).Equal(new(field.Element).One()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !new(field.Element).Zero().IsZero() || b.IsZero() || !b.IsOdd() || a.IsOdd() == b.IsOdd() {
+		t.Fatal("unexpected predicate")
+	}
+}
+
+func TestField_Sqrt(t *testing.T) {
+	// The x-coordinate of the base point yields its y-coordinate, y^2 = x^3 + 7.
+	enc := secp256k1.Base().EncodeUncompressed()
+	x, err := new(field.Element).SetBytes(enc[1:33])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	y2 := new(field.Element).Square(x)
+	y2.Mul(y2, x).Add(y2, new(field.Element).SetUint64(7))
+
+	y, ok := new(field.Element).Sqrt(y2)
+	if !ok {
+		t.Fatal("expected a square")
+	}
+
+	if y.IsOdd() != (enc[64]&1 == 1) {
+		y.Negate(y)
+	}
+
+	if !bytes.Equal(y.Bytes(), enc[33:]) {
+		t.Fatal("unexpected square root")
+	}
+
+	// 7 is not a square modulo p, so there is no point with x = 0.
+	r := new(field.Element).SetUint64(3)
+	if _, ok = r.Sqrt(new(field.Element).SetUint64(7)); ok {
+		t.Fatal("unexpected square root")
+	}
+
+	if r.Equal(new(field.Element).SetUint64(3)) != 1 {
+		t.Fatal("receiver must be unchanged")
+	}
+}
+
+func TestField_Encoding(t *testing.T) {
+	p, _ := new(big.Int).SetString(fieldOrder, 10)
+	e := new(field.Element).SetUint64(0x0102)
+
+	b := e.Bytes()
+	if len(b) != field.Length || b[30] != 1 || b[31] != 2 {
+		t.Fatalf("unexpected encoding %x", b)
+	}
+
+	d, err := new(field.Element).SetBytes(b)
+	if err != nil || d.Equal(e) != 1 {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+
+	for _, in := range [][]byte{nil, b[:31], append(b, 0), p.Bytes(), bytes.Repeat([]byte{0xff}, 32)} {
+		if _, err = new(field.Element).SetBytes(in); !errors.Is(err, field.ErrInvalidEncoding) {
+			t.Fatalf("expected %v, got %v", field.ErrInvalidEncoding, err)
+		}
+	}
+
+	if !new(field.Element).SetBytesMod(p.Bytes()).IsZero() {
+		t.Fatal("expected p to reduce to zero")
+	}
+}
+
+func TestField_Pow(t *testing.T) {
+	p, _ := new(big.Int).SetString(fieldOrder, 10)
+	a := new(field.Element).SetBigInt(new(big.Int).Sub(p, big.NewInt(3)))
+
+	for _, exp := range [][]byte{
+		nil,
+		{0},
+		{1},
+		{0, 0, 2},
+		{0xde, 0xad, 0xbe, 0xef},
+		new(big.Int).Sub(p, big.NewInt(2)).Bytes(),
+		bytes.Repeat([]byte{0xff}, 40),
+	} {
+		ref := new(big.Int).Exp(a.BigInt(), new(big.Int).SetBytes(exp), p)
+		if res := new(field.Element).Pow(a, exp); res.BigInt().Cmp(ref) != 0 {
+			t.Fatalf("exponent %x: expected %v, got %v", exp, ref, res.BigInt())
+		}
+	}
+
+	// Aliasing, and p - 2 yields the inverse.
+	c := a.Copy()
+	if c.Pow(c, new(big.Int).Sub(p, big.NewInt(2)).Bytes()).Equal(new(field.Element).Invert(a)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if new(field.Element).Pow(field.NewElement(), nil).Equal(new(field.Element).One()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestField_MulReference(t *testing.T) {
+	p, _ := new(big.Int).SetString(fieldOrder, 10)
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(0x1000003d1),
+		new(big.Int).Sub(p, big.NewInt(1)),
+		new(big.Int).Sub(p, big.NewInt(0x1000003d1)),
+		new(big.Int).Lsh(big.NewInt(1), 255),
+		new(big.Int).Lsh(big.NewInt(1), 128),
+	}
+
+	for range 64 {
+		values = append(values, new(big.Int).SetBytes(secp256k1.NewScalar().Random().Encode()))
+	}
+
+	// Multiplication is checked against big.Int arithmetic for all pairs. Run with -tags purego for the generic backend.
+	for _, x := range values {
+		for _, y := range values {
+			a := new(field.Element).SetBigInt(x)
+			b := new(field.Element).SetBigInt(y)
+			ref := new(big.Int).Mul(a.BigInt(), b.BigInt())
+			ref.Mod(ref, p)
+
+			if res := new(field.Element).Mul(a, b); res.BigInt().Cmp(ref) != 0 {
+				t.Fatalf("%x * %x: expected %x, got %x", x, y, ref, res.BigInt())
+			}
+		}
+
+		a := new(field.Element).SetBigInt(x)
+		ref := new(big.Int).Mul(a.BigInt(), a.BigInt())
+		if a.Square(a).BigInt().Cmp(ref.Mod(ref, p)) != 0 {
+			t.Fatalf("%x^2: unexpected result", x)
+		}
+	}
+}
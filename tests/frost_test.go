@@ -0,0 +1,342 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/frost"
+)
+
+// frostDealer returns the group public key and the secret shares f(1), ..., f(n) of a random secret, with a random
+// polynomial f of degree threshold - 1.
+func frostDealer(threshold, n int) (*secp256k1.Element, []*secp256k1.Scalar) {
+	coefficients := make([]*secp256k1.Scalar, threshold)
+	for i := range coefficients {
+		coefficients[i] = secp256k1.NewScalar().Random()
+	}
+
+	shares := make([]*secp256k1.Scalar, n)
+
+	for i := range shares {
+		x := secp256k1.NewScalar().SetUInt64(uint64(i + 1))
+		shares[i] = secp256k1.NewScalar()
+
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			shares[i].Multiply(x).Add(coefficients[j])
+		}
+	}
+
+	return secp256k1.ScalarBaseMult(coefficients[0]), shares
+}
+
+// frostSign runs both rounds with the participants whose 1-based identifiers are given, in increasing order.
+func frostSign(
+	t *testing.T,
+	groupKey *secp256k1.Element,
+	shares []*secp256k1.Scalar,
+	signers []int,
+	msg []byte,
+) (*frost.Session, []*secp256k1.Scalar) {
+	t.Helper()
+
+	nonces := make([]*frost.Nonce, len(signers))
+	commitments := make([]*frost.Commitment, len(signers))
+	ids := make([]*secp256k1.Scalar, len(signers))
+
+	for i, p := range signers {
+		var err error
+
+		ids[i] = secp256k1.NewScalar().SetUInt64(uint64(p))
+		if nonces[i], commitments[i], err = frost.Commit(ids[i], shares[p-1], rand.Reader); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	session, err := frost.NewSession(groupKey, commitments, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigShares := make([]*secp256k1.Scalar, len(signers))
+
+	for i, p := range signers {
+		if sigShares[i], err = session.Sign(ids[i], shares[p-1], nonces[i]); err != nil {
+			t.Fatal(err)
+		}
+
+		if !session.VerifyShare(ids[i], secp256k1.ScalarBaseMult(shares[p-1]), sigShares[i]) {
+			t.Fatal("expected valid signature share")
+		}
+
+		if _, err = session.Sign(ids[i], shares[p-1], nonces[i]); !errors.Is(err, frost.ErrInvalidNonce) {
+			t.Fatalf("expected %v, got %v", frost.ErrInvalidNonce, err)
+		}
+	}
+
+	return session, sigShares
+}
+
+func TestFROST_Sign(t *testing.T) {
+	msg := []byte("test")
+
+	for _, test := range []struct {
+		signers   []int
+		threshold int
+		n         int
+	}{
+		{threshold: 1, n: 1, signers: []int{1}},
+		{threshold: 2, n: 3, signers: []int{1, 3}},
+		{threshold: 2, n: 3, signers: []int{1, 2, 3}},
+		{threshold: 3, n: 5, signers: []int{2, 4, 5}},
+	} {
+		groupKey, shares := frostDealer(test.threshold, test.n)
+		session, sigShares := frostSign(t, groupKey, shares, test.signers, msg)
+
+		sig, err := session.Aggregate(sigShares)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sig.R.Equal(session.GroupCommitment()) != 1 {
+			t.Fatal("unexpected group commitment")
+		}
+
+		if !frost.Verify(groupKey, msg, sig) {
+			t.Fatalf("expected valid signature with signers %v", test.signers)
+		}
+
+		if frost.Verify(groupKey, []byte("other"), sig) ||
+			frost.Verify(secp256k1.Base(), msg, sig) ||
+			frost.Verify(groupKey, msg, &frost.Signature{R: sig.R, Z: sig.Z.Copy().Add(secp256k1.NewScalar().One())}) {
+			t.Fatal("expected invalid signature")
+		}
+
+		dec := new(frost.Signature)
+		if err = dec.Decode(sig.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !frost.Verify(groupKey, msg, dec) {
+			t.Fatal("expected valid decoded signature")
+		}
+	}
+}
+
+func TestFROST_Session(t *testing.T) {
+	msg := []byte("test")
+	groupKey, shares := frostDealer(2, 3)
+	session, sigShares := frostSign(t, groupKey, shares, []int{1, 2}, msg)
+	one, two, three := secp256k1.NewScalar().SetUInt64(1), secp256k1.NewScalar().SetUInt64(2),
+		secp256k1.NewScalar().SetUInt64(3)
+
+	// Shares are bound to the participant.
+	if session.VerifyShare(one, secp256k1.ScalarBaseMult(shares[1]), sigShares[0]) ||
+		session.VerifyShare(two, secp256k1.ScalarBaseMult(shares[1]), sigShares[0]) ||
+		session.VerifyShare(three, secp256k1.ScalarBaseMult(shares[2]), sigShares[0]) {
+		t.Fatal("expected invalid signature share")
+	}
+
+	// Binding factors are per participant.
+	rho1, err := session.BindingFactor(one)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = session.BindingFactor(three); !errors.Is(err, secp256k1.ErrIdentifierNotInSet) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrIdentifierNotInSet, err)
+	}
+
+	rho2, _ := session.BindingFactor(two)
+	if rho1.Equal(rho2) == 1 {
+		t.Fatal("expected distinct binding factors")
+	}
+
+	if _, err = session.Aggregate(sigShares[:1]); !errors.Is(err, frost.ErrInvalidSignatureShare) {
+		t.Fatalf("expected %v, got %v", frost.ErrInvalidSignatureShare, err)
+	}
+
+	if _, err = session.Aggregate([]*secp256k1.Scalar{sigShares[0], nil}); !errors.Is(
+		err, frost.ErrInvalidSignatureShare) {
+		t.Fatalf("expected %v, got %v", frost.ErrInvalidSignatureShare, err)
+	}
+
+	// A nonce that does not match the participant's commitment.
+	n1, c1, err := frost.Commit(one, shares[0], rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n2, c2, err := frost.Commit(two, shares[1], rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session, err = frost.NewSession(groupKey, []*frost.Commitment{c1, c2}, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = session.Sign(one, shares[0], n2); !errors.Is(err, frost.ErrInvalidNonce) {
+		t.Fatalf("expected %v, got %v", frost.ErrInvalidNonce, err)
+	}
+
+	if _, err = session.Sign(three, shares[2], n1); !errors.Is(err, secp256k1.ErrIdentifierNotInSet) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrIdentifierNotInSet, err)
+	}
+
+	// Invalid commitment lists.
+	for _, commitments := range [][]*frost.Commitment{
+		nil,
+		{c2, c1},
+		{c1, c1},
+		{c1, nil},
+		{c1, {ID: secp256k1.NewScalar(), Hiding: c2.Hiding, Binding: c2.Binding}},
+		{c1, {ID: two, Hiding: secp256k1.NewElement(), Binding: c2.Binding}},
+	} {
+		if _, err = frost.NewSession(groupKey, commitments, msg); !errors.Is(err, frost.ErrInvalidCommitment) {
+			t.Fatalf("expected %v, got %v", frost.ErrInvalidCommitment, err)
+		}
+	}
+
+	if _, err = frost.NewSession(secp256k1.NewElement(), []*frost.Commitment{c1}, msg); !errors.Is(
+		err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrIdentity, err)
+	}
+
+	// Commit errors.
+	if _, _, err = frost.Commit(secp256k1.NewScalar(), shares[0], rand.Reader); !errors.Is(
+		err, secp256k1.ErrZeroIdentifier) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrZeroIdentifier, err)
+	}
+
+	if _, _, err = frost.Commit(one, shares[0], nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	if _, _, err = frost.Commit(one, shares[0], bytes.NewReader(make([]byte, 40))); !errors.Is(
+		err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+
+	// Invalid signature encodings.
+	sig := new(frost.Signature)
+	for _, enc := range [][]byte{nil, make([]byte, 65), append(secp256k1.Base().Encode(), secp256k1.Order()...)} {
+		if err = sig.Decode(enc); !errors.Is(err, frost.ErrInvalidSignature) {
+			t.Fatalf("expected %v, got %v", frost.ErrInvalidSignature, err)
+		}
+	}
+}
+
+// TestFROST_RFC9591Vectors uses the FROST(secp256k1, SHA-256) inputs of RFC 9591, Appendix E.5, with participants 1
+// and 3 of a 2-of-3 trusted dealer setup, driving the nonce generation with the fixed randomness of the vectors.
+func TestFROST_RFC9591Vectors(t *testing.T) {
+	msg := []byte("test")
+	secret := decodeHexScalar(t, "0d004150d27c3bf2a42f312683d35fac7394b1e9e318249c1bfe7f0795a83114")
+	coefficient := decodeHexScalar(t, "fbf85eadae3058ea14f19148bb72b45e4399c0b16028acaf0395c9b03c823579")
+	groupKey := decodeHexElement(t, "02f37c34b66ced1fb51c34a90bdae006901f10625cc06c4f64663b0eae87d87b4f")
+
+	if secp256k1.ScalarBaseMult(secret).Equal(groupKey) != 1 {
+		t.Fatal("unexpected group public key")
+	}
+
+	expectedShares := []string{
+		"08f89ffe80ac94dcb920c26f3f46140bfc7f95b493f8310f5fc1ea2b01f4254c",
+		"04f0feac2edcedc6ce1253b7fab8c86b856a797f44d83d82a385554e6e401984",
+		"00e95d59dd0d46b0e303e500b62b7ccb0e555d49f5b849f5e748c071da8c0dbc",
+	}
+
+	shares := make([]*secp256k1.Scalar, len(expectedShares))
+
+	for i, h := range expectedShares {
+		shares[i] = decodeHexScalar(t, h)
+
+		x := secp256k1.NewScalar().SetUInt64(uint64(i + 1))
+		if coefficient.Copy().Multiply(x).Add(secret).Equal(shares[i]) != 1 {
+			t.Fatalf("unexpected share for participant %d", i+1)
+		}
+	}
+
+	signers := []struct {
+		hidingRandomness  string
+		bindingRandomness string
+		hiding            string
+		binding           string
+		id                uint64
+	}{
+		{
+			id:                1,
+			hidingRandomness:  "7ea5ed09af19f6ff21040c07ec2d2adbd35b759da5a401d4c99dd26b82391cb2",
+			bindingRandomness: "47acab018f116020c10cb9b9abdc7ac10aae1b48ca6e36dc15acb6ec9be5cdc5",
+			hiding:            "03c699af97d26bb4d3f05232ec5e1938c12f1e6ae97643c8f8f11c9820303f1904",
+			binding:           "02fa2aaccd51b948c9dc1a325d77226e98a5a3fe65fe9ba213761a60123040a45e",
+		},
+		{
+			id:                3,
+			hidingRandomness:  "e9165dad654fc20a9e31ca6f32ac032ec327b551a50e8ac5cf25f5c4c9e20757",
+			bindingRandomness: "e9059a232598a0fba0e495a687580e624ab425337c3221246fb2c716905bc9e7",
+		},
+	}
+
+	ids := make([]*secp256k1.Scalar, len(signers))
+	nonces := make([]*frost.Nonce, len(signers))
+	commitments := make([]*frost.Commitment, len(signers))
+
+	for i, s := range signers {
+		randomness, err := hex.DecodeString(s.hidingRandomness + s.bindingRandomness)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids[i] = secp256k1.NewScalar().SetUInt64(s.id)
+		if nonces[i], commitments[i], err = frost.Commit(ids[i], shares[s.id-1], bytes.NewReader(randomness)); err != nil {
+			t.Fatal(err)
+		}
+
+		if s.hiding == "" {
+			continue
+		}
+
+		if commitments[i].Hiding.Equal(decodeHexElement(t, s.hiding)) != 1 ||
+			commitments[i].Binding.Equal(decodeHexElement(t, s.binding)) != 1 {
+			t.Fatalf("unexpected nonce commitments for participant %d", s.id)
+		}
+	}
+
+	session, err := frost.NewSession(groupKey, commitments, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigShares := make([]*secp256k1.Scalar, len(signers))
+
+	for i, s := range signers {
+		if sigShares[i], err = session.Sign(ids[i], shares[s.id-1], nonces[i]); err != nil {
+			t.Fatal(err)
+		}
+
+		if !session.VerifyShare(ids[i], secp256k1.ScalarBaseMult(shares[s.id-1]), sigShares[i]) {
+			t.Fatalf("expected valid signature share for participant %d", s.id)
+		}
+	}
+
+	sig, err := session.Aggregate(sigShares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !frost.Verify(groupKey, msg, sig) {
+		t.Fatal("expected valid signature")
+	}
+}
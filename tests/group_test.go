@@ -10,9 +10,20 @@ package secp256k1_test
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"slices"
 	"testing"
 
+	"github.com/bytemare/hash2curve"
+	"golang.org/x/crypto/sha3"
+
 	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/field"
 )
 
 const (
@@ -80,3 +91,562 @@ func TestGroup_Order(t *testing.T) {
 		t.Fatal(errExpectedEquality)
 	}
 }
+
+func TestCurveParameters(t *testing.T) {
+	if new(big.Int).SetBytes(secp256k1.FieldOrder()).String() != fieldOrder ||
+		!bytes.Equal(secp256k1.FieldOrder(), field.Order()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !bytes.Equal(secp256k1.B(), append(make([]byte, 31), 7)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	x, y := secp256k1.BaseCoordinates()
+	if !secp256k1.IsOnCurve(x, y) ||
+		!bytes.Equal(secp256k1.Base().EncodeUncompressed(), append(append([]byte{4}, x...), y...)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if secp256k1.Cofactor() != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The returned slices are copies.
+	x[0]++
+	secp256k1.FieldOrder()[0]--
+
+	if x2, _ := secp256k1.BaseCoordinates(); x2[0] == x[0] ||
+		new(big.Int).SetBytes(secp256k1.FieldOrder()).String() != fieldOrder {
+		t.Fatal("unexpected aliasing")
+	}
+}
+
+// groupPublicKey is protocol code written against the generic group interface, whose elements only need to be
+// multipliable by scalars.
+func groupPublicKey[S any, E interface{ Multiply(S) E }](g secp256k1.PrimeOrderGroup[S, E], seed, dst []byte) E {
+	return g.Base().Multiply(g.HashToScalar(seed, dst))
+}
+
+func TestGroupType(t *testing.T) {
+	var g secp256k1.Group
+
+	if g.Ciphersuite() != h2c || g.ScalarLength() != scalarLength || g.ElementLength() != elementLength ||
+		!bytes.Equal(g.Order(), secp256k1.Order()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !g.NewScalar().IsZero() || !g.NewElement().IsIdentity() || g.Base().Equal(secp256k1.Base()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	input, dst := []byte("input"), []byte("github.com/bytemare/secp256k1:TestGroupType")
+
+	if g.HashToScalar(input, dst).Equal(secp256k1.HashToScalar(input, dst)) != 1 ||
+		g.HashToGroup(input, dst).Equal(secp256k1.HashToGroup(input, dst)) != 1 ||
+		g.EncodeToGroup(input, dst).Equal(secp256k1.EncodeToGroup(input, dst)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	pub := groupPublicKey[*secp256k1.Scalar, *secp256k1.Element](g, input, dst)
+	if pub.Equal(secp256k1.ScalarBaseMult(secp256k1.HashToScalar(input, dst))) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	// A copy of the generator that is not in normalized form goes through the generic multiplication.
+	g := secp256k1.Base().Double().Subtract(secp256k1.Base())
+
+	scalars := []*secp256k1.Scalar{
+		secp256k1.NewScalar(),
+		secp256k1.NewScalar().One(),
+		secp256k1.NewScalar().MinusOne(),
+	}
+
+	for range 10 {
+		scalars = append(scalars, secp256k1.NewScalar().Random())
+	}
+
+	for _, s := range scalars {
+		expected := g.Copy().Multiply(s)
+
+		if secp256k1.ScalarBaseMult(s).Equal(expected) != 1 {
+			t.Fatalf("unexpected ScalarBaseMult result for scalar %s", s.Hex())
+		}
+
+		if secp256k1.Base().Multiply(s).Equal(expected) != 1 {
+			t.Fatalf("unexpected Base().Multiply result for scalar %s", s.Hex())
+		}
+	}
+}
+
+func TestDoubleScalarBaseMultVartime(t *testing.T) {
+	p := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+	zero := secp256k1.NewScalar()
+
+	scalars := []*secp256k1.Scalar{zero, secp256k1.NewScalar().One(), secp256k1.NewScalar().MinusOne()}
+	for range 5 {
+		scalars = append(scalars, secp256k1.NewScalar().Random())
+	}
+
+	for _, a := range scalars {
+		for _, b := range scalars {
+			expected := secp256k1.Base().Multiply(a).Add(p.Copy().Multiply(b))
+			if secp256k1.DoubleScalarBaseMultVartime(a, b, p).Equal(expected) != 1 {
+				t.Fatalf("unexpected result for a = %s, b = %s", a.Hex(), b.Hex())
+			}
+		}
+	}
+
+	// The point may be the identity, and nil operands are absorbed.
+	a := scalars[3]
+	if secp256k1.DoubleScalarBaseMultVartime(a, a, secp256k1.NewElement()).Equal(secp256k1.Base().Multiply(a)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if secp256k1.DoubleScalarBaseMultVartime(a, nil, nil).Equal(secp256k1.Base().Multiply(a)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if secp256k1.DoubleScalarBaseMultVartime(nil, a, p).Equal(p.Copy().Multiply(a)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestMultiScalarMultVartime(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 17} {
+		scalars := make([]*secp256k1.Scalar, n)
+		points := make([]*secp256k1.Element, n)
+		expected := secp256k1.NewElement()
+
+		for i := range n {
+			scalars[i] = secp256k1.NewScalar().Random()
+
+			switch i % 4 {
+			case 0:
+				points[i] = secp256k1.Base()
+			case 1:
+				points[i] = secp256k1.NewElement() // identity
+			case 2:
+				scalars[i].Zero()
+				points[i] = secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+			default:
+				points[i] = secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+			}
+
+			expected.Add(points[i].Copy().Multiply(scalars[i]))
+		}
+
+		if secp256k1.MultiScalarMultVartime(scalars, points).Equal(expected) != 1 {
+			t.Fatalf("unexpected result for %d points", n)
+		}
+	}
+
+	// Opposite terms cancel out.
+	s := secp256k1.NewScalar().Random()
+	p := secp256k1.Base().Multiply(secp256k1.NewScalar().Random())
+
+	if !secp256k1.MultiScalarMultVartime(
+		[]*secp256k1.Scalar{s, s, secp256k1.NewScalar().One()},
+		[]*secp256k1.Element{p, p.Copy().Negate(), nil},
+	).IsIdentity() {
+		t.Fatal("expected the identity")
+	}
+
+	if panics, err := expectPanic(errors.New("vectors have different lengths"), func() {
+		secp256k1.MultiScalarMultVartime([]*secp256k1.Scalar{s}, nil)
+	}); !panics {
+		t.Fatal(err)
+	}
+}
+
+func TestGeneratorH(t *testing.T) {
+	const expected = "0345c9857f74eb8e63d7618815f165d8187e7a2d3403810e5493d54103dcec7731"
+
+	h := secp256k1.GeneratorH()
+	if h.Hex() != expected {
+		t.Fatalf("unexpected GeneratorH, want %s, got %s", expected, h.Hex())
+	}
+
+	dst := []byte("github.com/bytemare/secp256k1:GeneratorH:" + secp256k1.H2CSECP256K1)
+	if h.Equal(secp256k1.HashToGroup(nil, dst)) != 1 {
+		t.Fatal("expected GeneratorH to match its documented derivation")
+	}
+
+	if h.IsIdentity() || h.Equal(secp256k1.Base()) == 1 {
+		t.Fatal("unexpected GeneratorH")
+	}
+
+	// Modifying the returned element doesn't affect later calls.
+	h.Double()
+	if secp256k1.GeneratorH().Hex() != expected {
+		t.Fatal("expected GeneratorH to be immutable")
+	}
+}
+
+func TestDeriveGenerators(t *testing.T) {
+	label := []byte("label")
+	generators := secp256k1.DeriveGenerators(label, 8)
+
+	if len(generators) != 8 {
+		t.Fatalf("expected 8 generators, got %d", len(generators))
+	}
+
+	seen := map[string]bool{secp256k1.Base().Hex(): true, secp256k1.GeneratorH().Hex(): true}
+
+	for i, g := range generators {
+		dst := append([]byte("github.com/bytemare/secp256k1:Generators:"+secp256k1.H2CSECP256K1), 0, 0, 0, byte(i))
+		if g.Equal(secp256k1.HashToGroup(label, dst)) != 1 {
+			t.Fatalf("expected generator %d to match its documented derivation", i)
+		}
+
+		if g.IsIdentity() || seen[g.Hex()] {
+			t.Fatalf("unexpected generator %d", i)
+		}
+
+		seen[g.Hex()] = true
+	}
+
+	// Smaller sets are prefixes of larger ones, and other labels yield other sets.
+	prefix := secp256k1.DeriveGenerators(label, 3)
+	for i, g := range prefix {
+		if g.Equal(generators[i]) != 1 {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+
+	if secp256k1.DeriveGenerators([]byte("other"), 1)[0].Equal(generators[0]) == 1 {
+		t.Fatal("expected different generators for another label")
+	}
+
+	if len(secp256k1.DeriveGenerators(label, 0)) != 0 {
+		t.Fatal("expected no generators")
+	}
+}
+
+func TestExpandMessageXOF(t *testing.T) {
+	// RFC 9380, Appendix K.6.
+	dst := []byte("QUUX-V01-CS02-with-expander-SHAKE256")
+
+	for _, v := range []struct {
+		msg, expected string
+	}{
+		{"", "2ffc05c48ed32b95d72e807f6eab9f7530dd1c2f013914c8fed38c5ccc15ad76"},
+		{"abc", "b39e493867e2767216792abce1f2676c197c0692aed061560ead251821808e07"},
+	} {
+		if out := hex.EncodeToString(secp256k1.ExpandMessageXOF([]byte(v.msg), dst, 32)); out != v.expected {
+			t.Fatalf("unexpected output for %q: want %s, got %s", v.msg, v.expected, out)
+		}
+	}
+
+	// Longer outputs.
+	input := []byte("input")
+	if !bytes.Equal(secp256k1.ExpandMessageXOF(input, dst, 128), expandMessageXOF(sha3.NewShake256(), input, dst, 128)) {
+		t.Fatal("unexpected output")
+	}
+}
+
+func TestHashToGroupXOF(t *testing.T) {
+	input := []byte("input data")
+	dst := []byte("domain separation tag")
+
+	for _, f := range []func(input, dst []byte) *secp256k1.Element{
+		secp256k1.HashToGroupXOF,
+		secp256k1.EncodeToGroupXOF,
+	} {
+		e := f(input, dst)
+
+		if e.IsIdentity() || !e.IsOnCurve() {
+			t.Fatal("unexpected element")
+		}
+
+		if e.Equal(f(input, dst)) != 1 {
+			t.Fatal("expected determinism")
+		}
+
+		if e.Equal(f([]byte("other input"), dst)) == 1 || e.Equal(f(input, []byte("other dst"))) == 1 {
+			t.Fatal("expected different inputs to yield different elements")
+		}
+	}
+
+	if secp256k1.HashToGroupXOF(input, dst).Equal(secp256k1.HashToGroup(input, dst)) == 1 {
+		t.Fatal("expected XOF and XMD outputs to differ")
+	}
+
+	if secp256k1.EncodeToGroupXOF(input, dst).Equal(secp256k1.EncodeToGroup(input, dst)) == 1 {
+		t.Fatal("expected XOF and XMD outputs to differ")
+	}
+
+	if secp256k1.H2CSECP256K1XOF != "secp256k1_XOF:SHAKE256_SSWU_RO_" || secp256k1.E2CSECP256K1XOF != "secp256k1_XOF:SHAKE256_SSWU_NU_" {
+		t.Fatal("unexpected suite identifiers")
+	}
+}
+
+func TestHashToGroupXMD(t *testing.T) {
+	input := []byte("input data")
+	dst := []byte("domain separation tag")
+
+	// SHA-256 is the default suite.
+	if secp256k1.H2CSuiteXMD(crypto.SHA256) != secp256k1.H2CSECP256K1 ||
+		secp256k1.E2CSuiteXMD(crypto.SHA256) != secp256k1.E2CSECP256K1 {
+		t.Fatal("unexpected suite identifiers")
+	}
+
+	if secp256k1.HashToGroupXMD(crypto.SHA256, input, dst).Equal(secp256k1.HashToGroup(input, dst)) != 1 ||
+		secp256k1.EncodeToGroupXMD(crypto.SHA256, input, dst).Equal(secp256k1.EncodeToGroup(input, dst)) != 1 ||
+		secp256k1.HashToScalarXMD(crypto.SHA256, input, dst).Equal(secp256k1.HashToScalar(input, dst)) != 1 {
+		t.Fatal("expected SHA-256 to match the default suite")
+	}
+
+	for _, id := range []crypto.Hash{crypto.SHA512, crypto.SHA3_256} {
+		if secp256k1.H2CSuiteXMD(id) != "secp256k1_XMD:"+id.String()+"_SSWU_RO_" {
+			t.Fatalf("unexpected suite identifier %s", secp256k1.H2CSuiteXMD(id))
+		}
+
+		e := secp256k1.HashToGroupXMD(id, input, dst)
+		if e.IsIdentity() || !e.IsOnCurve() || e.Equal(secp256k1.HashToGroup(input, dst)) == 1 {
+			t.Fatalf("unexpected element for %s", id)
+		}
+
+		if e = secp256k1.EncodeToGroupXMD(id, input, dst); e.IsIdentity() || !e.IsOnCurve() {
+			t.Fatalf("unexpected element for %s", id)
+		}
+
+		expected := new(big.Int).SetBytes(hash2curve.ExpandXMD(id, input, dst, 48))
+		expected.Mod(expected, new(big.Int).SetBytes(secp256k1.Order()))
+
+		s := secp256k1.HashToScalarXMD(id, input, dst)
+		if new(big.Int).SetBytes(s.Encode()).Cmp(expected) != 0 {
+			t.Fatalf("unexpected scalar for %s", id)
+		}
+	}
+
+	// Hash functions that are too short or not linked are rejected.
+	for _, id := range []crypto.Hash{crypto.SHA1, crypto.SHA224, crypto.MD4} {
+		if hasPanic, _ := expectPanic(nil, func() {
+			_ = secp256k1.HashToGroupXMD(id, input, dst)
+		}); !hasPanic {
+			t.Fatalf("expected panic for %s", id)
+		}
+	}
+}
+
+func TestMapToGroup(t *testing.T) {
+	input := []byte("input data")
+	dst := []byte("domain separation tag")
+	p, _ := new(big.Int).SetString(fieldOrder, 0)
+
+	// EncodeToGroup is hash_to_field followed by the map.
+	var fe [32]byte
+	hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 1, 1, 48, p)[0].FillBytes(fe[:])
+
+	e := secp256k1.MapToGroup(fe)
+	if e.Equal(secp256k1.EncodeToGroup(input, dst)) != 1 {
+		t.Fatal("expected MapToGroup to match EncodeToGroup")
+	}
+
+	// The input is reduced modulo the field order.
+	var unreduced [32]byte
+	new(big.Int).Add(p, big.NewInt(1)).FillBytes(unreduced[:])
+
+	if secp256k1.MapToGroup(unreduced).Equal(secp256k1.MapToGroup([32]byte{31: 1})) != 1 {
+		t.Fatal("expected the input to be reduced")
+	}
+
+	for _, fe = range [][32]byte{{}, {31: 1}} {
+		if e = secp256k1.MapToGroup(fe); !e.IsOnCurve() {
+			t.Fatal("expected a point on the curve")
+		}
+	}
+}
+
+func TestHashToFieldElements(t *testing.T) {
+	input := []byte("input data")
+	dst := []byte("domain separation tag")
+	p, _ := new(big.Int).SetString(fieldOrder, 0)
+
+	u := secp256k1.HashToFieldElements(input, dst, 3)
+	if len(u) != 3 {
+		t.Fatalf("expected 3 elements, got %d", len(u))
+	}
+
+	for i, fe := range hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 3, 1, 48, p) {
+		if new(big.Int).SetBytes(u[i][:]).Cmp(fe) != 0 {
+			t.Fatalf("unexpected field element %d", i)
+		}
+	}
+
+	// Composing with MapToGroup yields HashToGroup and EncodeToGroup.
+	u = secp256k1.HashToFieldElements(input, dst, 2)
+	if secp256k1.MapToGroup(u[0]).Add(secp256k1.MapToGroup(u[1])).Equal(secp256k1.HashToGroup(input, dst)) != 1 {
+		t.Fatal("expected the composition to match HashToGroup")
+	}
+
+	u = secp256k1.HashToFieldElements(input, dst, 1)
+	if secp256k1.MapToGroup(u[0]).Equal(secp256k1.EncodeToGroup(input, dst)) != 1 {
+		t.Fatal("expected the composition to match EncodeToGroup")
+	}
+}
+
+func TestHashToGroupWriter(t *testing.T) {
+	message := bytes.Repeat([]byte("a long message, written in chunks. "), 100)
+
+	for _, dst := range [][]byte{
+		[]byte("domain separation tag"),
+		[]byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_"),
+		bytes.Repeat([]byte("a"), 300), // oversized
+	} {
+		w := secp256k1.NewHashToGroupWriter(dst)
+
+		// Empty message.
+		if w.Sum().Equal(secp256k1.HashToGroup(nil, dst)) != 1 {
+			t.Fatal("unexpected output for the empty message")
+		}
+
+		for i := 0; i < len(message); i += 7 {
+			_, _ = w.Write(message[i:min(i+7, len(message))])
+		}
+
+		expected := secp256k1.HashToGroup(message, dst)
+		if w.Sum().Equal(expected) != 1 {
+			t.Fatal("expected the streamed output to match HashToGroup")
+		}
+
+		// Sum doesn't change the state.
+		if w.Sum().Equal(expected) != 1 {
+			t.Fatal("expected Sum to be idempotent")
+		}
+
+		_, _ = w.Write([]byte("more"))
+		if w.Sum().Equal(secp256k1.HashToGroup(append(bytes.Clone(message), "more"...), dst)) != 1 {
+			t.Fatal("expected writes after Sum to be absorbed")
+		}
+
+		w.Reset()
+		_, _ = w.Write(message)
+
+		if w.Sum().Equal(expected) != 1 {
+			t.Fatal("expected Reset to discard previous writes")
+		}
+	}
+
+	if hasPanic, _ := expectPanic(nil, func() {
+		_ = secp256k1.NewHashToGroupWriter(nil)
+	}); !hasPanic {
+		t.Fatal("expected panic on empty DST")
+	}
+}
+
+func TestHashToGroupBatch(t *testing.T) {
+	dst := []byte("domain separation tag")
+	inputs := [][]byte{nil, []byte("a"), []byte("b"), bytes.Repeat([]byte("c"), 1000), []byte("a")}
+
+	res := secp256k1.HashToGroupBatch(inputs, dst)
+	if len(res) != len(inputs) {
+		t.Fatalf("expected %d elements, got %d", len(inputs), len(res))
+	}
+
+	for i, input := range inputs {
+		if res[i].Equal(secp256k1.HashToGroup(input, dst)) != 1 {
+			t.Fatalf("unexpected element at index %d", i)
+		}
+	}
+
+	if len(secp256k1.HashToGroupBatch(nil, dst)) != 0 {
+		t.Fatal("expected no elements")
+	}
+
+	if hasPanic, _ := expectPanic(nil, func() {
+		_ = secp256k1.HashToGroupBatch(inputs, nil)
+	}); !hasPanic {
+		t.Fatal("expected panic on empty DST")
+	}
+}
+
+func TestHashWithOptions(t *testing.T) {
+	input := []byte("input")
+	dst := []byte("domain separation tag")
+	short := []byte("short")
+
+	// Accepted DSTs match the panicking variants.
+	s, err := secp256k1.HashToScalarWithOptions(input, dst, nil)
+	if err != nil || s.Equal(secp256k1.HashToScalar(input, dst)) != 1 {
+		t.Fatalf("unexpected scalar, err: %v", err)
+	}
+
+	e, err := secp256k1.HashToGroupWithOptions(input, dst, nil)
+	if err != nil || e.Equal(secp256k1.HashToGroup(input, dst)) != 1 {
+		t.Fatalf("unexpected element, err: %v", err)
+	}
+
+	e, err = secp256k1.EncodeToGroupWithOptions(input, dst, &secp256k1.HashOptions{})
+	if err != nil || e.Equal(secp256k1.EncodeToGroup(input, dst)) != 1 {
+		t.Fatalf("unexpected element, err: %v", err)
+	}
+
+	// Empty DSTs are always rejected.
+	allow := &secp256k1.HashOptions{AllowShortDST: true}
+	if _, err = secp256k1.HashToGroupWithOptions(input, nil, allow); !errors.Is(err, secp256k1.ErrEmptyDST) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrEmptyDST, err)
+	}
+
+	if _, err = secp256k1.HashToScalarWithOptions(input, []byte{}, nil); !errors.Is(err, secp256k1.ErrEmptyDST) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrEmptyDST, err)
+	}
+
+	// Short DSTs are rejected by default.
+	if _, err = secp256k1.EncodeToGroupWithOptions(input, short, nil); !errors.Is(err, secp256k1.ErrShortDST) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrShortDST, err)
+	}
+
+	if _, err = secp256k1.HashToGroupWithOptions(input, dst, &secp256k1.HashOptions{MinDSTLength: 64}); !errors.Is(
+		err, secp256k1.ErrShortDST) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrShortDST, err)
+	}
+
+	if _, err = secp256k1.HashToGroupWithOptions(input, short, &secp256k1.HashOptions{MinDSTLength: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Short DSTs can be allowed, with a warning.
+	if e, err = secp256k1.HashToGroupWithOptions(input, short, allow); err != nil ||
+		e.Equal(secp256k1.HashToGroup(input, short)) != 1 {
+		t.Fatalf("unexpected element, err: %v", err)
+	}
+
+	var warning error
+	allow.Warn = func(err error) { warning = err }
+
+	if _, err = secp256k1.HashToScalarWithOptions(input, short, allow); err != nil {
+		t.Fatal(err)
+	}
+
+	if !errors.Is(warning, secp256k1.ErrShortDST) {
+		t.Fatalf("expected warning %v, got %v", secp256k1.ErrShortDST, warning)
+	}
+}
+
+func TestTaggedHash(t *testing.T) {
+	tag := sha256.Sum256([]byte("BIP0340/challenge"))
+	expected := sha256.Sum256(slices.Concat(tag[:], tag[:], []byte("abc")))
+
+	for _, data := range [][][]byte{
+		{[]byte("abc")},
+		{[]byte("a"), []byte("bc")},
+		{nil, []byte("ab"), {}, []byte("c")},
+	} {
+		if !bytes.Equal(secp256k1.TaggedHash("BIP0340/challenge", data...), expected[:]) {
+			t.Fatal("unexpected tagged hash")
+		}
+	}
+
+	if bytes.Equal(secp256k1.TaggedHash("BIP0340/aux", []byte("abc")), expected[:]) {
+		t.Fatal("expected the tag to separate the hashes")
+	}
+
+	empty := sha256.Sum256(slices.Concat(tag[:], tag[:]))
+	if !bytes.Equal(secp256k1.TaggedHash("BIP0340/challenge"), empty[:]) {
+		t.Fatal("unexpected tagged hash of empty data")
+	}
+}
@@ -46,6 +46,10 @@ type h2cVector struct {
 		X string `json:"x"`
 		Y string `json:"y"`
 	} `json:"Q1"`
+	Q struct {
+		X string `json:"x"`
+		Y string `json:"y"`
+	} `json:"Q"`
 	Msg string   `json:"msg"`
 	U   []string `json:"u"`
 }
@@ -73,6 +77,8 @@ func (v *h2cVector) run(t *testing.T) {
 		t.Fatal("invalid Group")
 	}
 
+	v.runIntermediate(t)
+
 	switch v.Ciphersuite[len(v.Ciphersuite)-3:] {
 	case "RO_":
 		p := secp256k1.HashToGroup([]byte(v.Msg), []byte(v.Dst))
@@ -91,6 +97,30 @@ func (v *h2cVector) run(t *testing.T) {
 	}
 }
 
+// runIntermediate checks the hash_to_field and map_to_curve intermediate values.
+func (v *h2cVector) runIntermediate(t *testing.T) {
+	u := secp256k1.HashToFieldElements([]byte(v.Msg), []byte(v.Dst), uint(len(v.U)))
+	var q []string
+	if len(v.U) == 1 {
+		q = []string{hex.EncodeToString(vectorToSecp256k1(v.Q.X, v.Q.Y))}
+	} else {
+		q = []string{
+			hex.EncodeToString(vectorToSecp256k1(v.Q0.X, v.Q0.Y)),
+			hex.EncodeToString(vectorToSecp256k1(v.Q1.X, v.Q1.Y)),
+		}
+	}
+
+	for i, expected := range v.U {
+		if hex.EncodeToString(u[i][:]) != expected[2:] {
+			t.Fatalf("Unexpected HashToFieldElements output.\n\tExpected %q\n\tgot \t%q", expected[2:], u[i])
+		}
+
+		if p := secp256k1.MapToGroup(u[i]); p.Hex() != q[i] {
+			t.Fatalf("Unexpected MapToGroup output.\n\tExpected %q\n\tgot \t%q", q[i], p.Hex())
+		}
+	}
+}
+
 func (v *h2cVectors) runCiphersuite(t *testing.T) {
 	for _, vector := range v.Vectors {
 		vector.h2cVectors = v
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ipa"
+)
+
+type ipaStatement struct {
+	g, h secp256k1.ElementVector
+	u, p *secp256k1.Element
+	a, b secp256k1.ScalarVector
+}
+
+func newIPAStatement(n int) *ipaStatement {
+	generators := secp256k1.DeriveGenerators([]byte("ipa test"), 2*n+1)
+	s := &ipaStatement{
+		g: generators[:n],
+		h: generators[n : 2*n],
+		u: generators[2*n],
+		a: randomScalarVector(n),
+		b: randomScalarVector(n),
+	}
+	s.p = ipa.Commit(s.g, s.h, s.u, s.a, s.b)
+
+	return s
+}
+
+func (s *ipaStatement) prove() *ipa.Proof {
+	t := ipa.NewTranscript("test")
+	t.AppendElement("P", s.p)
+
+	return ipa.Prove(t, s.g, s.h, s.u, s.a, s.b)
+}
+
+func (s *ipaStatement) verify(p *secp256k1.Element, proof *ipa.Proof) bool {
+	t := ipa.NewTranscript("test")
+	t.AppendElement("P", p)
+
+	return ipa.Verify(t, s.g, s.h, s.u, p, proof)
+}
+
+func TestIPA(t *testing.T) {
+	for _, n := range []int{1, 2, 4, 16, 64} {
+		s := newIPAStatement(n)
+		a, b := s.a.Copy(), s.b.Copy()
+		proof := s.prove()
+
+		if s.a.Equal(a) != 1 || s.b.Equal(b) != 1 {
+			t.Fatal("expected the inputs to be unchanged")
+		}
+
+		if len(proof.L) != len(proof.R) || 1<<len(proof.L) != n {
+			t.Fatalf("unexpected number of rounds %d for n = %d", len(proof.L), n)
+		}
+
+		if !s.verify(s.p, proof) {
+			t.Fatalf("expected valid proof for n = %d", n)
+		}
+
+		decoded := new(ipa.Proof)
+		if err := decoded.Decode(proof.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.verify(s.p, decoded) {
+			t.Fatal("expected valid decoded proof")
+		}
+	}
+}
+
+func TestIPA_Invalid(t *testing.T) {
+	s := newIPAStatement(8)
+	proof := s.prove()
+
+	// Another statement, a tampered proof, or another transcript are rejected.
+	if s.verify(s.p.Copy().Add(s.u), proof) {
+		t.Fatal("expected another statement to be rejected")
+	}
+
+	tampered := *proof
+	tampered.A = proof.A.Copy().Add(secp256k1.NewScalar().One())
+
+	if s.verify(s.p, &tampered) {
+		t.Fatal("expected a tampered scalar to be rejected")
+	}
+
+	tampered = *proof
+	tampered.L = proof.L.Copy()
+	tampered.L[1].Add(secp256k1.Base())
+
+	if s.verify(s.p, &tampered) {
+		t.Fatal("expected a tampered point to be rejected")
+	}
+
+	if ipa.Verify(ipa.NewTranscript("other"), s.g, s.h, s.u, s.p, proof) {
+		t.Fatal("expected another transcript to be rejected")
+	}
+
+	// A proof for other vectors doesn't prove the statement.
+	other := &ipaStatement{g: s.g, h: s.h, u: s.u, p: s.p, a: randomScalarVector(8), b: s.b}
+	if s.verify(s.p, other.prove()) {
+		t.Fatal("expected a proof for other vectors to be rejected")
+	}
+
+	// Malformed proofs.
+	tampered = *proof
+	tampered.R = proof.R[:2]
+
+	if s.verify(s.p, &tampered) || s.verify(s.p, nil) {
+		t.Fatal("expected a malformed proof to be rejected")
+	}
+
+	if ipa.Verify(ipa.NewTranscript("test"), s.g[:6], s.h[:6], s.u, s.p, proof) {
+		t.Fatal("expected a non power of 2 length to be rejected")
+	}
+
+	if err := new(ipa.Proof).Decode(proof.Encode()[1:]); !errors.Is(err, ipa.ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", ipa.ErrInvalidProof, err)
+	}
+
+	encoded := proof.Encode()
+	encoded[0] = 0x05
+
+	if err := new(ipa.Proof).Decode(encoded); !errors.Is(err, ipa.ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", ipa.ErrInvalidProof, err)
+	}
+
+	if panics, _ := hasPanic(func() {
+		ipa.Prove(ipa.NewTranscript("test"), s.g[:3], s.h[:3], s.u, s.a[:3], s.b[:3])
+	}); !panics {
+		t.Fatal("expected panic on a non power of 2 length")
+	}
+}
+
+func TestIPA_Transcript(t *testing.T) {
+	t1, t2 := ipa.NewTranscript("test"), ipa.NewTranscript("test")
+	t1.AppendMessage("m", []byte("ab"))
+	t2.AppendMessage("m", []byte("ab"))
+
+	if t1.ChallengeScalar("c").Equal(t2.ChallengeScalar("c")) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Challenges depend on labels, message boundaries, and previous challenges.
+	t3 := ipa.NewTranscript("test")
+	t3.AppendMessage("m", []byte("a"))
+	t3.AppendMessage("", []byte("b"))
+
+	if t1.ChallengeScalar("c").Equal(t3.ChallengeScalar("c")) == 1 {
+		t.Fatal("expected different challenges")
+	}
+
+	if t2.ChallengeScalar("c").Equal(t2.ChallengeScalar("c")) == 1 {
+		t.Fatal("expected successive challenges to differ")
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// The JWK of the key in opensslSecret.
+const (
+	jwkX       = "wDuAHxjR98kP9h0Xtulv5I_jddvMEl6ZXJ5iNokdiGY"
+	jwkY       = "DdpKRAw67AcjPUZcegDnrCVc1m8o81TpPkMGgBuiG1Q"
+	jwkD       = "qi9Pj-lhz2s_RZywnAruadjNaluEhDxo_vIJj4JKUAo"
+	jwkPublic  = `{"kty":"EC","crv":"secp256k1","x":"` + jwkX + `","y":"` + jwkY + `"}`
+	jwkPrivate = `{"kty":"EC","crv":"secp256k1","x":"` + jwkX + `","y":"` + jwkY + `","d":"` + jwkD + `"}`
+)
+
+func TestJWK_Vector(t *testing.T) {
+	k, err := secp256k1.NewPrivateKey(mustDecodeHex(t, opensslSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := k.MarshalJWK()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(enc) != jwkPrivate {
+		t.Fatalf("unexpected encoding %s", enc)
+	}
+
+	if enc, err = k.PublicKey().MarshalJWK(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(enc) != jwkPublic {
+		t.Fatalf("unexpected encoding %s", enc)
+	}
+
+	d, err := secp256k1.ParsePrivateKeyJWK([]byte(jwkPrivate))
+	if err != nil || !d.Equal(k) {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+
+	// Private keys can be parsed as public keys, and other members are ignored.
+	withAlg := strings.Replace(jwkPrivate, "{", `{"alg":"ES256K","use":"sig",`, 1)
+	for _, in := range []string{jwkPublic, jwkPrivate, withAlg} {
+		p, err := secp256k1.ParsePublicKeyJWK([]byte(in))
+		if err != nil || !p.Equal(k.PublicKey()) {
+			t.Fatalf("unexpected decoding, err: %v", err)
+		}
+	}
+}
+
+func TestJWK_RoundTrip(t *testing.T) {
+	k, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := k.MarshalJWK()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d, err := secp256k1.ParsePrivateKeyJWK(enc); err != nil || !d.Equal(k) {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+}
+
+func TestJWK_Invalid(t *testing.T) {
+	other, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherJWK, _ := other.PublicKey().MarshalJWK()
+	mismatch := strings.Replace(string(otherJWK), "}", `,"d":"`+jwkD+`"}`, 1)
+	zero := strings.Replace(jwkPrivate, jwkD, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", 1)
+
+	for _, test := range []struct {
+		err error
+		in  string
+	}{
+		{secp256k1.ErrInvalidJWK, ""},
+		{secp256k1.ErrInvalidJWK, "[]"},
+		{secp256k1.ErrUnsupportedCurve, strings.Replace(jwkPublic, "secp256k1", "P-256", 1)},
+		{secp256k1.ErrUnsupportedCurve, strings.Replace(jwkPublic, `"EC"`, `"OKP"`, 1)},
+		{secp256k1.ErrInvalidJWK, strings.Replace(jwkPublic, jwkX, jwkX[:40], 1)},
+		{secp256k1.ErrInvalidJWK, strings.Replace(jwkPublic, jwkY, jwkY+"==", 1)},
+		{secp256k1.ErrInvalidPointEncoding, strings.Replace(jwkPublic, jwkY, jwkX, 1)},
+	} {
+		if _, err = secp256k1.ParsePublicKeyJWK([]byte(test.in)); !errors.Is(err, test.err) {
+			t.Fatalf("%s: expected %v, got %v", test.in, test.err, err)
+		}
+	}
+
+	for _, test := range []struct {
+		err error
+		in  string
+	}{
+		{secp256k1.ErrInvalidPrivateKey, jwkPublic},
+		{secp256k1.ErrInvalidPrivateKey, zero},
+		{secp256k1.ErrInvalidPrivateKey, strings.Replace(jwkPrivate, jwkD, jwkD[:10], 1)},
+		{secp256k1.ErrInvalidJWK, mismatch},
+		{secp256k1.ErrUnsupportedCurve, strings.Replace(jwkPrivate, "secp256k1", "P-256K", 1)},
+	} {
+		if _, err = secp256k1.ParsePrivateKeyJWK([]byte(test.in)); !errors.Is(err, test.err) {
+			t.Fatalf("%s: expected %v, got %v", test.in, test.err, err)
+		}
+	}
+}
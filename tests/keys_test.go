@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// Interface checks.
+var (
+	_ crypto.PrivateKey = (*secp256k1.PrivateKey)(nil)
+	_ interface {
+		Public() crypto.PublicKey
+		Equal(x crypto.PrivateKey) bool
+	} = (*secp256k1.PrivateKey)(nil)
+	_ interface{ Equal(x crypto.PublicKey) bool } = (*secp256k1.PublicKey)(nil)
+)
+
+func TestGenerateKey(t *testing.T) {
+	k, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if k.Scalar().IsZero() {
+		t.Fatal("unexpected zero scalar")
+	}
+
+	if secp256k1.ScalarBaseMult(k.Scalar()).Equal(k.PublicKey().Element()) != 1 {
+		t.Fatal("public key does not match the private key")
+	}
+
+	if !k.Public().(*secp256k1.PublicKey).Equal(k.PublicKey()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if _, err = secp256k1.GenerateKey(nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	if _, err = secp256k1.GenerateKey(bytes.NewReader(nil)); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+}
+
+func TestPrivateKey_Encoding(t *testing.T) {
+	k, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := secp256k1.NewPrivateKey(k.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !d.Equal(k) || !d.PublicKey().Equal(k.PublicKey()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	enc, _ := k.MarshalBinary()
+	u := new(secp256k1.PrivateKey)
+
+	if err = u.UnmarshalBinary(enc); err != nil || !u.Equal(k) {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+
+	other, _ := secp256k1.GenerateKey(rand.Reader)
+	if k.Equal(other) || k.Equal(k.PublicKey()) {
+		t.Fatal("unexpected equality")
+	}
+
+	// The returned scalar is a copy.
+	k.Scalar().Zero()
+
+	if !k.Equal(d) {
+		t.Fatal("the private key must not be modified through its scalar")
+	}
+
+	for _, in := range [][]byte{nil, make([]byte, 32), secp256k1.Order(), enc[:31]} {
+		if _, err = secp256k1.NewPrivateKey(in); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+			t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPrivateKey, err)
+		}
+
+		if err = u.UnmarshalBinary(in); err == nil || !u.Equal(k) {
+			t.Fatal("expected error, and the receiver to be unchanged")
+		}
+	}
+}
+
+func TestPublicKey_Encoding(t *testing.T) {
+	k, err := secp256k1.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub := k.PublicKey()
+
+	if !bytes.Equal(pub.Bytes(), pub.Element().Encode()) ||
+		!bytes.Equal(pub.BytesUncompressed(), pub.Element().EncodeUncompressed()) {
+		t.Fatal("unexpected encoding")
+	}
+
+	for _, enc := range [][]byte{pub.Bytes(), pub.BytesUncompressed()} {
+		p, err := secp256k1.NewPublicKey(enc)
+		if err != nil || !p.Equal(pub) {
+			t.Fatalf("unexpected decoding, err: %v", err)
+		}
+	}
+
+	enc, _ := pub.MarshalBinary()
+	u := new(secp256k1.PublicKey)
+
+	if err = u.UnmarshalBinary(enc); err != nil || !u.Equal(pub) {
+		t.Fatalf("unexpected decoding, err: %v", err)
+	}
+
+	// Invalid encodings: identity, wrong length, not on the curve, and invalid prefix.
+	notOnCurve := pub.BytesUncompressed()
+	notOnCurve[64] ^= 1
+	badPrefix := pub.Bytes()
+	badPrefix[0] = 4
+
+	for _, in := range [][]byte{nil, make([]byte, 33), enc[:32], notOnCurve, badPrefix} {
+		if _, err = secp256k1.NewPublicKey(in); !errors.Is(err, secp256k1.ErrInvalidPointEncoding) {
+			t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPointEncoding, err)
+		}
+
+		if err = u.UnmarshalBinary(in); err == nil || !u.Equal(pub) {
+			t.Fatal("expected error, and the receiver to be unchanged")
+		}
+	}
+
+	if pub.Equal(k) {
+		t.Fatal("unexpected equality")
+	}
+}
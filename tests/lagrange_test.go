@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// polynomial evaluates the polynomial with the given coefficients at x.
+func polynomial(coefficients []*secp256k1.Scalar, x *secp256k1.Scalar) *secp256k1.Scalar {
+	res := secp256k1.NewScalar()
+
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		res.Multiply(x).Add(coefficients[i])
+	}
+
+	return res
+}
+
+func identifiers(ids ...uint64) []*secp256k1.Scalar {
+	res := make([]*secp256k1.Scalar, len(ids))
+	for i, id := range ids {
+		res[i] = secp256k1.NewScalar().SetUInt64(id)
+	}
+
+	return res
+}
+
+func TestLagrangeCoefficient_Reconstruction(t *testing.T) {
+	threshold := 3
+	coefficients := make([]*secp256k1.Scalar, threshold)
+	for i := range coefficients {
+		coefficients[i] = secp256k1.NewScalar().Random()
+	}
+
+	participants := identifiers(2, 5, 7)
+	secret := secp256k1.NewScalar()
+	x := secp256k1.NewScalar().SetUInt64(42)
+	atX := secp256k1.NewScalar()
+
+	for _, id := range participants {
+		share := polynomial(coefficients, id)
+
+		l, err := secp256k1.LagrangeCoefficient(id, participants)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		secret.Add(share.Copy().Multiply(l))
+
+		l, err = secp256k1.LagrangeCoefficientAt(x, id, participants)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		atX.Add(share.Multiply(l))
+	}
+
+	if secret.Equal(coefficients[0]) != 1 {
+		t.Fatal("expected reconstruction of the secret")
+	}
+
+	if atX.Equal(polynomial(coefficients, x)) != 1 {
+		t.Fatal("expected interpolation at x")
+	}
+
+	// All coefficients at once.
+	all, err := secp256k1.LagrangeCoefficients(participants)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret.Zero()
+	for i, id := range participants {
+		secret.Add(polynomial(coefficients, id).Multiply(all[i]))
+	}
+
+	if secret.Equal(coefficients[0]) != 1 {
+		t.Fatal("expected reconstruction of the secret")
+	}
+}
+
+func TestLagrangeCoefficient_Errors(t *testing.T) {
+	one := secp256k1.NewScalar().One()
+
+	tests := []struct {
+		id           *secp256k1.Scalar
+		name         string
+		expected     error
+		participants []*secp256k1.Scalar
+	}{
+		{name: "empty", id: one, participants: nil, expected: secp256k1.ErrNoParticipants},
+		{name: "nil id", id: nil, participants: identifiers(1, 2), expected: secp256k1.ErrNilIdentifier},
+		{
+			name:         "nil participant",
+			id:           one,
+			participants: []*secp256k1.Scalar{one, nil},
+			expected:     secp256k1.ErrNilIdentifier,
+		},
+		{name: "zero", id: one, participants: identifiers(1, 0), expected: secp256k1.ErrZeroIdentifier},
+		{name: "not in set", id: one, participants: identifiers(2, 3), expected: secp256k1.ErrIdentifierNotInSet},
+		{name: "duplicate", id: one, participants: identifiers(1, 2, 2), expected: secp256k1.ErrDuplicateIdentifier},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := secp256k1.LagrangeCoefficient(test.id, test.participants); !errors.Is(err, test.expected) {
+				t.Fatalf("expected error %q, got %v", test.expected, err)
+			}
+		})
+	}
+
+	if _, err := secp256k1.LagrangeCoefficients(identifiers(1, 1)); err == nil {
+		t.Fatal("expected error")
+	}
+}
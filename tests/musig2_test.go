@@ -0,0 +1,405 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/musig2"
+)
+
+// Public keys and aggregate keys from the BIP-327 key_agg_vectors.json test vectors.
+var (
+	musig2Keys = []string{
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66",
+	}
+
+	musig2KeyAggVectors = []struct {
+		expected string
+		indices  []int
+	}{
+		{indices: []int{0, 1, 2}, expected: "90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C"},
+		{indices: []int{2, 1, 0}, expected: "6204DE8B083426DC6EAF9502D27024D53FC826BF7D2012148A0575435DF54B2B"},
+		{indices: []int{0, 0, 0}, expected: "B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935"},
+		{indices: []int{0, 0, 1, 1}, expected: "69BC22BFA5D106306E48A20679DE1D7389386124D07571D0D872686028C26A3E"},
+	}
+)
+
+// verifyBIP340 returns whether sig is a valid BIP-340 signature of msg for the x-only public key.
+func verifyBIP340(t *testing.T, pub [32]byte, msg []byte, sig [64]byte) bool {
+	t.Helper()
+
+	p := secp256k1.NewElement()
+	if err := p.LiftX(pub); err != nil {
+		return false
+	}
+
+	s := secp256k1.NewScalar()
+	if err := s.Decode(sig[32:]); err != nil {
+		return false
+	}
+
+	e := secp256k1.NewScalar().SetBytesMod(secp256k1.TaggedHash("BIP0340/challenge", sig[:32], pub[:], msg))
+
+	// R = s * G - e * P must have an even y coordinate and the x coordinate of the signature.
+	r := secp256k1.DoubleScalarBaseMultVartime(s, secp256k1.NewScalar().Subtract(e), p)
+	if r.IsIdentity() {
+		return false
+	}
+
+	enc := r.Encode()
+
+	return enc[0] == 2 && bytes.Equal(enc[1:], sig[:32])
+}
+
+func musig2PublicKeys(t *testing.T, indices ...int) []*secp256k1.PublicKey {
+	t.Helper()
+
+	keys := make([]*secp256k1.PublicKey, len(indices))
+
+	for i, j := range indices {
+		k, err := secp256k1.NewPublicKey(mustDecodeHex(t, musig2Keys[j]))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keys[i] = k
+	}
+
+	return keys
+}
+
+func TestMuSig2_KeyAgg_Vectors(t *testing.T) {
+	for _, v := range musig2KeyAggVectors {
+		ctx, err := musig2.KeyAgg(musig2PublicKeys(t, v.indices...))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pub := ctx.XOnlyPublicKey()
+		if !strings.EqualFold(hex.EncodeToString(pub[:]), v.expected) {
+			t.Fatalf("unexpected aggregate key for %v: %x", v.indices, pub)
+		}
+	}
+
+	if _, err := musig2.KeyAgg(nil); !errors.Is(err, musig2.ErrNoKeys) {
+		t.Fatalf("expected %v, got %v", musig2.ErrNoKeys, err)
+	}
+
+}
+
+func TestMuSig2_KeySort(t *testing.T) {
+	keys := musig2PublicKeys(t, 1, 2, 0)
+	sorted := musig2.KeySort(keys)
+
+	for i, j := range []int{2, 0, 1} {
+		if !sorted[i].Equal(musig2PublicKeys(t, j)[0]) {
+			t.Fatalf("unexpected key at index %d", i)
+		}
+	}
+
+	if !keys[0].Equal(musig2PublicKeys(t, 1)[0]) {
+		t.Fatal("expected the input to be left untouched")
+	}
+}
+
+func TestMuSig2_ApplyTweak(t *testing.T) {
+	ctx, err := musig2.KeyAgg(musig2PublicKeys(t, 0, 1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := ctx.PublicKey()
+
+	if err = ctx.ApplyTweak(secp256k1.Order(), false); !errors.Is(err, musig2.ErrInvalidTweak) {
+		t.Fatalf("expected %v, got %v", musig2.ErrInvalidTweak, err)
+	}
+
+	// Tweaking with the negated discrete logarithm of the key yields the identity, which is simulated here with the
+	// key of a single signer.
+	k := newTestKey(t)
+
+	single, err := musig2.KeyAgg([]*secp256k1.PublicKey{k.PublicKey()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := single.Coefficient(k.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tweak := secp256k1.NewScalar().Subtract(k.Scalar().Multiply(a))
+	if err = single.ApplyTweak(tweak.Encode(), false); !errors.Is(err, musig2.ErrInvalidTweak) {
+		t.Fatalf("expected %v, got %v", musig2.ErrInvalidTweak, err)
+	}
+
+	if !ctx.PublicKey().Equal(before) {
+		t.Fatal("expected the context to be left untouched on error")
+	}
+
+	tweak = secp256k1.NewScalar().SetUInt64(42)
+	if err = ctx.ApplyTweak(tweak.Encode(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	if ctx.PublicKey().Element().Equal(before.Element().Add(secp256k1.ScalarBaseMult(tweak))) != 1 {
+		t.Fatal("unexpected plain tweak")
+	}
+
+	before = ctx.PublicKey()
+	if err = ctx.ApplyTweak(tweak.Encode(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	x := before.Element().EncodeXOnly()
+	even := secp256k1.NewElement()
+
+	if err = even.LiftX(x); err != nil {
+		t.Fatal(err)
+	}
+
+	if ctx.PublicKey().Element().Equal(even.Add(secp256k1.ScalarBaseMult(tweak))) != 1 {
+		t.Fatal("unexpected x-only tweak")
+	}
+
+	if _, err = ctx.Coefficient(newTestKey(t).PublicKey()); !errors.Is(err, musig2.ErrKeyNotInSession) {
+		t.Fatalf("expected %v, got %v", musig2.ErrKeyNotInSession, err)
+	}
+}
+
+// musig2Sign runs the protocol for the keys on msg, with the tweaks applied to the aggregate key, and returns the
+// aggregate context and signature.
+func musig2Sign(
+	t *testing.T,
+	keys []*secp256k1.PrivateKey,
+	msg []byte,
+	tweaks map[bool][]byte,
+) (*musig2.KeyAggContext, [64]byte) {
+	t.Helper()
+
+	pubs := make([]*secp256k1.PublicKey, len(keys))
+	for i, k := range keys {
+		pubs[i] = k.PublicKey()
+	}
+
+	ctx, err := musig2.KeyAgg(musig2.KeySort(pubs))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, xOnly := range []bool{false, true} {
+		if tweak, ok := tweaks[xOnly]; ok {
+			if err = ctx.ApplyTweak(tweak, xOnly); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	aggKey := ctx.XOnlyPublicKey()
+	secNonces := make([]*musig2.SecretNonce, len(keys))
+	pubNonces := make([]musig2.PublicNonce, len(keys))
+
+	for i, k := range keys {
+		secNonces[i], pubNonces[i], err = musig2.NonceGen(k.PublicKey(), rand.Reader, &musig2.NonceOptions{
+			PrivateKey:   k,
+			AggregateKey: aggKey[:],
+			Message:      msg,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	aggNonce, err := musig2.NonceAgg(pubNonces)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := musig2.NewSession(ctx, aggNonce, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigs := make([]*secp256k1.Scalar, len(keys))
+
+	for i, k := range keys {
+		if sigs[i], err = session.Sign(secNonces[i], k); err != nil {
+			t.Fatal(err)
+		}
+
+		if !session.VerifyPartial(sigs[i], pubNonces[i], k.PublicKey()) {
+			t.Fatal("expected valid partial signature")
+		}
+
+		// The partial signature is bound to the signer.
+		other := (i + 1) % len(keys)
+		if other != i && session.VerifyPartial(sigs[i], pubNonces[other], k.PublicKey()) ||
+			!keys[other].Equal(k) && session.VerifyPartial(sigs[i], pubNonces[i], keys[other].PublicKey()) ||
+			session.VerifyPartial(sigs[i].Copy().Add(secp256k1.NewScalar().One()), pubNonces[i], k.PublicKey()) {
+			t.Fatal("expected invalid partial signature")
+		}
+
+		// Nonces can't be reused.
+		if _, err = session.Sign(secNonces[i], k); !errors.Is(err, musig2.ErrInvalidSecretNonce) {
+			t.Fatalf("expected %v, got %v", musig2.ErrInvalidSecretNonce, err)
+		}
+	}
+
+	sig, err := session.Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ctx, sig
+}
+
+func TestMuSig2_Sign(t *testing.T) {
+	msg := []byte("message")
+	tweak := sha256.Sum256([]byte("tweak"))
+
+	for _, tweaks := range []map[bool][]byte{
+		nil,
+		{false: tweak[:]},
+		{true: tweak[:]},
+		{false: tweak[:], true: tweak[:]},
+	} {
+		for _, n := range []int{1, 2, 3, 5} {
+			keys := make([]*secp256k1.PrivateKey, n)
+			for i := range keys {
+				keys[i] = newTestKey(t)
+			}
+
+			ctx, sig := musig2Sign(t, keys, msg, tweaks)
+
+			if !verifyBIP340(t, ctx.XOnlyPublicKey(), msg, sig) {
+				t.Fatalf("expected valid signature for %d signers", n)
+			}
+
+			if verifyBIP340(t, ctx.XOnlyPublicKey(), []byte("other"), sig) {
+				t.Fatal("expected invalid signature")
+			}
+		}
+	}
+
+	// An empty message is a valid message, as is signing with the same key twice.
+	k := newTestKey(t)
+
+	ctx, sig := musig2Sign(t, []*secp256k1.PrivateKey{k, k}, []byte{}, nil)
+	if !verifyBIP340(t, ctx.XOnlyPublicKey(), nil, sig) {
+		t.Fatal("expected valid signature")
+	}
+}
+
+func TestMuSig2_Session_Errors(t *testing.T) {
+	k1, k2 := newTestKey(t), newTestKey(t)
+	msg := []byte("message")
+
+	ctx, err := musig2.KeyAgg([]*secp256k1.PublicKey{k1.PublicKey(), k2.PublicKey()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sec1, pub1, err := musig2.NonceGen(k1.PublicKey(), rand.Reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, pub2, err := musig2.NonceGen(k2.PublicKey(), rand.Reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = musig2.NonceAgg([]musig2.PublicNonce{pub1, {}}); !errors.Is(err, musig2.ErrInvalidNonce) {
+		t.Fatalf("expected %v, got %v", musig2.ErrInvalidNonce, err)
+	}
+
+	aggNonce, err := musig2.NonceAgg([]musig2.PublicNonce{pub1, pub2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := aggNonce
+	invalid[0] = 4
+
+	if _, err = musig2.NewSession(ctx, invalid, msg); !errors.Is(err, musig2.ErrInvalidNonce) {
+		t.Fatalf("expected %v, got %v", musig2.ErrInvalidNonce, err)
+	}
+
+	session, err := musig2.NewSession(ctx, aggNonce, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A nonce generated for another key, or a key that is not part of the session.
+	if _, err = session.Sign(sec1, k2); !errors.Is(err, musig2.ErrInvalidSecretNonce) {
+		t.Fatalf("expected %v, got %v", musig2.ErrInvalidSecretNonce, err)
+	}
+
+	k3 := newTestKey(t)
+
+	sec3, _, err := musig2.NonceGen(k3.PublicKey(), rand.Reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = session.Sign(sec3, k3); !errors.Is(err, musig2.ErrKeyNotInSession) {
+		t.Fatalf("expected %v, got %v", musig2.ErrKeyNotInSession, err)
+	}
+
+	// NonceGen checks the optional private key against the public key.
+	if _, _, err = musig2.NonceGen(k1.PublicKey(), rand.Reader, &musig2.NonceOptions{PrivateKey: k2}); !errors.Is(
+		err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+
+	if _, _, err = musig2.NonceGen(k1.PublicKey(), bytes.NewReader(nil), nil); !errors.Is(
+		err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+
+	if _, _, err = musig2.NonceGen(k1.PublicKey(), nil, nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	// The nonce is deterministic in its inputs, and the message is optional.
+	rnd := bytes.Repeat([]byte{1}, 32)
+	nonces := make(map[musig2.PublicNonce]bool)
+
+	for _, opts := range []*musig2.NonceOptions{
+		{},
+		{PrivateKey: k1},
+		{Message: []byte{}},
+		{Message: msg},
+		{AggregateKey: bytes.Repeat([]byte{2}, 32)},
+		{Extra: []byte{3}},
+	} {
+		_, n1, err := musig2.NonceGen(k1.PublicKey(), bytes.NewReader(rnd), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, n2, _ := musig2.NonceGen(k1.PublicKey(), bytes.NewReader(rnd), opts)
+
+		if n1 != n2 || nonces[n1] {
+			t.Fatal("unexpected nonce")
+		}
+
+		nonces[n1] = true
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/oprf"
+)
+
+func TestOPRF(t *testing.T) {
+	input := []byte("password")
+
+	for _, mode := range []oprf.Mode{oprf.OPRF, oprf.VOPRF} {
+		key := newTestKey(t)
+
+		blind, blinded, err := mode.Blind(input, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		evaluated, err := oprf.BlindEvaluate(key, blinded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := oprf.Finalize(input, blind, evaluated)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := mode.Evaluate(key, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, expected) || len(output) != oprf.OutputLength {
+			t.Fatalf("unexpected output in mode %d", mode)
+		}
+
+		// Another blind yields the same output.
+		blind2, blinded2, err := mode.Blind(input, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if blinded2.Equal(blinded) == 1 {
+			t.Fatal("expected different blinded elements")
+		}
+
+		evaluated2, _ := oprf.BlindEvaluate(key, blinded2)
+		if output2, _ := oprf.Finalize(input, blind2, evaluated2); !bytes.Equal(output, output2) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Another key yields another output.
+		if other, _ := mode.Evaluate(newTestKey(t), input); bytes.Equal(other, output) {
+			t.Fatal("expected different outputs")
+		}
+	}
+}
+
+func TestOPRF_ModeSeparation(t *testing.T) {
+	key := newTestKey(t)
+	a, _ := oprf.OPRF.Evaluate(key, []byte("input"))
+	b, _ := oprf.VOPRF.Evaluate(key, []byte("input"))
+
+	if bytes.Equal(a, b) {
+		t.Fatal("expected modes to yield different outputs")
+	}
+}
+
+func TestOPRF_DeriveKeyPair(t *testing.T) {
+	seed := bytes.Repeat([]byte{0xa3}, 32)
+
+	k1, err := oprf.OPRF.DeriveKeyPair(seed, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k2, err := oprf.OPRF.DeriveKeyPair(seed, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !k1.Equal(k2) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	k3, _ := oprf.VOPRF.DeriveKeyPair(seed, []byte("info"))
+	k4, _ := oprf.OPRF.DeriveKeyPair(seed, []byte("other"))
+
+	if k1.Equal(k3) || k1.Equal(k4) {
+		t.Fatal("expected different keys")
+	}
+}
+
+func TestVOPRF_Proof(t *testing.T) {
+	mode := oprf.VOPRF
+	key := newTestKey(t)
+	inputs := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	blinds := make([]*secp256k1.Scalar, len(inputs))
+	blinded := make([]*secp256k1.Element, len(inputs))
+	evaluated := make([]*secp256k1.Element, len(inputs))
+
+	for i, input := range inputs {
+		var err error
+		if blinds[i], blinded[i], err = mode.Blind(input, rand.Reader); err != nil {
+			t.Fatal(err)
+		}
+
+		if evaluated[i], err = oprf.BlindEvaluate(key, blinded[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	proof, err := mode.ProveEvaluation(key, blinded, evaluated, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !mode.VerifyEvaluation(key.PublicKey(), blinded, evaluated, proof) {
+		t.Fatal("expected valid proof")
+	}
+
+	// Encoding.
+	decoded := new(oprf.Proof)
+	if err = decoded.Decode(proof.Encode()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !mode.VerifyEvaluation(key.PublicKey(), blinded, evaluated, decoded) {
+		t.Fatal("expected valid decoded proof")
+	}
+
+	if err = decoded.Decode(proof.Encode()[1:]); !errors.Is(err, oprf.ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", oprf.ErrInvalidProof, err)
+	}
+
+	if err = decoded.Decode(bytes.Repeat([]byte{0xff}, oprf.ProofLength)); !errors.Is(err, oprf.ErrInvalidProof) {
+		t.Fatalf("expected %v, got %v", oprf.ErrInvalidProof, err)
+	}
+
+	// Rejections.
+	if mode.VerifyEvaluation(newTestKey(t).PublicKey(), blinded, evaluated, proof) {
+		t.Fatal("expected another public key to be rejected")
+	}
+
+	if oprf.OPRF.VerifyEvaluation(key.PublicKey(), blinded, evaluated, proof) {
+		t.Fatal("expected another mode to be rejected")
+	}
+
+	forged := []*secp256k1.Element{evaluated[0], evaluated[2], evaluated[1]}
+	if mode.VerifyEvaluation(key.PublicKey(), blinded, forged, proof) {
+		t.Fatal("expected swapped evaluations to be rejected")
+	}
+
+	if mode.VerifyEvaluation(key.PublicKey(), blinded[:2], evaluated, proof) ||
+		mode.VerifyEvaluation(key.PublicKey(), nil, nil, proof) ||
+		mode.VerifyEvaluation(key.PublicKey(), blinded, evaluated, &oprf.Proof{}) {
+		t.Fatal("expected invalid inputs to be rejected")
+	}
+
+	// A dishonest server evaluating with another key can't prove it.
+	other := newTestKey(t)
+	evaluated[1], _ = oprf.BlindEvaluate(other, blinded[1])
+
+	if proof, err = mode.ProveEvaluation(key, blinded, evaluated, rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+
+	if mode.VerifyEvaluation(key.PublicKey(), blinded, evaluated, proof) {
+		t.Fatal("expected the dishonest evaluation to be rejected")
+	}
+}
+
+func TestOPRF_InvalidElements(t *testing.T) {
+	key := newTestKey(t)
+
+	if _, err := oprf.BlindEvaluate(key, secp256k1.NewElement()); !errors.Is(err, oprf.ErrInvalidInput) {
+		t.Fatalf("expected %v, got %v", oprf.ErrInvalidInput, err)
+	}
+
+	if _, err := oprf.Finalize(nil, secp256k1.NewScalar().Random(), nil); !errors.Is(err, oprf.ErrInvalidInput) {
+		t.Fatalf("expected %v, got %v", oprf.ErrInvalidInput, err)
+	}
+
+	if _, _, err := oprf.OPRF.Blind(nil, bytes.NewReader(nil)); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+
+	if _, _, err := oprf.OPRF.Blind(nil, nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	if _, err := oprf.VOPRF.ProveEvaluation(key, nil, nil, rand.Reader); !errors.Is(err, oprf.ErrInvalidInput) {
+		t.Fatalf("expected %v, got %v", oprf.ErrInvalidInput, err)
+	}
+
+	if _, err := oprf.VOPRF.ProveEvaluation(key, nil, nil, nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/pedersen"
+)
+
+func TestPedersen_Commit(t *testing.T) {
+	v, r := secp256k1.NewScalar().Random(), secp256k1.NewScalar().Random()
+	c := pedersen.Commit(v, r)
+
+	expected := secp256k1.ScalarBaseMult(v).Add(secp256k1.GeneratorH().Multiply(r))
+	if c.Element().Equal(expected) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !c.Verify(v, r) {
+		t.Fatal("expected valid opening")
+	}
+
+	if c.Verify(r, v) || c.Verify(v, secp256k1.NewScalar().Random()) {
+		t.Fatal("expected invalid opening")
+	}
+
+	c2, r2, err := pedersen.CommitRandom(v, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c2.Equal(c) || !c2.Verify(v, r2) {
+		t.Fatal("expected a hiding commitment to the same value")
+	}
+
+	if _, _, err = pedersen.CommitRandom(v, nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	if _, _, err = pedersen.CommitRandom(v, bytes.NewReader(nil)); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+}
+
+func TestPedersen_Homomorphism(t *testing.T) {
+	v1, r1 := secp256k1.NewScalar().SetUInt64(30), secp256k1.NewScalar().Random()
+	v2, r2 := secp256k1.NewScalar().SetUInt64(12), secp256k1.NewScalar().Random()
+	c1, c2 := pedersen.Commit(v1, r1), pedersen.Commit(v2, r2)
+
+	sum := c1.Copy().Add(c2)
+	if !sum.Verify(secp256k1.NewScalar().SetUInt64(42), r1.Copy().Add(r2)) {
+		t.Fatal("expected the sum to open to the sum of values")
+	}
+
+	diff := c1.Copy().Subtract(c2)
+	if !diff.Verify(secp256k1.NewScalar().SetUInt64(18), r1.Copy().Subtract(r2)) {
+		t.Fatal("expected the difference to open to the difference of values")
+	}
+
+	// The receiver of Copy is untouched.
+	if !c1.Verify(v1, r1) {
+		t.Fatal("expected the original commitment to be unchanged")
+	}
+}
+
+func TestPedersen_Encoding(t *testing.T) {
+	c := pedersen.Commit(secp256k1.NewScalar().Random(), secp256k1.NewScalar().Random())
+
+	encoded := c.Encode()
+	if len(encoded) != pedersen.CommitmentLength {
+		t.Fatalf("unexpected length %d", len(encoded))
+	}
+
+	decoded := new(pedersen.Commitment)
+	if err := decoded.Decode(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.Equal(c) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The commitment to zero with a zero blinding factor is the identity.
+	zero := pedersen.Commit(secp256k1.NewScalar(), secp256k1.NewScalar())
+	if err := decoded.Decode(zero.Encode()); err != nil || !decoded.Equal(zero) {
+		t.Fatal("expected the identity to be decoded")
+	}
+
+	encoded[0] = 0x05
+	if err := decoded.Decode(encoded); !errors.Is(err, pedersen.ErrInvalidCommitment) {
+		t.Fatalf("expected %v, got %v", pedersen.ErrInvalidCommitment, err)
+	}
+
+	if c.Equal(nil) {
+		t.Fatal("expected nil to be different")
+	}
+}
+
+func TestPedersen_CommitVector(t *testing.T) {
+	generators := pedersen.NewGenerators([]byte("vector"), 4)
+	values := secp256k1.NewScalarVector(4)
+	r := secp256k1.NewScalar().Random()
+
+	for _, v := range values {
+		v.Random()
+	}
+
+	c := pedersen.CommitVector(generators, values, r)
+
+	expected := secp256k1.GeneratorH().Multiply(r)
+	for i, v := range values {
+		expected.Add(generators[i].Copy().Multiply(v))
+	}
+
+	if c.Element().Equal(expected) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !c.VerifyVector(generators, values, r) {
+		t.Fatal("expected valid opening")
+	}
+
+	swapped := secp256k1.ScalarVector{values[1], values[0], values[2], values[3]}
+	if c.VerifyVector(generators, swapped, r) || c.VerifyVector(generators, values[:3], r) {
+		t.Fatal("expected invalid opening")
+	}
+
+	// Vector commitments are homomorphic too.
+	values2 := secp256k1.NewScalarVector(4)
+	for _, v := range values2 {
+		v.Random()
+	}
+
+	r2 := secp256k1.NewScalar().Random()
+	sum := c.Copy().Add(pedersen.CommitVector(generators, values2, r2))
+
+	if !sum.VerifyVector(generators, values.Copy().Add(values2), r.Copy().Add(r2)) {
+		t.Fatal("expected the sum to open to the sum of vectors")
+	}
+
+	// With the base point as the single generator, this is Commit.
+	if !pedersen.CommitVector(pedersen.Generators{secp256k1.Base()}, values[:1], r).Equal(pedersen.Commit(values[0], r)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if panics, _ := hasPanic(func() { pedersen.CommitVector(generators[:2], values, r) }); !panics {
+		t.Fatal("expected panic on too many values")
+	}
+}
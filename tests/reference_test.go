@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/field"
+	"github.com/bytemare/secp256k1/internal/reference"
+)
+
+// referenceScalars returns edge case scalars, followed by n random ones.
+func referenceScalars(n int) []*secp256k1.Scalar {
+	scalars := []*secp256k1.Scalar{
+		secp256k1.NewScalar(),
+		secp256k1.NewScalar().One(),
+		secp256k1.NewScalar().SetUInt64(2),
+		secp256k1.NewScalar().MinusOne(),
+		secp256k1.NewScalar().SetUInt64(1 << 63),
+		secp256k1.NewScalar().SetBytesMod(bytes.Repeat([]byte{0xff}, 32)),
+	}
+
+	for range n {
+		scalars = append(scalars, secp256k1.NewScalar().Random())
+	}
+
+	return scalars
+}
+
+func TestReference(t *testing.T) {
+	// Sanity checks of the reference itself.
+	two := reference.ScalarBaseMult(big.NewInt(2))
+	if hex.EncodeToString(reference.Encode(two)) != "02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5" {
+		t.Fatal("unexpected 2G")
+	}
+
+	if !reference.G.IsOnCurve() || !two.IsOnCurve() || !reference.ScalarMult(reference.N, reference.G).IsIdentity() {
+		t.Fatal("invalid reference point")
+	}
+
+	if p, ok := reference.Decode(reference.Encode(reference.Neg(two))); !ok || p.Y.Cmp(reference.Neg(two).Y) != 0 {
+		t.Fatal("invalid reference encoding")
+	}
+}
+
+func TestReferenceScalars(t *testing.T) {
+	scalars := referenceScalars(8)
+
+	for i, a := range scalars {
+		for j, b := range scalars {
+			if err := reference.CrossCheckScalars(a, b); err != nil {
+				t.Fatalf("%d, %d: %v", i, j, err)
+			}
+		}
+	}
+}
+
+func TestReferenceField(t *testing.T) {
+	elements := []*field.Element{
+		field.NewElement(),
+		field.NewElement().One(),
+		field.NewElement().SetUint64(7),
+		field.NewElement().Negate(field.NewElement().One()),
+	}
+
+	for _, s := range referenceScalars(4) {
+		elements = append(elements, field.NewElement().SetBytesMod(s.Encode()))
+	}
+
+	for i, a := range elements {
+		for j, b := range elements {
+			if err := reference.CrossCheckField(a, b); err != nil {
+				t.Fatalf("%d, %d: %v", i, j, err)
+			}
+		}
+	}
+}
+
+func TestReferenceElements(t *testing.T) {
+	scalars := referenceScalars(2)
+	elements := []*secp256k1.Element{secp256k1.NewElement(), secp256k1.Base(), secp256k1.Base().Negate()}
+
+	for _, s := range scalars[1:] {
+		elements = append(elements, secp256k1.ScalarBaseMult(s))
+	}
+
+	for i, p := range elements {
+		for j, q := range elements {
+			if err := reference.CrossCheckElements(p, q, scalars[(i+j)%len(scalars)]); err != nil {
+				t.Fatalf("%d, %d: %v", i, j, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+type rfc6979Vector struct {
+	key     string
+	message string
+	nonce   string
+}
+
+// Widely used secp256k1/SHA-256 RFC 6979 vectors, e.g. from python-ecdsa and Trezor.
+var rfc6979Vectors = []rfc6979Vector{
+	{
+		key:     "0000000000000000000000000000000000000000000000000000000000000001",
+		message: "Satoshi Nakamoto",
+		nonce:   "8f8a276c19f4149656b280621e358cce24f5f52542772691ee69063b74f15d15",
+	},
+	{
+		key:     "0000000000000000000000000000000000000000000000000000000000000001",
+		message: "All those moments will be lost in time, like tears in rain. Time to die...",
+		nonce:   "38aa22d72376b4dbc472e06c3ba403ee0a394da63fc58d88686c611aba98d6b3",
+	},
+	{
+		key:     "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+		message: "Satoshi Nakamoto",
+		nonce:   "33a19b60e25fb6f4435af53a3d42d493644827367e6453928554f43e49aa6f90",
+	},
+	{
+		key:     "f8b8af8ce3c7cca5e300d33939540c10d45ce001b8f252bfbc57ba0342904181",
+		message: "Alan Turing",
+		nonce:   "525a82b70e67874398067543fd84c83d30c175fdc45fdeee082fe13b1d7cfdf1",
+	},
+}
+
+func TestDeriveScalarRFC6979_Vectors(t *testing.T) {
+	for _, v := range rfc6979Vectors {
+		key := decodeHexScalar(t, v.key).Encode()
+		digest := sha256.Sum256([]byte(v.message))
+
+		k, err := secp256k1.DeriveScalarRFC6979(key, digest[:], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if k.Hex() != v.nonce {
+			t.Fatalf("unexpected nonce for %q: want %s, got %s", v.message, v.nonce, k.Hex())
+		}
+	}
+}
+
+func TestDeriveScalarRFC6979_Extra(t *testing.T) {
+	key := secp256k1.NewScalar().Random().Encode()
+	digest := sha256.Sum256([]byte("message"))
+
+	k1, err := secp256k1.DeriveScalarRFC6979(key, digest[:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k2, err := secp256k1.DeriveScalarRFC6979(key, digest[:], []byte("extra"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k3, err := secp256k1.DeriveScalarRFC6979(key, digest[:], []byte("extra"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if k1.Equal(k2) == 1 {
+		t.Fatal("expected additional data to change the nonce")
+	}
+
+	if k2.Equal(k3) != 1 {
+		t.Fatal("expected determinism with the same additional data")
+	}
+}
+
+func TestDeriveScalarRFC6979_InvalidKey(t *testing.T) {
+	digest := sha256.Sum256([]byte("message"))
+
+	for _, key := range [][]byte{
+		nil,
+		make([]byte, scalarLength),
+		make([]byte, scalarLength-1),
+		secp256k1.Order(),
+	} {
+		if _, err := secp256k1.DeriveScalarRFC6979(key, digest[:], nil); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+			t.Fatalf("expected error %q, got %v", secp256k1.ErrInvalidPrivateKey, err)
+		}
+	}
+}
+
+func decodeHexScalar(t *testing.T, h string) *secp256k1.Scalar {
+	s := secp256k1.NewScalar()
+	if err := s.DecodeHex(h); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/ring"
+)
+
+func newTestRing(t *testing.T, n int) ([]*secp256k1.PrivateKey, []*secp256k1.PublicKey) {
+	t.Helper()
+
+	keys := make([]*secp256k1.PrivateKey, n)
+	pubs := make([]*secp256k1.PublicKey, n)
+
+	for i := range keys {
+		keys[i] = newTestKey(t)
+		pubs[i] = keys[i].PublicKey()
+	}
+
+	return keys, pubs
+}
+
+func TestRing_Sign(t *testing.T) {
+	message := []byte("message")
+
+	for _, n := range []int{1, 2, 5} {
+		keys, pubs := newTestRing(t, n)
+
+		for _, k := range keys {
+			sig, err := ring.Sign(k, pubs, message, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !ring.Verify(pubs, message, sig) {
+				t.Fatalf("expected valid signature for ring size %d", n)
+			}
+
+			if ring.Verify(pubs, []byte("other"), sig) {
+				t.Fatal("expected another message to be rejected")
+			}
+
+			decoded := new(ring.Signature)
+			if err = decoded.Decode(sig.Encode()); err != nil {
+				t.Fatal(err)
+			}
+
+			if !ring.Verify(pubs, message, decoded) {
+				t.Fatal("expected valid decoded signature")
+			}
+		}
+	}
+
+	// The signer may appear several times in the ring, and after members that are not.
+	keys, pubs := newTestRing(t, 2)
+	pubs = append(pubs, pubs[1])
+
+	sig, err := ring.Sign(keys[1], pubs, message, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ring.Verify(pubs, message, sig) {
+		t.Fatal("expected valid signature with a repeated signer")
+	}
+}
+
+func TestRing_Invalid(t *testing.T) {
+	message := []byte("message")
+	keys, pubs := newTestRing(t, 3)
+
+	if _, err := ring.Sign(newTestKey(t), pubs, message, rand.Reader); !errors.Is(err, ring.ErrKeyNotInRing) {
+		t.Fatalf("expected %v, got %v", ring.ErrKeyNotInRing, err)
+	}
+
+	if _, err := ring.SignLinkable(keys[0], nil, message, rand.Reader); !errors.Is(err, ring.ErrKeyNotInRing) {
+		t.Fatalf("expected %v, got %v", ring.ErrKeyNotInRing, err)
+	}
+
+	if _, err := ring.Sign(keys[0], pubs, message, bytes.NewReader(nil)); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrRandomSource, err)
+	}
+
+	if _, err := ring.SignLinkable(keys[0], pubs, message, nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrNilRandomSource, err)
+	}
+
+	sig, err := ring.Sign(keys[1], pubs, message, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Other rings, reordered rings, and tampered responses are rejected.
+	_, others := newTestRing(t, 3)
+	if ring.Verify(others, message, sig) ||
+		ring.Verify([]*secp256k1.PublicKey{pubs[1], pubs[0], pubs[2]}, message, sig) ||
+		ring.Verify(pubs[:2], message, sig) ||
+		ring.Verify(nil, message, sig) ||
+		ring.Verify(pubs, message, nil) {
+		t.Fatal("expected invalid signature")
+	}
+
+	sig.S[2].Add(secp256k1.NewScalar().One())
+	if ring.Verify(pubs, message, sig) {
+		t.Fatal("expected a tampered signature to be rejected")
+	}
+
+	decoded := new(ring.Signature)
+	if err = decoded.Decode(sig.Encode()[1:]); !errors.Is(err, ring.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ring.ErrInvalidSignature, err)
+	}
+
+	if err = decoded.Decode(bytes.Repeat([]byte{0xff}, 64)); !errors.Is(err, ring.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ring.ErrInvalidSignature, err)
+	}
+}
+
+func TestRing_Linkable(t *testing.T) {
+	message := []byte("vote")
+	keys, pubs := newTestRing(t, 4)
+
+	sig1, err := ring.SignLinkable(keys[2], pubs, message, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ring.VerifyLinkable(pubs, message, sig1) {
+		t.Fatal("expected valid signature")
+	}
+
+	if sig1.KeyImage.Equal(ring.KeyImage(keys[2])) != 1 {
+		t.Fatal("unexpected key image")
+	}
+
+	// The same signer is linked across messages and rings, other signers aren't.
+	_, others := newTestRing(t, 2)
+	ring2 := append(others, pubs[2])
+
+	sig2, err := ring.SignLinkable(keys[2], ring2, []byte("another vote"), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig3, err := ring.SignLinkable(keys[0], pubs, message, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ring.VerifyLinkable(ring2, []byte("another vote"), sig2) || !ring.VerifyLinkable(pubs, message, sig3) {
+		t.Fatal("expected valid signatures")
+	}
+
+	if !ring.Linked(sig1, sig2) || ring.Linked(sig1, sig3) {
+		t.Fatal("unexpected linkability")
+	}
+
+	// The key image can't be swapped for another.
+	forged := *sig1
+	forged.KeyImage = sig3.KeyImage
+
+	if ring.VerifyLinkable(pubs, message, &forged) {
+		t.Fatal("expected a swapped key image to be rejected")
+	}
+
+	forged.KeyImage = secp256k1.NewElement()
+	if ring.VerifyLinkable(pubs, message, &forged) || ring.VerifyLinkable(pubs, []byte("other"), sig1) {
+		t.Fatal("expected invalid signature")
+	}
+
+	// Encoding.
+	decoded := new(ring.LinkableSignature)
+	if err = decoded.Decode(sig1.Encode()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ring.VerifyLinkable(pubs, message, decoded) || !ring.Linked(decoded, sig1) {
+		t.Fatal("expected valid decoded signature")
+	}
+
+	if err = decoded.Decode(sig1.Encode()[:40]); !errors.Is(err, ring.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ring.ErrInvalidSignature, err)
+	}
+
+	if err = decoded.Decode(make([]byte, 10)); !errors.Is(err, ring.ErrInvalidSignature) {
+		t.Fatalf("expected %v, got %v", ring.ErrInvalidSignature, err)
+	}
+}
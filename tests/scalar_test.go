@@ -10,6 +10,9 @@ package secp256k1_test
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -17,6 +20,10 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/bytemare/hash"
+	"github.com/bytemare/hash2curve"
+	"golang.org/x/crypto/sha3"
+
 	"github.com/bytemare/secp256k1"
 )
 
@@ -80,6 +87,57 @@ func TestScalar_SetInt(t *testing.T) {
 	}
 }
 
+func TestScalar_SetBytesMod(t *testing.T) {
+	order := new(big.Int).SetBytes(secp256k1.Order())
+
+	for _, length := range []int{0, 1, 31, 32, 33, 48, 64, 100} {
+		in := make([]byte, length)
+		if _, err := rand.Read(in); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := new(big.Int).SetBytes(in)
+		expected.Mod(expected, order)
+
+		s := secp256k1.NewScalar().SetBytesMod(in)
+		if new(big.Int).SetBytes(s.Encode()).Cmp(expected) != 0 {
+			t.Fatalf("unexpected reduction for length %d", length)
+		}
+	}
+
+	// The order reduces to 0, and the order + 1 to 1.
+	if !secp256k1.NewScalar().SetBytesMod(secp256k1.Order()).IsZero() {
+		t.Fatal("expected zero")
+	}
+
+	orderPlusOne := order.Add(order, big.NewInt(1)).Bytes()
+	if secp256k1.NewScalar().SetBytesMod(orderPlusOne).Equal(secp256k1.NewScalar().One()) != 1 {
+		t.Fatal("expected one")
+	}
+}
+
+func TestScalar_FromHash(t *testing.T) {
+	h := sha512.New()
+	h.Write([]byte("transcript"))
+
+	digest := h.Sum(nil)
+	expected := secp256k1.NewScalar().SetBytesMod(digest)
+
+	if secp256k1.NewScalar().FromHash(h).Equal(expected) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The hash state is left untouched and can still absorb data.
+	if !bytes.Equal(h.Sum(nil), digest) {
+		t.Fatal("expected FromHash to leave the hash state untouched")
+	}
+
+	h.Write([]byte("more"))
+	if secp256k1.NewScalar().FromHash(h).Equal(expected) == 1 {
+		t.Fatal("expected a different scalar after absorbing more data")
+	}
+}
+
 func TestScalar_EncodedLength(t *testing.T) {
 	encodedScalar := secp256k1.NewScalar().Random().Encode()
 	if len(encodedScalar) != scalarLength {
@@ -96,24 +154,24 @@ func TestScalar_Decode_OutOfBounds(t *testing.T) {
 	encoded := make([]byte, scalarLength-1)
 	big.NewInt(1).FillBytes(encoded)
 
-	expected := errors.New("invalid scalar length")
-	if err := secp256k1.NewScalar().Decode(encoded); err == nil || err.Error() != expected.Error() {
+	expected := secp256k1.ErrInvalidScalarLength
+	if err := secp256k1.NewScalar().Decode(encoded); !errors.Is(err, expected) {
 		t.Errorf("expected error %q, got %v", expected, err)
 	}
 
 	encoded = make([]byte, scalarLength+1)
 	big.NewInt(1).FillBytes(encoded)
 
-	expected = errors.New("invalid scalar length")
-	if err := secp256k1.NewScalar().Decode(encoded); err == nil || err.Error() != expected.Error() {
+	expected = secp256k1.ErrInvalidScalarLength
+	if err := secp256k1.NewScalar().Decode(encoded); !errors.Is(err, expected) {
 		t.Errorf("expected error %q, got %v", expected, err)
 	}
 
 	// Decode the order
 	order := secp256k1.Order()
 
-	expected = errors.New("scalar too big")
-	if err := secp256k1.NewScalar().Decode(order); err == nil || err.Error() != expected.Error() {
+	expected = secp256k1.ErrScalarTooBig
+	if err := secp256k1.NewScalar().Decode(order); !errors.Is(err, expected) {
 		t.Errorf("expected error %q, got %v", expected, err)
 	}
 
@@ -122,8 +180,8 @@ func TestScalar_Decode_OutOfBounds(t *testing.T) {
 	order1 := new(big.Int).SetBytes(order)
 	order1.Add(order1, big.NewInt(1)).FillBytes(encoded)
 
-	expected = errors.New("scalar too big")
-	if err := secp256k1.NewScalar().Decode(order1.Bytes()); err == nil || err.Error() != expected.Error() {
+	expected = secp256k1.ErrScalarTooBig
+	if err := secp256k1.NewScalar().Decode(order1.Bytes()); !errors.Is(err, expected) {
 		t.Errorf("expected error %q, got %v", expected, err)
 	}
 }
@@ -173,6 +231,84 @@ func TestScalar_Random(t *testing.T) {
 	}
 }
 
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestScalar_RandomFrom(t *testing.T) {
+	// A deterministic source yields the same scalar.
+	seed := bytes.Repeat([]byte{0x42}, 2*scalarLength)
+
+	s1, err := secp256k1.NewScalar().RandomFrom(bytes.NewReader(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s2, err := secp256k1.NewScalar().RandomFrom(bytes.NewReader(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s1.Equal(s2) != 1 || s1.IsZero() {
+		t.Fatal("expected equal non-zero scalars from the same source")
+	}
+
+	if s1.Hex() != hex.EncodeToString(seed[:scalarLength]) {
+		t.Fatalf("unexpected scalar %s", s1.Hex())
+	}
+
+	// crypto/rand.
+	if _, err = secp256k1.NewScalar().RandomFrom(rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+
+	// Failures are returned and leave the receiver untouched.
+	s := secp256k1.NewScalar().One()
+
+	if _, err = s.RandomFrom(nil); !errors.Is(err, secp256k1.ErrNilRandomSource) {
+		t.Fatalf("expected error on nil reader, got %v", err)
+	}
+
+	if _, err = s.RandomFrom(bytes.NewReader([]byte{1, 2, 3})); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatal("expected error on short reader")
+	}
+
+	if _, err = s.RandomFrom(zeroReader{}); !errors.Is(err, secp256k1.ErrRandomSource) {
+		t.Fatalf("expected error on zero reader, got %v", err)
+	}
+
+	if s.Equal(secp256k1.NewScalar().One()) != 1 {
+		t.Fatal("expected receiver to be untouched on error")
+	}
+}
+
+func TestScalar_RandomFrom_RejectionSampling(t *testing.T) {
+	// Non-canonical candidates are rejected instead of being reduced.
+	valid := bytes.Repeat([]byte{0x42}, scalarLength)
+	source := append(bytes.Repeat([]byte{0xff}, scalarLength), secp256k1.Order()...)
+	source = append(source, valid...)
+
+	s, err := secp256k1.NewScalar().RandomFrom(bytes.NewReader(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(s.Encode(), valid) {
+		t.Fatalf("expected the first canonical candidate, got %s", s.Hex())
+	}
+
+	// A source that never yields canonical values.
+	if _, err = secp256k1.NewScalar().RandomFrom(bytes.NewReader(bytes.Repeat([]byte{0xff}, 100*scalarLength))); !errors.Is(
+		err,
+		secp256k1.ErrRandomSource,
+	) {
+		t.Fatalf("expected error %q, got %v", secp256k1.ErrRandomSource, err)
+	}
+}
+
 func TestScalar_Equal(t *testing.T) {
 	zero := secp256k1.NewScalar().Zero()
 	zero2 := secp256k1.NewScalar().Zero()
@@ -230,6 +366,220 @@ func TestScalar_LessOrEqual(t *testing.T) {
 	}
 }
 
+func TestScalar_LessOrEqual_Regression(t *testing.T) {
+	// A smaller scalar can have greater bytes than a larger one after their first difference, e.g. 0x00ff < 0x0100,
+	// which a byte-by-byte comparison that does not stop at the first difference gets wrong. The ordering of FROST
+	// identifiers relies on this.
+	for _, c := range []struct {
+		s, r uint64
+	}{
+		{0xff, 0x100},
+		{0x01ff, 0x0200},
+		{0x00ff00ff, 0x01000000},
+		{1<<63 - 1, 1 << 63},
+	} {
+		s, r := secp256k1.NewScalar().SetUInt64(c.s), secp256k1.NewScalar().SetUInt64(c.r)
+
+		if s.LessOrEqual(r) != 1 || r.LessOrEqual(s) != 0 {
+			t.Fatalf("unexpected ordering of %#x and %#x", c.s, c.r)
+		}
+	}
+
+	// Random scalars, against their integer ordering, and s < s + 1 for s ending in 0xff.
+	for range 100 {
+		s, r := secp256k1.NewScalar().Random(), secp256k1.NewScalar().Random()
+		expected := 0
+		if new(big.Int).SetBytes(s.Encode()).Cmp(new(big.Int).SetBytes(r.Encode())) <= 0 {
+			expected = 1
+		}
+
+		if s.LessOrEqual(r) != expected {
+			t.Fatalf("unexpected ordering of %s and %s", s.Hex(), r.Hex())
+		}
+
+		enc := s.Encode()
+		enc[0], enc[len(enc)-1] = 0, 0xff
+
+		if err := s.Decode(enc); err != nil {
+			t.Fatal(err)
+		}
+
+		if s.LessOrEqual(s.Copy().Add(secp256k1.NewScalar().One())) != 1 {
+			t.Fatalf("expected %s < %s + 1", s.Hex(), s.Hex())
+		}
+	}
+}
+
+func TestScalar_IsHigh(t *testing.T) {
+	half := new(big.Int).SetBytes(secp256k1.Order())
+	half.Rsh(half, 1)
+
+	encoded := make([]byte, scalarLength)
+	low := secp256k1.NewScalar()
+	if err := low.Decode(half.FillBytes(encoded)); err != nil {
+		t.Fatal(err)
+	}
+
+	if low.IsHigh() {
+		t.Fatal("expected (n-1)/2 to not be high")
+	}
+
+	high := low.Copy().Add(secp256k1.NewScalar().One())
+	if !high.IsHigh() {
+		t.Fatal("expected (n+1)/2 to be high")
+	}
+
+	if secp256k1.NewScalar().Zero().IsHigh() || secp256k1.NewScalar().One().IsHigh() {
+		t.Fatal("expected small scalars to not be high")
+	}
+
+	if !secp256k1.NewScalar().MinusOne().IsHigh() {
+		t.Fatal("expected n-1 to be high")
+	}
+}
+
+func TestScalar_NegateIfHigh(t *testing.T) {
+	// A high scalar is negated.
+	minusOne := secp256k1.NewScalar().MinusOne()
+	if minusOne.NegateIfHigh().Equal(secp256k1.NewScalar().One()) != 1 {
+		t.Fatal("expected -(n-1) = 1")
+	}
+
+	// A low scalar is left untouched.
+	one := secp256k1.NewScalar().One()
+	if one.NegateIfHigh().Equal(secp256k1.NewScalar().One()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	for range 100 {
+		s := secp256k1.NewScalar().Random()
+		cpy := s.Copy()
+
+		if s.NegateIfHigh().IsHigh() {
+			t.Fatal("expected low scalar after normalization")
+		}
+
+		if s.Equal(cpy) != 1 && !s.Add(cpy).IsZero() {
+			t.Fatal("expected either the same scalar or its negation")
+		}
+	}
+}
+
+func TestScalar_Bit(t *testing.T) {
+	for range 10 {
+		s := secp256k1.NewScalar().Random()
+		ref := new(big.Int).SetBytes(s.Encode())
+
+		for i := -1; i <= scalarLength*8+1; i++ {
+			expected := uint8(0)
+			if i >= 0 {
+				expected = uint8(ref.Bit(i))
+			}
+
+			if b := s.Bit(i); b != expected {
+				t.Fatalf("unexpected bit %d: want %d, got %d", i, expected, b)
+			}
+		}
+	}
+}
+
+func TestScalar_Window(t *testing.T) {
+	s := secp256k1.NewScalar().Random()
+	ref := new(big.Int).SetBytes(s.Encode())
+
+	for width := 1; width <= 8; width++ {
+		mask := big.NewInt(1<<width - 1)
+
+		for offset := 0; offset < scalarLength*8+8; offset++ {
+			expected := new(big.Int).Rsh(ref, uint(offset))
+			expected.And(expected, mask)
+
+			if w := s.Window(offset, width); uint64(w) != expected.Uint64() {
+				t.Fatalf("unexpected window at %d of width %d: want %d, got %d", offset, width, expected, w)
+			}
+		}
+	}
+
+	if s.Window(-1, 4) != 0 {
+		t.Fatal("expected 0 for negative offset")
+	}
+
+	for _, width := range []int{0, 9} {
+		if panics, err := expectPanic(errors.New("invalid window width"), func() {
+			_ = s.Window(0, width)
+		}); !panics {
+			t.Error(fmt.Errorf("%s: %w)", errNoPanic, err))
+		}
+	}
+}
+
+func nafToBigInt(naf []int8) *big.Int {
+	res := new(big.Int)
+	digit := new(big.Int)
+
+	for i := len(naf) - 1; i >= 0; i-- {
+		res.Lsh(res, 1)
+		res.Add(res, digit.SetInt64(int64(naf[i])))
+	}
+
+	return res
+}
+
+func testNAF(t *testing.T, s *secp256k1.Scalar, w int) {
+	naf := s.NAF(w)
+	if len(naf) != scalarLength*8+1 {
+		t.Fatalf("unexpected NAF length %d", len(naf))
+	}
+
+	bound := 1 << (w - 1)
+	lastNonZero := -w
+
+	for i, d := range naf {
+		if d == 0 {
+			continue
+		}
+
+		if d%2 == 0 || int(d) >= bound || int(d) <= -bound {
+			t.Fatalf("invalid NAF digit %d for w = %d", d, w)
+		}
+
+		if i-lastNonZero < w {
+			t.Fatalf("adjacent non-zero digits at %d and %d for w = %d", lastNonZero, i, w)
+		}
+
+		lastNonZero = i
+	}
+
+	if nafToBigInt(naf).Cmp(new(big.Int).SetBytes(s.Encode())) != 0 {
+		t.Fatalf("NAF recoding does not yield the scalar for w = %d", w)
+	}
+}
+
+func TestScalar_NAF(t *testing.T) {
+	scalars := []*secp256k1.Scalar{
+		secp256k1.NewScalar().Zero(),
+		secp256k1.NewScalar().One(),
+		secp256k1.NewScalar().SetUInt64(7),
+		secp256k1.NewScalar().MinusOne(),
+		secp256k1.NewScalar().Random(),
+		secp256k1.NewScalar().Random(),
+	}
+
+	for w := 2; w <= 8; w++ {
+		for _, s := range scalars {
+			testNAF(t, s, w)
+		}
+	}
+
+	for _, w := range []int{-1, 0, 1, 9} {
+		if panics, err := expectPanic(errors.New("invalid NAF window width"), func() {
+			_ = secp256k1.NewScalar().One().NAF(w)
+		}); !panics {
+			t.Error(fmt.Errorf("%s: %w)", errNoPanic, err))
+		}
+	}
+}
+
 func TestScalar_Add(t *testing.T) {
 	r := secp256k1.NewScalar().Random()
 	cpy := r.Copy()
@@ -370,6 +720,38 @@ func TestScalar_Pow(t *testing.T) {
 	}
 }
 
+func TestScalar_PowUint64(t *testing.T) {
+	s := secp256k1.NewScalar().Random()
+
+	// s**0 = 1
+	if s.Copy().PowUint64(0).Equal(secp256k1.NewScalar().One()) != 1 {
+		t.Fatal("expected s**0 = 1")
+	}
+
+	// s**1 = s
+	if s.Copy().PowUint64(1).Equal(s) != 1 {
+		t.Fatal("expected s**1 = s")
+	}
+
+	// 0**e = 0
+	if !secp256k1.NewScalar().PowUint64(5).IsZero() {
+		t.Fatal("expected 0**5 = 0")
+	}
+
+	// 5**7 = 78125
+	if secp256k1.NewScalar().SetUInt64(5).PowUint64(7).Equal(secp256k1.NewScalar().SetUInt64(78125)) != 1 {
+		t.Fatal("expected 5**7 = 78125")
+	}
+
+	// Same results as Pow.
+	exp := secp256k1.NewScalar()
+	for _, e := range []uint64{2, 3, 255, 513, 1<<64 - 1} {
+		if s.Copy().PowUint64(e).Equal(s.Copy().Pow(exp.SetUInt64(e))) != 1 {
+			t.Fatalf("expected equality with Pow for exponent %d", e)
+		}
+	}
+}
+
 func TestScalar_Invert(t *testing.T) {
 	s := secp256k1.NewScalar().Random()
 	sqr := s.Copy().Multiply(s)
@@ -385,6 +767,20 @@ func TestScalar_Invert(t *testing.T) {
 	if s.One().Equal(square.Multiply(inv)) != 1 {
 		t.Fatal(errExpectedEquality)
 	}
+
+	// 1/0 = 0
+	if !secp256k1.NewScalar().Zero().Invert().IsZero() {
+		t.Fatal("expected the inverse of zero to be zero")
+	}
+
+	// 1/1 = 1 and 1/-1 = -1
+	if secp256k1.NewScalar().One().Invert().Equal(secp256k1.NewScalar().One()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if secp256k1.NewScalar().MinusOne().Invert().Equal(secp256k1.NewScalar().MinusOne()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
 }
 
 func TestScalar_HashToScalar(t *testing.T) {
@@ -406,6 +802,110 @@ func TestScalar_HashToScalar(t *testing.T) {
 	if s.Equal(ref) != 1 {
 		t.Error(errExpectedEquality)
 	}
+
+	// Hashing into an existing receiver.
+	s = secp256k1.NewScalar().Random()
+	if s.Hash(data, dst) != s || s.Equal(ref) != 1 {
+		t.Error(errExpectedEquality)
+	}
+}
+
+func TestScalar_HashToScalars(t *testing.T) {
+	data := []byte("input data")
+	dst := []byte("domain separation tag")
+	order := new(big.Int).SetBytes(secp256k1.Order())
+
+	for _, count := range []uint{1, 2, 5, 170} {
+		scalars := secp256k1.HashToScalars(data, dst, count)
+		if uint(len(scalars)) != count {
+			t.Fatalf("expected %d scalars, got %d", count, len(scalars))
+		}
+
+		uniform := hash2curve.ExpandXMD(crypto.SHA256, data, dst, count*48)
+
+		for i, s := range scalars {
+			expected := new(big.Int).SetBytes(uniform[i*48 : (i+1)*48])
+			expected.Mod(expected, order)
+
+			if new(big.Int).SetBytes(s.Encode()).Cmp(expected) != 0 {
+				t.Fatalf("unexpected scalar %d for count %d", i, count)
+			}
+
+			for _, other := range scalars[:i] {
+				if s.Equal(other) == 1 {
+					t.Fatal("unexpected equality between scalars")
+				}
+			}
+		}
+	}
+
+	// The first output for a count of 1 is HashToScalar.
+	if secp256k1.HashToScalars(data, dst, 1)[0].Equal(secp256k1.HashToScalar(data, dst)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if len(secp256k1.HashToScalars(data, dst, 0)) != 0 {
+		t.Fatal("expected no scalars")
+	}
+
+	if panics, _ := expectPanic(nil, func() {
+		_ = secp256k1.HashToScalars(data, dst, 171)
+	}); !panics {
+		t.Fatal(errNoPanic)
+	}
+}
+
+// expandMessageXOF is a straightforward implementation of expand_message_xof from RFC 9380, for short DSTs.
+func expandMessageXOF(shake sha3.ShakeHash, input, dst []byte, length int) []byte {
+	_, _ = shake.Write(input)
+	_, _ = shake.Write([]byte{byte(length >> 8), byte(length)})
+	_, _ = shake.Write(dst)
+	_, _ = shake.Write([]byte{byte(len(dst))})
+
+	out := make([]byte, length)
+	_, _ = shake.Read(out)
+
+	return out
+}
+
+func TestScalar_HashToScalarXOF(t *testing.T) {
+	data := []byte("input data")
+	dst := []byte("domain separation tag")
+	order := new(big.Int).SetBytes(secp256k1.Order())
+
+	for _, test := range []struct {
+		shake sha3.ShakeHash
+		id    hash.Hash
+	}{
+		{id: hash.SHAKE128, shake: sha3.NewShake128()},
+		{id: hash.SHAKE256, shake: sha3.NewShake256()},
+	} {
+		expected := new(big.Int).SetBytes(expandMessageXOF(test.shake, data, dst, 48))
+		expected.Mod(expected, order)
+
+		s := secp256k1.HashToScalarXOF(test.id, data, dst)
+		if new(big.Int).SetBytes(s.Encode()).Cmp(expected) != 0 {
+			t.Fatalf("unexpected scalar for %s", test.id)
+		}
+
+		if s.Equal(secp256k1.HashToScalar(data, dst)) == 1 {
+			t.Fatal("expected XOF and XMD outputs to differ")
+		}
+	}
+
+	// Fixed-output hash functions are rejected.
+	if panics, err := expectPanic(errors.New("hash function is not an extendable-output function"), func() {
+		_ = secp256k1.HashToScalarXOF(hash.SHA256, data, dst)
+	}); !panics {
+		t.Error(fmt.Errorf("%s: %w)", errNoPanic, err))
+	}
+
+	// Empty DST.
+	if panics, err := expectPanic(errors.New("zero-length DST"), func() {
+		_ = secp256k1.HashToScalarXOF(hash.SHAKE256, data, nil)
+	}); !panics {
+		t.Error(fmt.Errorf("%s: %w)", errNoPanic, err))
+	}
 }
 
 func TestScalar_HashToScalar_NoDST(t *testing.T) {
@@ -474,3 +974,74 @@ func expectPanic(expectedError error, f func()) (bool, error) {
 
 	return true, nil
 }
+
+func TestScalar_StrictNilOperands(t *testing.T) {
+	s := secp256k1.NewScalar().Random()
+
+	// Nil operands are absorbed by default.
+	if s.Copy().Add(nil).Equal(s) != 1 || !s.Copy().Multiply(nil).IsZero() || !s.Copy().Set(nil).IsZero() {
+		t.Fatal("expected nil operands to be absorbed by default")
+	}
+
+	// The Strict variants reject them, and leave the receiver untouched.
+	r := s.Copy()
+
+	for name, err := range map[string]error{
+		"Add":      r.AddStrict(nil),
+		"Subtract": r.SubtractStrict(nil),
+		"Multiply": r.MultiplyStrict(nil),
+		"Pow":      r.PowStrict(nil),
+		"Set":      r.SetStrict(nil),
+	} {
+		if !errors.Is(err, secp256k1.ErrNilOperand) {
+			t.Fatalf("%s: expected error %q, got %v", name, secp256k1.ErrNilOperand, err)
+		}
+	}
+
+	if r.Equal(s) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// They behave like their lenient counterparts on non-nil operands.
+	two := secp256k1.NewScalar().SetUInt64(2)
+	if r.AddStrict(s) != nil || r.SubtractStrict(s) != nil || r.MultiplyStrict(two) != nil ||
+		r.PowStrict(two) != nil || r.Equal(s.Copy().Multiply(two).Pow(two)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if r.SetStrict(s) != nil || r.Equal(s) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestScalar_MultiplyReference(t *testing.T) {
+	n := new(big.Int).SetBytes(secp256k1.Order())
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Sub(n, big.NewInt(1)),
+		new(big.Int).Sub(n, big.NewInt(2)),
+		new(big.Int).Rsh(n, 1),
+		new(big.Int).Lsh(big.NewInt(1), 255),
+		new(big.Int).Lsh(big.NewInt(1), 129),
+	}
+
+	for range 64 {
+		values = append(values, new(big.Int).SetBytes(secp256k1.NewScalar().Random().Encode()))
+	}
+
+	// Multiplication is checked against big.Int arithmetic for all pairs. Run with -tags purego for the generic
+	// backend.
+	for _, x := range values {
+		for _, y := range values {
+			a := secp256k1.NewScalar().SetBytesMod(x.Bytes())
+			b := secp256k1.NewScalar().SetBytesMod(y.Bytes())
+			ref := new(big.Int).Mul(x, y)
+			ref.Mod(ref, n)
+
+			if res := a.Multiply(b); !bytes.Equal(res.Encode(), ref.FillBytes(make([]byte, 32))) {
+				t.Fatalf("%x * %x: expected %x, got %x", x, y, ref, res.Encode())
+			}
+		}
+	}
+}
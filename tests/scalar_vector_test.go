@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+func randomScalarVector(n int) secp256k1.ScalarVector {
+	v := secp256k1.NewScalarVector(n)
+	for _, s := range v {
+		s.Random()
+	}
+
+	return v
+}
+
+func TestScalarVector_New(t *testing.T) {
+	v := secp256k1.NewScalarVector(4)
+	if len(v) != 4 {
+		t.Fatalf("unexpected length %d", len(v))
+	}
+
+	for _, s := range v {
+		if !s.IsZero() {
+			t.Fatal("expected zero scalar")
+		}
+	}
+}
+
+func TestScalarVector_Arithmetic(t *testing.T) {
+	n := 8
+	a := randomScalarVector(n)
+	b := randomScalarVector(n)
+	s := secp256k1.NewScalar().Random()
+
+	sum := a.Copy().Add(b)
+	diff := a.Copy().Subtract(b)
+	prod := a.Copy().Multiply(b)
+	broadAdd := a.Copy().AddScalar(s)
+	broadMul := a.Copy().MultiplyScalar(s)
+	neg := a.Copy().Negate()
+
+	for i := range n {
+		if sum[i].Equal(a[i].Copy().Add(b[i])) != 1 {
+			t.Fatal("unexpected Add result")
+		}
+
+		if diff[i].Equal(a[i].Copy().Subtract(b[i])) != 1 {
+			t.Fatal("unexpected Subtract result")
+		}
+
+		if prod[i].Equal(a[i].Copy().Multiply(b[i])) != 1 {
+			t.Fatal("unexpected Multiply result")
+		}
+
+		if broadAdd[i].Equal(a[i].Copy().Add(s)) != 1 {
+			t.Fatal("unexpected AddScalar result")
+		}
+
+		if broadMul[i].Equal(a[i].Copy().Multiply(s)) != 1 {
+			t.Fatal("unexpected MultiplyScalar result")
+		}
+
+		if !neg[i].Add(a[i]).IsZero() {
+			t.Fatal("unexpected Negate result")
+		}
+	}
+
+	// The copies must not affect the originals.
+	if a.Equal(sum) == 1 || a.Equal(a.Copy()) != 1 {
+		t.Fatal("unexpected vector equality")
+	}
+}
+
+func TestScalarVector_InnerProduct(t *testing.T) {
+	n := 16
+	a := randomScalarVector(n)
+	b := randomScalarVector(n)
+
+	expected := secp256k1.NewScalar()
+	for i := range n {
+		expected.Add(a[i].Copy().Multiply(b[i]))
+	}
+
+	if a.InnerProduct(b).Equal(expected) != 1 {
+		t.Fatal("unexpected inner product")
+	}
+
+	if a.Copy().Multiply(b).Sum().Equal(expected) != 1 {
+		t.Fatal("unexpected sum")
+	}
+
+	if !secp256k1.NewScalarVector(0).InnerProduct(nil).IsZero() {
+		t.Fatal("expected zero inner product for empty vectors")
+	}
+}
+
+func TestScalarVector_Powers(t *testing.T) {
+	x := secp256k1.NewScalar().Random()
+	powers := secp256k1.ScalarPowers(x, 5)
+	exp := secp256k1.NewScalar()
+
+	for i, p := range powers {
+		if p.Equal(x.Copy().Pow(exp.SetUInt64(uint64(i)))) != 1 {
+			t.Fatalf("unexpected power %d", i)
+		}
+	}
+
+	if len(secp256k1.ScalarPowers(x, 0)) != 0 {
+		t.Fatal("expected empty vector")
+	}
+}
+
+func TestScalarVector_LengthMismatch(t *testing.T) {
+	a := randomScalarVector(3)
+	b := randomScalarVector(4)
+	expected := errors.New("vectors have different lengths")
+
+	for _, f := range []func(){
+		func() { a.Add(b) },
+		func() { a.Subtract(b) },
+		func() { a.Multiply(b) },
+		func() { a.InnerProduct(b) },
+	} {
+		if panics, err := expectPanic(expected, f); !panics {
+			t.Fatal(fmt.Errorf("%s: %w)", errNoPanic, err))
+		}
+	}
+
+	if a.Equal(b) != 0 {
+		t.Fatal("unexpected equality")
+	}
+}
+
+func TestElementVector(t *testing.T) {
+	v := secp256k1.NewElementVector(3)
+	for _, e := range v {
+		if !e.IsIdentity() {
+			t.Fatal("expected identity")
+		}
+	}
+
+	g := secp256k1.DeriveGenerators([]byte("element vector"), 3)
+	w := secp256k1.ElementVector(g).Copy()
+	s := randomScalarVector(3)
+
+	expected := secp256k1.NewElement()
+	for i := range w {
+		expected.Add(g[i].Copy().Multiply(s[i]))
+	}
+
+	if w.MultiScalarMult(s).Equal(expected) != 1 || w.MultiScalarMultVartime(s).Equal(expected) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if w.Copy().Multiply(s).Sum().Equal(expected) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	k := secp256k1.NewScalar().Random()
+	if w.Copy().MultiplyScalar(k).Sum().Equal(w.Sum().Multiply(k)) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if v.Add(w).Equal(w) != 1 || v.Equal(w[:2]) != 0 || w.Equal(g) != 1 {
+		t.Fatal("unexpected equality")
+	}
+
+	// Copies are deep.
+	c := w.Copy()
+	c[0].Double()
+
+	if c.Equal(w) == 1 {
+		t.Fatal("expected a deep copy")
+	}
+
+	if panics, _ := hasPanic(func() { w.Add(w[:2]) }); !panics {
+		t.Fatal(errNoPanic)
+	}
+
+	if panics, _ := hasPanic(func() { w.MultiScalarMult(s[:2]) }); !panics {
+		t.Fatal(errNoPanic)
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+func TestBuildDST(t *testing.T) {
+	dst := secp256k1.BuildDST("QUUX", 1, 2, secp256k1.H2CSECP256K1)
+	if string(dst) != "QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_" {
+		t.Fatalf("unexpected DST %q", dst)
+	}
+}
+
+func TestLookupSuite(t *testing.T) {
+	input := []byte("input data")
+	dst := []byte("domain separation tag")
+
+	for _, test := range []struct {
+		ref func(input, dst []byte) *secp256k1.Element
+		id  string
+		ro  bool
+	}{
+		{id: secp256k1.H2CSECP256K1, ref: secp256k1.HashToGroup, ro: true},
+		{id: secp256k1.E2CSECP256K1, ref: secp256k1.EncodeToGroup},
+		{id: secp256k1.H2CSECP256K1XOF, ref: secp256k1.HashToGroupXOF, ro: true},
+		{id: secp256k1.E2CSECP256K1XOF, ref: secp256k1.EncodeToGroupXOF},
+		{id: "secp256k1_XMD:SHA-512_SSWU_RO_", ref: func(input, dst []byte) *secp256k1.Element {
+			return secp256k1.HashToGroupXMD(crypto.SHA512, input, dst)
+		}, ro: true},
+	} {
+		t.Run(test.id, func(t *testing.T) {
+			s, err := secp256k1.LookupSuite(test.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if s.ID != test.id || s.RandomOracle != test.ro {
+				t.Fatalf("unexpected suite %q, random oracle %v", s.ID, s.RandomOracle)
+			}
+
+			if s.Map(input, dst).Equal(test.ref(input, dst)) != 1 {
+				t.Fatal(errExpectedEquality)
+			}
+
+			// A reduced oversize DST yields the same result as the original one.
+			long := bytes.Repeat([]byte("a"), 300)
+			if s.Map(input, s.ReduceDST(long)).Equal(s.Map(input, long)) != 1 {
+				t.Fatal(errExpectedEquality)
+			}
+
+			if !bytes.Equal(s.ReduceDST(dst), dst) {
+				t.Fatal("short DSTs must not be reduced")
+			}
+		})
+	}
+
+	if _, err := secp256k1.LookupSuite("secp256k1_XMD:MD5_SSWU_RO_"); !errors.Is(err, secp256k1.ErrUnknownSuite) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrUnknownSuite, err)
+	}
+
+	if len(secp256k1.Suites()) < 6 {
+		t.Fatal("expected at least the SHA-256, SHA-512 and SHAKE256 suites")
+	}
+}
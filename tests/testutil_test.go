@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/testutil"
+)
+
+func TestTestutil(t *testing.T) {
+	elements := []*secp256k1.Element{
+		secp256k1.NewElement(),
+		secp256k1.Base(),
+		secp256k1.ScalarBaseMult(secp256k1.NewScalar().Random()),
+	}
+
+	scalars := []*secp256k1.Scalar{
+		secp256k1.NewScalar(),
+		secp256k1.NewScalar().One(),
+		secp256k1.NewScalar().Random(),
+	}
+
+	encodings := make([]string, len(elements))
+	for i, e := range elements {
+		encodings[i] = e.Hex()
+	}
+
+	testutil.Check(t, elements, scalars)
+
+	// The checks don't modify their arguments.
+	for i, e := range elements {
+		if e.Hex() != encodings[i] {
+			t.Fatalf("element %d was modified", i)
+		}
+	}
+
+	if !scalars[0].IsZero() || scalars[1].Equal(secp256k1.NewScalar().One()) != 1 {
+		t.Fatal("scalars were modified")
+	}
+
+	h := secp256k1.HashToGroup([]byte("input"), []byte("github.com/bytemare/secp256k1:TestTestutil"))
+	s := secp256k1.HashToScalar([]byte("input"), []byte("github.com/bytemare/secp256k1:TestTestutil"))
+
+	for _, err := range []error{
+		testutil.CheckGroupLaws(h, elements[1], elements[0]),
+		testutil.CheckScalarMultiplication(h, elements[2], s, secp256k1.NewScalar().MinusOne()),
+		testutil.CheckFieldLaws(s, scalars[2], scalars[0]),
+		testutil.CheckElementEncoding(h),
+		testutil.CheckScalarEncoding(s),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
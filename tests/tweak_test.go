@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+func TestTweak_KeyPair(t *testing.T) {
+	d := secp256k1.NewScalar().Random()
+	p := secp256k1.ScalarBaseMult(d)
+	tweak := secp256k1.NewScalar().Random()
+
+	// Add.
+	d1, p1 := d.Copy(), p.Copy()
+	if err := d1.AddTweak(tweak); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p1.AddTweak(tweak); err != nil {
+		t.Fatal(err)
+	}
+
+	if d1.Equal(d.Copy().Add(tweak)) != 1 || p1.Equal(secp256k1.ScalarBaseMult(d1)) != 1 {
+		t.Fatal("expected matching tweaked keys")
+	}
+
+	// Multiply.
+	d2, p2 := d.Copy(), p.Copy()
+	if err := d2.MulTweak(tweak); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p2.MulTweak(tweak); err != nil {
+		t.Fatal(err)
+	}
+
+	if d2.Equal(d.Copy().Multiply(tweak)) != 1 || p2.Equal(secp256k1.ScalarBaseMult(d2)) != 1 {
+		t.Fatal("expected matching tweaked keys")
+	}
+
+	// A zero tweak is allowed for addition.
+	if err := d1.AddTweak(secp256k1.NewScalar()); err != nil || d1.Equal(d.Copy().Add(tweak)) != 1 {
+		t.Fatal("expected the zero tweak to leave the scalar unchanged")
+	}
+
+	if err := p1.AddTweak(secp256k1.NewScalar()); err != nil || p1.Equal(secp256k1.ScalarBaseMult(d1)) != 1 {
+		t.Fatal("expected the zero tweak to leave the element unchanged")
+	}
+}
+
+func TestTweak_Errors(t *testing.T) {
+	d := secp256k1.NewScalar().Random()
+	p := secp256k1.ScalarBaseMult(d)
+	negD := secp256k1.NewScalar().Subtract(d)
+
+	// Results that are zero or the identity.
+	if err := d.Copy().AddTweak(negD); !errors.Is(err, secp256k1.ErrInvalidTweak) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidTweak, err)
+	}
+
+	e := p.Copy()
+	if err := e.AddTweak(negD); !errors.Is(err, secp256k1.ErrInvalidTweak) || !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidTweak, err)
+	}
+
+	if e.Equal(p) != 1 {
+		t.Fatal("expected the receiver to be unchanged on error")
+	}
+
+	// Zero multiplicative tweaks.
+	if err := d.Copy().MulTweak(secp256k1.NewScalar()); !errors.Is(err, secp256k1.ErrInvalidTweak) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidTweak, err)
+	}
+
+	if err := p.Copy().MulTweak(secp256k1.NewScalar()); !errors.Is(err, secp256k1.ErrInvalidTweak) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidTweak, err)
+	}
+
+	// Nil tweaks.
+	if err := d.Copy().AddTweak(nil); !errors.Is(err, secp256k1.ErrInvalidTweak) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidTweak, err)
+	}
+
+	if err := p.Copy().MulTweak(nil); !errors.Is(err, secp256k1.ErrInvalidTweak) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidTweak, err)
+	}
+
+	// Invalid keys.
+	one := secp256k1.NewScalar().One()
+
+	if err := secp256k1.NewScalar().AddTweak(one); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+
+	if err := secp256k1.NewScalar().MulTweak(one); !errors.Is(err, secp256k1.ErrInvalidPrivateKey) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrInvalidPrivateKey, err)
+	}
+
+	if err := secp256k1.NewElement().AddTweak(one); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrIdentity, err)
+	}
+
+	if err := secp256k1.NewElement().MulTweak(one); !errors.Is(err, secp256k1.ErrIdentity) {
+		t.Fatalf("expected %v, got %v", secp256k1.ErrIdentity, err)
+	}
+}
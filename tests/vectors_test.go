@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/vectors"
+)
+
+// decodeHexElementAllowIdentity is like decodeHexElement, but accepts the encoding of the identity.
+func decodeHexElementAllowIdentity(t *testing.T, h string) *secp256k1.Element {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := secp256k1.NewElement()
+	if err = e.DecodeAllowIdentity(b); err != nil {
+		t.Fatal(err)
+	}
+
+	return e
+}
+
+func TestVectorsGenerate(t *testing.T) {
+	v := vectors.Generate([]byte("seed"), 8)
+
+	// Generation is deterministic, depends on the seed, and survives a JSON round trip.
+	if !reflect.DeepEqual(v, vectors.Generate([]byte("seed"), 8)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if reflect.DeepEqual(v.Scalars[1:], vectors.Generate([]byte("other"), 8).Scalars[1:]) {
+		t.Fatal("expected different vectors for different seeds")
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := new(vectors.Vectors)
+	if err = json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(v, decoded) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if v.Ciphersuite != secp256k1.H2CSECP256K1 || v.Scalars[0].A != secp256k1.NewScalar().Hex() ||
+		v.Points[0].Q != secp256k1.NewElement().Hex() {
+		t.Fatal("unexpected edge cases")
+	}
+}
+
+func TestVectorsConsistency(t *testing.T) {
+	v := vectors.Generate([]byte("seed"), 8)
+
+	for i, s := range v.Scalars {
+		a, b := decodeHexScalar(t, s.A), decodeHexScalar(t, s.B)
+
+		if decodeHexScalar(t, s.Sum).Subtract(b).Equal(a) != 1 ||
+			decodeHexScalar(t, s.Difference).Add(b).Equal(a) != 1 ||
+			!decodeHexScalar(t, s.Negation).Add(a).IsZero() ||
+			decodeHexScalar(t, s.Product).Equal(a.Copy().Multiply(b)) != 1 {
+			t.Fatalf("%d: inconsistent scalar vector", i)
+		}
+
+		if !a.IsZero() && decodeHexScalar(t, s.Inverse).Multiply(a).Equal(secp256k1.NewScalar().One()) != 1 {
+			t.Fatalf("%d: inconsistent scalar inverse", i)
+		}
+	}
+
+	for i, p := range v.Points {
+		k, pk, q := decodeHexScalar(t, p.K), decodeHexElementAllowIdentity(t, p.P), decodeHexElementAllowIdentity(t, p.Q)
+
+		if secp256k1.ScalarBaseMult(k).Equal(pk) != 1 ||
+			decodeHexElementAllowIdentity(t, p.Sum).Subtract(q).Equal(pk) != 1 ||
+			decodeHexElementAllowIdentity(t, p.Difference).Add(q).Equal(pk) != 1 ||
+			decodeHexElementAllowIdentity(t, p.Double).Equal(pk.Copy().Add(pk)) != 1 ||
+			!decodeHexElementAllowIdentity(t, p.Negation).Add(pk).IsIdentity() ||
+			decodeHexElementAllowIdentity(t, p.Product).Equal(q.Copy().Multiply(k)) != 1 {
+			t.Fatalf("%d: inconsistent point vector", i)
+		}
+	}
+
+	for i, h := range v.HashToCurve {
+		msg, err := hex.DecodeString(h.Message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decodeHexElementAllowIdentity(t, h.P).Equal(secp256k1.HashToGroup(msg, []byte(v.DST))) != 1 {
+			t.Fatalf("%d: inconsistent hash-to-curve vector", i)
+		}
+	}
+}
+
+func TestVectorsRFC9380(t *testing.T) {
+	data, err := os.ReadFile("h2c/secp256k1_XMD-SHA-256_SSWU_RO_.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rfc h2cVectors
+	if err = json.Unmarshal(data, &rfc); err != nil {
+		t.Fatal(err)
+	}
+
+	v := vectors.Generate(nil, len(rfc.Vectors))
+	if v.DST != rfc.Dst {
+		t.Fatal(errExpectedEquality)
+	}
+
+	for i, expected := range rfc.Vectors {
+		h := v.HashToCurve[i]
+
+		if h.Message != hex.EncodeToString([]byte(expected.Msg)) ||
+			"0x"+h.U0 != expected.U[0] || "0x"+h.U1 != expected.U[1] ||
+			h.Q0 != hex.EncodeToString(vectorToSecp256k1(expected.Q0.X, expected.Q0.Y)) ||
+			h.Q1 != hex.EncodeToString(vectorToSecp256k1(expected.Q1.X, expected.Q1.Y)) ||
+			h.P != hex.EncodeToString(vectorToSecp256k1(expected.P.X, expected.P.Y)) {
+			t.Fatalf("%d: unexpected hash-to-curve vector", i)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+	"github.com/bytemare/secp256k1/vrf"
+)
+
+func TestVRF(t *testing.T) {
+	k := newTestKey(t)
+	alpha := []byte("sample")
+
+	proof := vrf.Prove(k, alpha)
+	if len(proof) != vrf.ProofLength {
+		t.Fatalf("unexpected proof length %d", len(proof))
+	}
+
+	if !bytes.Equal(proof, vrf.Prove(k, alpha)) {
+		t.Fatal("expected deterministic proofs")
+	}
+
+	beta, err := vrf.Verify(k.PublicKey(), alpha, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := vrf.ProofToHash(proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(beta, hash) || len(beta) != vrf.OutputLength {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Other inputs yield other outputs.
+	other, err := vrf.ProofToHash(vrf.Prove(k, []byte("test")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(beta, other) {
+		t.Fatal("expected different outputs")
+	}
+}
+
+func TestVRF_Construction(t *testing.T) {
+	// Gamma is x * encode_to_curve(PK || alpha), and the output hashes it with the suite string 0xFE.
+	k := newTestKey(t)
+	alpha := []byte("sample")
+	dst := append([]byte("ECVRF_"+secp256k1.E2CSECP256K1), 0xfe)
+	h := secp256k1.EncodeToGroup(append(k.PublicKey().Bytes(), alpha...), dst)
+	gamma := h.Multiply(k.Scalar()).Encode()
+
+	proof := vrf.Prove(k, alpha)
+	if !bytes.Equal(proof[:33], gamma) {
+		t.Fatal("unexpected Gamma")
+	}
+
+	expected := sha256.Sum256(append(append([]byte{0xfe, 0x03}, gamma...), 0x00))
+
+	beta, err := vrf.ProofToHash(proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(beta, expected[:]) {
+		t.Fatal("unexpected output")
+	}
+}
+
+func TestVRF_Invalid(t *testing.T) {
+	k := newTestKey(t)
+	alpha := []byte("sample")
+	proof := vrf.Prove(k, alpha)
+
+	if _, err := vrf.Verify(newTestKey(t).PublicKey(), alpha, proof); !errors.Is(err, vrf.ErrInvalidProof) {
+		t.Fatal("expected another key to be rejected")
+	}
+
+	if _, err := vrf.Verify(k.PublicKey(), []byte("other"), proof); !errors.Is(err, vrf.ErrInvalidProof) {
+		t.Fatal("expected another input to be rejected")
+	}
+
+	if _, err := vrf.Verify(&secp256k1.PublicKey{}, alpha, proof); !errors.Is(err, vrf.ErrInvalidProof) {
+		t.Fatal("expected an invalid public key to be rejected")
+	}
+
+	for _, i := range []int{1, 40, vrf.ProofLength - 1} {
+		tampered := bytes.Clone(proof)
+		tampered[i] ^= 1
+
+		if _, err := vrf.Verify(k.PublicKey(), alpha, tampered); !errors.Is(err, vrf.ErrInvalidProof) {
+			t.Fatalf("expected a tampered proof at byte %d to be rejected", i)
+		}
+	}
+
+	if _, err := vrf.ProofToHash(proof[1:]); !errors.Is(err, vrf.ErrInvalidProof) {
+		t.Fatal("expected a short proof to be rejected")
+	}
+
+	// s must be canonical.
+	tampered := bytes.Clone(proof)
+	copy(tampered[49:], bytes.Repeat([]byte{0xff}, 32))
+
+	if _, err := vrf.ProofToHash(tampered); !errors.Is(err, vrf.ErrInvalidProof) {
+		t.Fatal("expected a non-canonical s to be rejected")
+	}
+
+	// Gamma must be a valid point.
+	tampered = bytes.Clone(proof)
+	tampered[0] = 0x04
+
+	if _, err := vrf.ProofToHash(tampered); !errors.Is(err, vrf.ErrInvalidProof) {
+		t.Fatal("expected an invalid Gamma to be rejected")
+	}
+}
@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package testutil provides checks of the algebraic invariants of secp256k1 scalars and elements, i.e. the group and
+// field laws and the encoding round trips, to be run on arbitrary values from downstream tests, e.g. on the
+// intermediate values of a protocol. Each check returns an error wrapping ErrInvariant that describes the first law
+// that doesn't hold, and nil otherwise. Check runs all of them on every combination of the given values.
+//
+// The checks only use the secp256k1 package's public API and never modify their arguments.
+package testutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// ErrInvariant indicates a violated invariant.
+var ErrInvariant = errors.New("invariant violated")
+
+func violated(law string) error {
+	return fmt.Errorf("%w: %s", ErrInvariant, law)
+}
+
+func equal(a, b *secp256k1.Element) bool {
+	return a.Equal(b) == 1
+}
+
+// CheckGroupLaws checks that the elements satisfy the group laws: associativity, commutativity, identity, inverse, and
+// the consistency of Double and Subtract with Add.
+func CheckGroupLaws(a, b, c *secp256k1.Element) error {
+	if !equal(a.Copy().Add(b).Add(c), a.Copy().Add(b.Copy().Add(c))) {
+		return violated("(a + b) + c = a + (b + c)")
+	}
+
+	if !equal(a.Copy().Add(b), b.Copy().Add(a)) {
+		return violated("a + b = b + a")
+	}
+
+	if !equal(a.Copy().Add(secp256k1.NewElement()), a) || !equal(secp256k1.NewElement().Add(a), a) {
+		return violated("a + 0 = 0 + a = a")
+	}
+
+	if !a.Copy().Add(a.Copy().Negate()).IsIdentity() || !a.Copy().Subtract(a).IsIdentity() {
+		return violated("a + (-a) = a - a = 0")
+	}
+
+	if !equal(a.Copy().Subtract(b), a.Copy().Add(b.Copy().Negate())) {
+		return violated("a - b = a + (-b)")
+	}
+
+	if !equal(a.Copy().Double(), a.Copy().Add(a)) {
+		return violated("2a = a + a")
+	}
+
+	return nil
+}
+
+// CheckScalarMultiplication checks that the multiplication of the element by scalars is distributive over both
+// scalar and element addition, compatible with scalar multiplication, and consistent with the identity, 0, 1, and
+// the base point multiplication.
+func CheckScalarMultiplication(e, f *secp256k1.Element, a, b *secp256k1.Scalar) error {
+	if !equal(e.Copy().Multiply(a.Copy().Add(b)), e.Copy().Multiply(a).Add(e.Copy().Multiply(b))) {
+		return violated("(a + b)e = ae + be")
+	}
+
+	if !equal(e.Copy().Add(f).Multiply(a), e.Copy().Multiply(a).Add(f.Copy().Multiply(a))) {
+		return violated("a(e + f) = ae + af")
+	}
+
+	if !equal(e.Copy().Multiply(a.Copy().Multiply(b)), e.Copy().Multiply(b).Multiply(a)) {
+		return violated("(ab)e = a(be)")
+	}
+
+	if !e.Copy().Multiply(secp256k1.NewScalar()).IsIdentity() || !secp256k1.NewElement().Multiply(a).IsIdentity() {
+		return violated("0e = a0 = 0")
+	}
+
+	if !equal(e.Copy().Multiply(secp256k1.NewScalar().One()), e) {
+		return violated("1e = e")
+	}
+
+	if !equal(e.Copy().Multiply(secp256k1.NewScalar().MinusOne()), e.Copy().Negate()) {
+		return violated("(-1)e = -e")
+	}
+
+	if !equal(secp256k1.ScalarBaseMult(a), secp256k1.Base().Multiply(a)) {
+		return violated("ScalarBaseMult(a) = aG")
+	}
+
+	return nil
+}
+
+// CheckFieldLaws checks that the scalars satisfy the laws of the scalar field: associativity, commutativity, and
+// distributivity of addition and multiplication, their identities, and their inverses.
+func CheckFieldLaws(a, b, c *secp256k1.Scalar) error {
+	if a.Copy().Add(b).Add(c).Equal(a.Copy().Add(b.Copy().Add(c))) != 1 ||
+		a.Copy().Multiply(b).Multiply(c).Equal(a.Copy().Multiply(b.Copy().Multiply(c))) != 1 {
+		return violated("scalar associativity")
+	}
+
+	if a.Copy().Add(b).Equal(b.Copy().Add(a)) != 1 || a.Copy().Multiply(b).Equal(b.Copy().Multiply(a)) != 1 {
+		return violated("scalar commutativity")
+	}
+
+	if a.Copy().Multiply(b.Copy().Add(c)).Equal(a.Copy().Multiply(b).Add(a.Copy().Multiply(c))) != 1 {
+		return violated("a(b + c) = ab + ac")
+	}
+
+	if a.Copy().Add(secp256k1.NewScalar()).Equal(a) != 1 ||
+		a.Copy().Multiply(secp256k1.NewScalar().One()).Equal(a) != 1 {
+		return violated("a + 0 = a * 1 = a")
+	}
+
+	if !a.Copy().Subtract(a).IsZero() || a.Copy().Subtract(b).Add(b).Equal(a) != 1 {
+		return violated("a - a = 0 and (a - b) + b = a")
+	}
+
+	if !a.IsZero() && a.Copy().Invert().Multiply(a).Equal(secp256k1.NewScalar().One()) != 1 {
+		return violated("a * 1/a = 1")
+	}
+
+	return nil
+}
+
+// CheckElementEncoding checks that the element survives its encoding and decoding round trips, and that the identity
+// is encoded as zeros.
+func CheckElementEncoding(e *secp256k1.Element) error {
+	enc := e.Encode()
+	if len(enc) != secp256k1.ElementLength() {
+		return violated("element encoding length")
+	}
+
+	d := secp256k1.NewElement()
+	if err := d.DecodeAllowIdentity(enc); err != nil || !equal(d, e) {
+		return violated("element encoding round trip")
+	}
+
+	text, err := e.MarshalText()
+	if err != nil {
+		return violated("element text encoding")
+	}
+
+	if err = d.UnmarshalText(text); err != nil || !equal(d, e) {
+		return violated("element text round trip")
+	}
+
+	if e.IsIdentity() {
+		if !bytes.Equal(enc, make([]byte, secp256k1.ElementLength())) {
+			return violated("identity encoding")
+		}
+
+		return nil
+	}
+
+	if !e.IsOnCurve() {
+		return violated("element on curve")
+	}
+
+	if err := d.DecodeHex(e.Hex()); err != nil || !equal(d, e) {
+		return violated("element hex round trip")
+	}
+
+	pub, err := secp256k1.NewPublicKey(e.EncodeUncompressed())
+	if err != nil || !equal(pub.Element(), e) {
+		return violated("element uncompressed encoding round trip")
+	}
+
+	return nil
+}
+
+// CheckScalarEncoding checks that the scalar survives its encoding and decoding round trips.
+func CheckScalarEncoding(s *secp256k1.Scalar) error {
+	enc := s.Encode()
+	if len(enc) != secp256k1.ScalarLength() {
+		return violated("scalar encoding length")
+	}
+
+	d := secp256k1.NewScalar()
+	if err := d.Decode(enc); err != nil || d.Equal(s) != 1 {
+		return violated("scalar encoding round trip")
+	}
+
+	if err := d.DecodeHex(s.Hex()); err != nil || d.Equal(s) != 1 {
+		return violated("scalar hex round trip")
+	}
+
+	if err := d.DecodeLE(s.EncodeLE()); err != nil || d.Equal(s) != 1 {
+		return violated("scalar little-endian round trip")
+	}
+
+	if d.SetBytesMod(enc).Equal(s) != 1 {
+		return violated("scalar reduction of a canonical encoding")
+	}
+
+	return nil
+}
+
+// Check runs all checks on the elements and scalars, and on every combination of them, and reports the violated
+// invariants to tb. The number of combinations grows with the fourth power of the number of values, and each involves
+// several scalar multiplications, so a handful of each is enough.
+func Check(tb testing.TB, elements []*secp256k1.Element, scalars []*secp256k1.Scalar) {
+	tb.Helper()
+
+	report := func(err error, format string, args ...any) {
+		tb.Helper()
+
+		if err != nil {
+			tb.Errorf("%v, for "+format, append([]any{err}, args...)...)
+		}
+	}
+
+	for i, e := range elements {
+		report(CheckElementEncoding(e), "element %d", i)
+
+		for j, f := range elements {
+			for k, g := range elements {
+				report(CheckGroupLaws(e, f, g), "elements %d, %d, %d", i, j, k)
+			}
+
+			for k, a := range scalars {
+				for l, b := range scalars {
+					report(CheckScalarMultiplication(e, f, a, b), "elements %d, %d and scalars %d, %d", i, j, k, l)
+				}
+			}
+		}
+	}
+
+	for i, a := range scalars {
+		report(CheckScalarEncoding(a), "scalar %d", i)
+
+		for j, b := range scalars {
+			for k, c := range scalars {
+				report(CheckFieldLaws(a, b, c), "scalars %d, %d, %d", i, j, k)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import "fmt"
+
+// The tweaking methods below mirror libsecp256k1's secp256k1_ec_seckey_tweak_add, secp256k1_ec_seckey_tweak_mul,
+// secp256k1_ec_pubkey_tweak_add and secp256k1_ec_pubkey_tweak_mul, as used e.g. in BIP-32 child key derivation and
+// Taproot output keys. Tweaking a private key and its public key with the same tweak yields a matching key pair:
+// (d + t) * G = d * G + t * G and (d * t) * G = t * (d * G). All of them leave the receiver untouched on error.
+
+// checkTweak returns an error wrapping ErrInvalidTweak if the tweak is nil, or zero when nonZero is set.
+func checkTweak(tweak *Scalar, nonZero bool) error {
+	if tweak == nil {
+		return fmt.Errorf("%w: nil tweak", ErrInvalidTweak)
+	}
+
+	if nonZero && tweak.IsZero() {
+		return fmt.Errorf("%w: zero tweak", ErrInvalidTweak)
+	}
+
+	return nil
+}
+
+// AddTweak sets the receiver, a non-zero private key scalar, to the sum of the receiver and the tweak. It returns an
+// error wrapping ErrInvalidPrivateKey if the receiver is zero, and one wrapping ErrInvalidTweak if the tweak is nil or
+// the result would be zero.
+func (s *Scalar) AddTweak(tweak *Scalar) error {
+	if err := checkTweak(tweak, false); err != nil {
+		return err
+	}
+
+	if s.IsZero() {
+		return fmt.Errorf("%w: zero scalar", ErrInvalidPrivateKey)
+	}
+
+	res := s.Copy().Add(tweak)
+	if res.IsZero() {
+		return fmt.Errorf("%w: the tweaked scalar is zero", ErrInvalidTweak)
+	}
+
+	s.Set(res)
+
+	return nil
+}
+
+// MulTweak sets the receiver, a non-zero private key scalar, to the product of the receiver and the tweak. It returns
+// an error wrapping ErrInvalidPrivateKey if the receiver is zero, and one wrapping ErrInvalidTweak if the tweak is nil
+// or zero.
+func (s *Scalar) MulTweak(tweak *Scalar) error {
+	if err := checkTweak(tweak, true); err != nil {
+		return err
+	}
+
+	if s.IsZero() {
+		return fmt.Errorf("%w: zero scalar", ErrInvalidPrivateKey)
+	}
+
+	s.Multiply(tweak)
+
+	return nil
+}
+
+// AddTweak sets the receiver, a public key element other than the identity, to the sum of the receiver and tweak * G.
+// It returns an error wrapping ErrIdentity if the receiver is the identity, and one wrapping ErrInvalidTweak if the
+// tweak is nil or the result would be the identity.
+func (e *Element) AddTweak(tweak *Scalar) error {
+	if err := checkTweak(tweak, false); err != nil {
+		return err
+	}
+
+	if e.IsIdentity() {
+		return fmt.Errorf("%w: cannot tweak the identity", ErrIdentity)
+	}
+
+	res := ScalarBaseMult(tweak).Add(e)
+	if res.IsIdentity() {
+		return fmt.Errorf("%w: %w", ErrInvalidTweak, ErrIdentity)
+	}
+
+	e.set(res)
+
+	return nil
+}
+
+// MulTweak sets the receiver, a public key element other than the identity, to the product of the receiver and the
+// tweak. It returns an error wrapping ErrIdentity if the receiver is the identity, and one wrapping ErrInvalidTweak if
+// the tweak is nil or zero.
+func (e *Element) MulTweak(tweak *Scalar) error {
+	if err := checkTweak(tweak, true); err != nil {
+		return err
+	}
+
+	if e.IsIdentity() {
+		return fmt.Errorf("%w: cannot tweak the identity", ErrIdentity)
+	}
+
+	e.Multiply(tweak)
+
+	return nil
+}
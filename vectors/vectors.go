@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package vectors generates known-answer test vectors for scalar arithmetic, point arithmetic, and hash-to-curve with
+// the secp256k1 package, so that other implementations can be validated against it programmatically. Vectors are
+// derived deterministically from a seed, and serialize to JSON with encoding/json. Scalars are encoded as 32-byte and
+// points as 33-byte compressed hexadecimal strings, with the identity encoded as zeros.
+package vectors
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"github.com/bytemare/secp256k1"
+)
+
+// DST is the domain separation tag used in hash-to-curve vectors, which is the one of the test vectors of RFC 9380.
+const DST = "QUUX-V01-CS02-with-" + secp256k1.H2CSECP256K1
+
+// seedDST is the domain separation tag used to derive the operands of the vectors from the seed.
+const seedDST = "github.com/bytemare/secp256k1/vectors:" + secp256k1.H2CSECP256K1
+
+// ScalarVector holds the results of the arithmetic operations on two scalars A and B. The inverse of zero is zero.
+type ScalarVector struct {
+	A          string `json:"a"`
+	B          string `json:"b"`
+	Sum        string `json:"sum"`
+	Difference string `json:"difference"`
+	Product    string `json:"product"`
+	Negation   string `json:"negation"`
+	Inverse    string `json:"inverse"`
+}
+
+// PointVector holds the results of the arithmetic operations on the points P = K * G and Q, with G the base point.
+type PointVector struct {
+	K          string `json:"k"`
+	P          string `json:"p"`
+	Q          string `json:"q"`
+	Sum        string `json:"sum"`
+	Difference string `json:"difference"`
+	Double     string `json:"double"`
+	Negation   string `json:"negation"`
+	Product    string `json:"product"` // K * Q
+}
+
+// HashToCurveVector holds the intermediate values and output of HashToGroup of the hex-encoded message with DST, as
+// specified in RFC 9380: the field elements U0 and U1, their maps Q0 and Q1 to the curve, and the output P = Q0 + Q1.
+type HashToCurveVector struct {
+	Message string `json:"msg"`
+	U0      string `json:"u0"`
+	U1      string `json:"u1"`
+	Q0      string `json:"q0"`
+	Q1      string `json:"q1"`
+	P       string `json:"p"`
+}
+
+// Vectors is a set of known-answer test vectors.
+type Vectors struct {
+	Ciphersuite string              `json:"ciphersuite"`
+	DST         string              `json:"dst"`
+	Scalars     []ScalarVector      `json:"scalars"`
+	Points      []PointVector       `json:"points"`
+	HashToCurve []HashToCurveVector `json:"hashToCurve"`
+}
+
+// operand returns the i-th scalar derived from the seed for the label.
+func operand(seed []byte, label string, i int) *secp256k1.Scalar {
+	input := binary.BigEndian.AppendUint32(append([]byte(label), seed...), uint32(i))
+	return secp256k1.HashToScalar(input, []byte(seedDST))
+}
+
+// rfcMessages are the messages of the hash-to-curve test vectors of RFC 9380.
+var rfcMessages = []string{
+	"",
+	"abc",
+	"abcdef0123456789",
+	"q128_" + strings.Repeat("q", 128),
+	"a512_" + strings.Repeat("a", 512),
+}
+
+// Generate returns n vectors of each kind, derived from the seed. The same seed always yields the same vectors. The
+// first scalar vector covers zero and one, the first point vector the identity, and the first hash-to-curve vectors
+// use the messages of RFC 9380 and match its test vectors.
+func Generate(seed []byte, n int) *Vectors {
+	v := &Vectors{
+		Ciphersuite: secp256k1.H2CSECP256K1,
+		DST:         DST,
+		Scalars:     make([]ScalarVector, n),
+		Points:      make([]PointVector, n),
+		HashToCurve: make([]HashToCurveVector, n),
+	}
+
+	for i := range n {
+		a, b := operand(seed, "a", i), operand(seed, "b", i)
+		k, q := operand(seed, "k", i), secp256k1.ScalarBaseMult(operand(seed, "q", i))
+
+		if i == 0 {
+			a.Zero()
+			b.One()
+			q.Identity()
+		}
+
+		v.Scalars[i] = scalarVector(a, b)
+		v.Points[i] = pointVector(k, q)
+
+		message := operand(seed, "msg", i).Encode()
+		if i < len(rfcMessages) {
+			message = []byte(rfcMessages[i])
+		}
+
+		v.HashToCurve[i] = hashToCurveVector(message)
+	}
+
+	return v
+}
+
+func scalarVector(a, b *secp256k1.Scalar) ScalarVector {
+	return ScalarVector{
+		A:          a.Hex(),
+		B:          b.Hex(),
+		Sum:        a.Copy().Add(b).Hex(),
+		Difference: a.Copy().Subtract(b).Hex(),
+		Product:    a.Copy().Multiply(b).Hex(),
+		Negation:   secp256k1.NewScalar().Subtract(a).Hex(),
+		Inverse:    a.Copy().Invert().Hex(),
+	}
+}
+
+func pointVector(k *secp256k1.Scalar, q *secp256k1.Element) PointVector {
+	p := secp256k1.ScalarBaseMult(k)
+
+	return PointVector{
+		K:          k.Hex(),
+		P:          p.Hex(),
+		Q:          q.Hex(),
+		Sum:        p.Copy().Add(q).Hex(),
+		Difference: p.Copy().Subtract(q).Hex(),
+		Double:     p.Copy().Double().Hex(),
+		Negation:   p.Copy().Negate().Hex(),
+		Product:    q.Copy().Multiply(k).Hex(),
+	}
+}
+
+func hashToCurveVector(message []byte) HashToCurveVector {
+	u := secp256k1.HashToFieldElements(message, []byte(DST), 2)
+	q0, q1 := secp256k1.MapToGroup(u[0]), secp256k1.MapToGroup(u[1])
+
+	return HashToCurveVector{
+		Message: hex.EncodeToString(message),
+		U0:      hex.EncodeToString(u[0][:]),
+		U1:      hex.EncodeToString(u[1][:]),
+		Q0:      q0.Hex(),
+		Q1:      q1.Hex(),
+		P:       q0.Add(q1).Hex(),
+	}
+}
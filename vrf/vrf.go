@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2023 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package vrf implements the ECVRF verifiable random function of RFC 9381 over secp256k1, on top of the secp256k1
+// package's arithmetic. The holder of a private key computes a proof for an input with Prove, from which anyone derives
+// the pseudorandom output with ProofToHash, and which anyone holding the public key checks with Verify. The output is
+// unique for each key and input, and indistinguishable from random without the proof.
+//
+// RFC 9381 does not define a secp256k1 suite. This package follows the ECVRF-P256-SHA256-SSWU suite, substituting the
+// curve: points are encoded in compressed form, the challenge is truncated to 16 bytes, nonces are generated as in RFC
+// 6979, and inputs are mapped to the curve with the secp256k1_XMD:SHA-256_SSWU_NU_ encode-to-curve suite of RFC 9380.
+// Its suite string is the private-use value 0xFE.
+package vrf
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/secp256k1"
+)
+
+const (
+	// ProofLength is the byte size of a proof, i.e. Gamma || c || s.
+	ProofLength = pointLength + challengeLength + scalarLength
+
+	// OutputLength is the byte size of the VRF output.
+	OutputLength = sha256.Size
+
+	// suiteString identifies the ciphersuite in all hashes.
+	suiteString = 0xfe
+
+	pointLength     = 33
+	challengeLength = 16
+	scalarLength    = 32
+
+	domainSeparatorChallenge = 0x02
+	domainSeparatorOutput    = 0x03
+	domainSeparatorBack      = 0x00
+)
+
+// ErrInvalidProof indicates a proof that is malformed or does not verify.
+var ErrInvalidProof = errors.New("invalid VRF proof")
+
+// encodeDST is the domain separation tag of the encode-to-curve step.
+var encodeDST = append([]byte("ECVRF_"+secp256k1.E2CSECP256K1), suiteString)
+
+// encodeToCurve maps the input to the curve for the public key, as ECVRF_encode_to_curve_h2c_suite.
+func encodeToCurve(pub *secp256k1.PublicKey, alpha []byte) *secp256k1.Element {
+	return secp256k1.EncodeToGroup(append(pub.Bytes(), alpha...), encodeDST)
+}
+
+// challenge returns the truncated challenge hash of the points, as ECVRF_challenge_generation.
+func challenge(points ...*secp256k1.Element) []byte {
+	h := sha256.New()
+	h.Write([]byte{suiteString, domainSeparatorChallenge})
+
+	for _, p := range points {
+		h.Write(p.Encode())
+	}
+
+	h.Write([]byte{domainSeparatorBack})
+
+	return h.Sum(nil)[:challengeLength]
+}
+
+// Prove returns the 81-byte proof of the VRF output for the private key and the input alpha. It is deterministic, and
+// the nonce is derived from the private key and the input as specified in RFC 6979.
+func Prove(key *secp256k1.PrivateKey, alpha []byte) []byte {
+	pub := key.PublicKey()
+	h := encodeToCurve(pub, alpha)
+	hString := h.Encode()
+	x := key.Scalar()
+
+	defer x.Zero()
+
+	h1 := sha256.Sum256(hString)
+
+	k, err := secp256k1.DeriveScalarRFC6979(key.Bytes(), h1[:], nil)
+	if err != nil {
+		// A private key always encodes a valid non-zero scalar.
+		panic(err)
+	}
+
+	defer k.Zero()
+
+	gamma := h.Copy().Multiply(x)
+	c := challenge(pub.Element(), h, gamma, secp256k1.ScalarBaseMult(k), h.Copy().Multiply(k))
+	s := secp256k1.NewScalar().SetBytesMod(c).Multiply(x).Add(k)
+
+	proof := make([]byte, 0, ProofLength)
+	proof = append(proof, gamma.Encode()...)
+	proof = append(proof, c...)
+
+	return append(proof, s.Encode()...)
+}
+
+// decodeProof returns the components of the proof, or an error wrapping ErrInvalidProof if it is malformed.
+func decodeProof(proof []byte) (gamma *secp256k1.Element, c []byte, s *secp256k1.Scalar, err error) {
+	if len(proof) != ProofLength {
+		return nil, nil, nil, fmt.Errorf("%w: invalid length %d", ErrInvalidProof, len(proof))
+	}
+
+	gamma = secp256k1.NewElement()
+	if err = gamma.Decode(proof[:pointLength]); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	s = secp256k1.NewScalar()
+	if err = s.Decode(proof[pointLength+challengeLength:]); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	return gamma, proof[pointLength : pointLength+challengeLength], s, nil
+}
+
+// outputHash returns the VRF output of the point Gamma, as ECVRF_proof_to_hash. The cofactor is 1.
+func outputHash(gamma *secp256k1.Element) []byte {
+	h := sha256.New()
+	h.Write([]byte{suiteString, domainSeparatorOutput})
+	h.Write(gamma.Encode())
+	h.Write([]byte{domainSeparatorBack})
+
+	return h.Sum(nil)
+}
+
+// ProofToHash returns the 32-byte VRF output of the proof, or an error wrapping ErrInvalidProof if it is malformed.
+// It does not verify the proof, so the output must only be trusted once Verify succeeded, which returns the same value.
+func ProofToHash(proof []byte) ([]byte, error) {
+	gamma, _, _, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return outputHash(gamma), nil
+}
+
+// Verify returns the VRF output if the proof is valid for the public key and the input alpha, and an error wrapping
+// ErrInvalidProof otherwise.
+func Verify(pub *secp256k1.PublicKey, alpha, proof []byte) ([]byte, error) {
+	if pub == nil || pub.Element().IsIdentity() {
+		return nil, fmt.Errorf("%w: invalid public key", ErrInvalidProof)
+	}
+
+	gamma, c, s, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	y := pub.Element()
+	h := encodeToCurve(pub, alpha)
+	negC := secp256k1.NewScalar().Subtract(secp256k1.NewScalar().SetBytesMod(c))
+
+	u := secp256k1.DoubleScalarBaseMultVartime(s, negC, y)
+	v := secp256k1.MultiScalarMultVartime([]*secp256k1.Scalar{s, negC}, []*secp256k1.Element{h, gamma})
+
+	if subtle.ConstantTimeCompare(c, challenge(y, h, gamma, u, v)) != 1 {
+		return nil, ErrInvalidProof
+	}
+
+	return outputHash(gamma), nil
+}